@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.coldstart) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/coldstartconnector"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.coldstart", "github.com/open-telemetry/opentelemetry-lambda/collector/common/coldstartconnector", "coldstart", func(extensionId string) connector.Factory {
+		return coldstartconnector.NewFactory()
+	})
+}
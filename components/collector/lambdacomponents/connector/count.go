@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.count)
+
+package connector
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/countconnector"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.count", "github.com/open-telemetry/opentelemetry-collector-contrib/connector/countconnector", "count", func(extensionId string) connector.Factory {
+		return countconnector.NewFactory()
+	})
+}
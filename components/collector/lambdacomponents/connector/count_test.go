@@ -0,0 +1,45 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.count)
+
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestCountSpans(t *testing.T) {
+	f := factory(t, "count", "")
+	sink := new(consumertest.MetricsSink)
+	conn, err := f.CreateTracesToMetrics(context.Background(), settings(f), f.CreateDefaultConfig(), sink)
+	if err != nil {
+		t.Fatalf("CreateTracesToMetrics() = %v", err)
+	}
+	if err := conn.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for range 3 {
+		spans.AppendEmpty()
+	}
+	if err := conn.ConsumeTraces(context.Background(), td); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+
+	metrics := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := range metrics.Len() {
+		if m := metrics.At(i); m.Name() == "trace.span.count" {
+			if got := m.Sum().DataPoints().At(0).IntValue(); got != 3 {
+				t.Errorf("trace.span.count = %d, want 3", got)
+			}
+			return
+		}
+	}
+	t.Error("no trace.span.count metric")
+}
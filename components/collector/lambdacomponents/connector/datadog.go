@@ -0,0 +1,26 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.datadog) && !lambdacomponents.metricsonly && !lambdacomponents.core
+
+// Like the Datadog exporter, the connector depends on the Datadog agent
+// libraries, so the lambdacomponents.core profile leaves it out.
+
+package connector
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/datadogconnector"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.datadog", "github.com/open-telemetry/opentelemetry-collector-contrib/connector/datadogconnector", "datadog", func(extensionId string) connector.Factory {
+		// Stats are computed in time buckets and only emitted once a bucket is
+		// closed. Stopping the connector flushes the open buckets, and short
+		// buckets keep what is waiting for it small when the environment is
+		// frozen between invocations.
+		return defaults.Connector(datadogconnector.NewFactory(), func(cfg *datadogconnector.Config) {
+			cfg.Traces.BucketInterval = 2 * time.Second
+		})
+	})
+}
@@ -0,0 +1,17 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.datadog) && !lambdacomponents.metricsonly && !lambdacomponents.core
+
+package connector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/datadogconnector"
+)
+
+func TestDatadogBucketInterval(t *testing.T) {
+	cfg := factory(t, "datadog", "").CreateDefaultConfig().(*datadogconnector.Config)
+	if cfg.Traces.BucketInterval != 2*time.Second {
+		t.Errorf("stats bucket = %v, want 2s", cfg.Traces.BucketInterval)
+	}
+}
@@ -0,0 +1,65 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.durationrouting) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+var durationRoutingType = component.MustNewType("durationrouting")
+
+func init() {
+	Register("lambdacomponents.connector.durationrouting", "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector", "durationrouting", func(extensionId string) connector.Factory {
+		routing := routingconnector.NewFactory()
+		return connector.NewFactory(
+			durationRoutingType,
+			func() component.Config { return &durationRoutingConfig{Threshold: time.Second} },
+			connector.WithTracesToTraces(func(ctx context.Context, set connector.Settings, cfg component.Config, next consumer.Traces) (connector.Traces, error) {
+				return routing.CreateTracesToTraces(ctx, set, cfg.(*durationRoutingConfig).routingConfig(routing), next)
+			}, routing.TracesToTracesStability()),
+		)
+	})
+}
+
+// durationRoutingConfig configures a routing connector that sends the spans
+// lasting longer than Threshold to SlowPipelines and the others to
+// DefaultPipelines.
+type durationRoutingConfig struct {
+	Threshold        time.Duration `mapstructure:"threshold"`
+	SlowPipelines    []pipeline.ID `mapstructure:"slow_pipelines"`
+	DefaultPipelines []pipeline.ID `mapstructure:"default_pipelines"`
+}
+
+func (cfg *durationRoutingConfig) Validate() error {
+	if cfg.Threshold <= 0 {
+		return errors.New("threshold must be greater than zero")
+	}
+	if len(cfg.SlowPipelines) == 0 {
+		return errors.New("slow_pipelines must list at least one pipeline")
+	}
+	return nil
+}
+
+// routingConfig returns the routing connector configuration with a single
+// route, matching spans by duration.
+func (cfg *durationRoutingConfig) routingConfig(routing connector.Factory) *routingconnector.Config {
+	routingCfg := routing.CreateDefaultConfig().(*routingconnector.Config)
+	routingCfg.ErrorMode = ottl.IgnoreError
+	routingCfg.DefaultPipelines = cfg.DefaultPipelines
+	routingCfg.Table = []routingconnector.RoutingTableItem{{
+		Context:   "span",
+		Condition: fmt.Sprintf("span.end_time_unix_nano - span.start_time_unix_nano > %d", cfg.Threshold.Nanoseconds()),
+		Pipelines: cfg.SlowPipelines,
+	}}
+	return routingCfg
+}
@@ -0,0 +1,79 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.durationrouting) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// spanNames returns the names of the spans the sink received.
+func spanNames(sink *consumertest.TracesSink) []string {
+	var names []string
+	for _, td := range sink.AllTraces() {
+		for i := range td.ResourceSpans().Len() {
+			for j := range td.ResourceSpans().At(i).ScopeSpans().Len() {
+				spans := td.ResourceSpans().At(i).ScopeSpans().At(j).Spans()
+				for k := range spans.Len() {
+					names = append(names, spans.At(k).Name())
+				}
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestDurationRouting(t *testing.T) {
+	slowID := pipeline.NewIDWithName(pipeline.SignalTraces, "slow")
+	defaultID := pipeline.NewIDWithName(pipeline.SignalTraces, "default")
+	slow, other := new(consumertest.TracesSink), new(consumertest.TracesSink)
+
+	f := factory(t, "durationrouting", "")
+	cfg := f.CreateDefaultConfig().(*durationRoutingConfig)
+	cfg.SlowPipelines = []pipeline.ID{slowID}
+	cfg.DefaultPipelines = []pipeline.ID{defaultID}
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{slowID: slow, defaultID: other})
+	conn, err := f.CreateTracesToTraces(context.Background(), settings(f), cfg, router)
+	if err != nil {
+		t.Fatalf("CreateTracesToTraces() = %v", err)
+	}
+	if err := conn.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Shutdown(context.Background()) })
+
+	start := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for name, duration := range map[string]time.Duration{
+		"fast":         10 * time.Millisecond,
+		"at threshold": time.Second,
+		"slow":         2 * time.Second,
+	} {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(duration)))
+	}
+	if err := conn.ConsumeTraces(context.Background(), td); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+
+	if got, want := spanNames(slow), []string{"slow"}; !slices.Equal(got, want) {
+		t.Errorf("slow pipeline spans = %v, want %v", got, want)
+	}
+	if got, want := spanNames(other), []string{"at threshold", "fast"}; !slices.Equal(got, want) {
+		t.Errorf("default pipeline spans = %v, want %v", got, want)
+	}
+}
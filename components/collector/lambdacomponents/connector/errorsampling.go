@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.errorsampling) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/errorsamplingconnector"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.errorsampling", "github.com/open-telemetry/opentelemetry-lambda/collector/common/errorsamplingconnector", "errorsampling", func(extensionId string) connector.Factory {
+		return errorsamplingconnector.NewFactory()
+	})
+}
@@ -0,0 +1,21 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.errorsampling) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/errorsamplingconnector"
+	"go.opentelemetry.io/collector/component"
+)
+
+// The sampling itself is tested with the connector.
+func TestErrorSamplingRegistered(t *testing.T) {
+	f := factory(t, "errorsampling", "")
+	if _, ok := f.CreateDefaultConfig().(*errorsamplingconnector.Config); !ok {
+		t.Errorf("default config = %T, want the errorsampling connector's", f.CreateDefaultConfig())
+	}
+	if f.TracesToTracesStability() == component.StabilityLevelUndefined {
+		t.Error("connector doesn't connect traces to traces")
+	}
+}
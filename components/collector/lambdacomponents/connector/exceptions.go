@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.exceptions) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.exceptions", "github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector", "exceptions", func(extensionId string) connector.Factory {
+		return exceptionsconnector.NewFactory()
+	})
+}
@@ -0,0 +1,42 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.exceptions) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestExceptionsLogs(t *testing.T) {
+	f := factory(t, "exceptions", "")
+	sink := new(consumertest.LogsSink)
+	conn, err := f.CreateTracesToLogs(context.Background(), settings(f), f.CreateDefaultConfig(), sink)
+	if err != nil {
+		t.Fatalf("CreateTracesToLogs() = %v", err)
+	}
+	if err := conn.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("ok")
+	failed := spans.AppendEmpty()
+	failed.SetName("failed")
+	failed.Status().SetCode(ptrace.StatusCodeError)
+	event := failed.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().PutStr("exception.type", "TimeoutError")
+	if err := conn.ConsumeTraces(context.Background(), td); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+
+	if got := sink.LogRecordCount(); got != 1 {
+		t.Errorf("%d exception logs, want 1", got)
+	}
+}
@@ -0,0 +1,23 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.failover)
+
+package connector
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.failover", "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector", "failover", func(extensionId string) connector.Factory {
+		// The upstream intervals are measured in minutes, longer than most
+		// invocations. Retry the higher priority pipelines within seconds so
+		// a recovered primary is picked up during the next warm invocation.
+		return defaults.Connector(failoverconnector.NewFactory(), func(cfg *failoverconnector.Config) {
+			cfg.RetryInterval = 10 * time.Second
+			cfg.RetryGap = 2 * time.Second
+		})
+	})
+}
@@ -0,0 +1,20 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.failover)
+
+package connector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+)
+
+func TestFailoverDefaults(t *testing.T) {
+	cfg := factory(t, "failover", "").CreateDefaultConfig().(*failoverconnector.Config)
+	if cfg.RetryInterval != 10*time.Second {
+		t.Errorf("retry interval = %v, want 10s", cfg.RetryInterval)
+	}
+	if cfg.RetryGap != 2*time.Second {
+		t.Errorf("retry gap = %v, want 2s", cfg.RetryGap)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.forward)
+
+package connector
+
+import (
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/forwardconnector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.forward", "go.opentelemetry.io/collector/connector/forwardconnector", "forward", func(extensionId string) connector.Factory {
+		return forwardconnector.NewFactory()
+	})
+}
@@ -0,0 +1,44 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.forward)
+
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestForwardFanOut(t *testing.T) {
+	primaryID := pipeline.NewIDWithName(pipeline.SignalTraces, "primary")
+	archiveID := pipeline.NewIDWithName(pipeline.SignalTraces, "archive")
+	primary, archive := new(consumertest.TracesSink), new(consumertest.TracesSink)
+
+	f := factory(t, "forward", "")
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{primaryID: primary, archiveID: archive})
+	conn, err := f.CreateTracesToTraces(context.Background(), settings(f), f.CreateDefaultConfig(), router)
+	if err != nil {
+		t.Fatalf("CreateTracesToTraces() = %v", err)
+	}
+	if err := conn.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	if err := conn.ConsumeTraces(context.Background(), td); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+
+	for name, sink := range map[string]*consumertest.TracesSink{"primary": primary, "archive": archive} {
+		if got := sink.SpanCount(); got != 1 {
+			t.Errorf("%s pipeline received %d spans, want 1", name, got)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.grafanacloud) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/grafanacloudconnector"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.grafanacloud", "github.com/open-telemetry/opentelemetry-collector-contrib/connector/grafanacloudconnector", "grafanacloud", func(extensionId string) connector.Factory {
+		return grafanacloudconnector.NewFactory()
+	})
+}
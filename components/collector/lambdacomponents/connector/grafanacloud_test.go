@@ -0,0 +1,32 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.grafanacloud) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestGrafanaCloudTracesToMetrics(t *testing.T) {
+	f := factory(t, "grafanacloud", "")
+	conn, err := f.CreateTracesToMetrics(context.Background(), settings(f), f.CreateDefaultConfig(), new(consumertest.MetricsSink))
+	if err != nil {
+		t.Fatalf("CreateTracesToMetrics() = %v", err)
+	}
+	if err := conn.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("host.id", "2024/03/05/[$LATEST]0123456789abcdef")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	if err := conn.ConsumeTraces(context.Background(), td); err != nil {
+		t.Errorf("ConsumeTraces() = %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.logtometrics) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/logtometricsconnector"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.logtometrics", "github.com/open-telemetry/opentelemetry-lambda/collector/common/logtometricsconnector", "logtometrics", func(extensionId string) connector.Factory {
+		return logtometricsconnector.NewFactory()
+	})
+}
@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.ottlspanmetrics) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/ottlspanmetricsconnector"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.ottlspanmetrics", "github.com/open-telemetry/opentelemetry-lambda/collector/common/ottlspanmetricsconnector", "ottlspanmetrics", func(extensionId string) connector.Factory {
+		return ottlspanmetricsconnector.NewFactory()
+	})
+}
@@ -0,0 +1,18 @@
+//go:build lambdacomponents.custom
+
+package connector
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/registry"
+	"go.opentelemetry.io/collector/connector"
+)
+
+// Registry builds and describes the connectors in Factories. Build is the
+// supported way to read the registrations; Factories is kept for existing
+// callers.
+var Registry = registry.NewKind("connector", &Factories)
+
+// Register adds a connector to Factories. See registry.Kind.Register.
+func Register(tag, module, typ string, newFactory func(extensionId string) connector.Factory) {
+	Registry.Register(tag, module, typ, newFactory)
+}
@@ -0,0 +1,20 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.routing)
+
+package connector
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.routing", "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector", "routing", func(extensionId string) connector.Factory {
+		// A route condition that fails to evaluate counts as no match, so the
+		// data goes to `default_pipelines` instead of failing the whole batch.
+		return defaults.Connector(routingconnector.NewFactory(), func(cfg *routingconnector.Config) {
+			cfg.ErrorMode = ottl.IgnoreError
+		})
+	})
+}
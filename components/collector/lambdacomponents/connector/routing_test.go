@@ -0,0 +1,19 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.routing)
+
+package connector
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TestRoutingDefaults(t *testing.T) {
+	cfg := factory(t, "routing", "").CreateDefaultConfig().(*routingconnector.Config)
+	// A condition that fails to evaluate sends the data to the default
+	// pipelines rather than failing the batch.
+	if cfg.ErrorMode != ottl.IgnoreError {
+		t.Errorf("error mode = %q, want %q", cfg.ErrorMode, ottl.IgnoreError)
+	}
+}
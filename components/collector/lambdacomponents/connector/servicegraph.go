@@ -0,0 +1,23 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.servicegraph) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.servicegraph", "github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector", "servicegraph", func(extensionId string) connector.Factory {
+		// Incomplete edges are kept in memory until their pair arrives. Expire
+		// them quickly and cap the store so edges from a request interrupted by
+		// a freeze don't accumulate across warm invocations.
+		return defaults.Connector(servicegraphconnector.NewFactory(), func(cfg *servicegraphconnector.Config) {
+			cfg.Store.TTL = time.Second
+			cfg.Store.MaxItems = 500
+		})
+	})
+}
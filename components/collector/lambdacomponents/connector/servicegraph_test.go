@@ -0,0 +1,20 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.servicegraph) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector"
+)
+
+func TestServiceGraphDefaults(t *testing.T) {
+	cfg := factory(t, "servicegraph", "").CreateDefaultConfig().(*servicegraphconnector.Config)
+	if cfg.Store.TTL != time.Second {
+		t.Errorf("incomplete edges expire after %v, want 1s", cfg.Store.TTL)
+	}
+	if cfg.Store.MaxItems != 500 {
+		t.Errorf("store holds %d edges, want 500", cfg.Store.MaxItems)
+	}
+}
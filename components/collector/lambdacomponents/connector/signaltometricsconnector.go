@@ -8,7 +8,7 @@ import (
 )
 
 func init() {
-	Factories = append(Factories, func(extensionId string) connector.Factory {
+	Register("lambdacomponents.connector.signaltometrics", "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector", "signaltometrics", func(extensionId string) connector.Factory {
 		return signaltometricsconnector.NewFactory()
 	})
 }
@@ -1,4 +1,4 @@
-//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.spaneventtolog)
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.spaneventtolog) && !lambdacomponents.metricsonly
 
 package connector
 
@@ -8,7 +8,7 @@ import (
 )
 
 func init() {
-	Factories = append(Factories, func(extensionId string) connector.Factory {
+	Register("lambdacomponents.connector.spaneventtolog", "github.com/dev7a/otelcol-con-spaneventtolog", "spaneventtolog", func(extensionId string) connector.Factory {
 		return spaneventtologconnector.NewFactory()
 	})
 }
@@ -0,0 +1,23 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.spanmetrics) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.spanmetrics", "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector", "spanmetrics", func(extensionId string) connector.Factory {
+		// The caches outlive invocations while the environment stays warm, and
+		// the flush timer doesn't fire while it is frozen.
+		return defaults.Connector(spanmetricsconnector.NewFactory(), func(cfg *spanmetricsconnector.Config) {
+			cfg.DimensionsCacheSize = 500
+			cfg.ResourceMetricsCacheSize = 100
+			cfg.MetricsFlushInterval = time.Second
+		})
+	})
+}
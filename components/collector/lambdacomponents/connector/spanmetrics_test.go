@@ -0,0 +1,20 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.spanmetrics) && !lambdacomponents.metricsonly
+
+package connector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
+)
+
+func TestSpanMetricsDefaults(t *testing.T) {
+	cfg := factory(t, "spanmetrics", "").CreateDefaultConfig().(*spanmetricsconnector.Config)
+	if cfg.DimensionsCacheSize != 500 || cfg.ResourceMetricsCacheSize != 100 {
+		t.Errorf("caches hold %d dimensions and %d resources, want 500 and 100", cfg.DimensionsCacheSize, cfg.ResourceMetricsCacheSize)
+	}
+	if cfg.MetricsFlushInterval != time.Second {
+		t.Errorf("flush interval = %v, want 1s", cfg.MetricsFlushInterval)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.temporalitysplit)
+
+package connector
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/temporalitysplitconnector"
+	"go.opentelemetry.io/collector/connector"
+)
+
+func init() {
+	Register("lambdacomponents.connector.temporalitysplit", "github.com/open-telemetry/opentelemetry-lambda/collector/common/temporalitysplitconnector", "temporalitysplit", func(extensionId string) connector.Factory {
+		return temporalitysplitconnector.NewFactory()
+	})
+}
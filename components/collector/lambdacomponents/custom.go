@@ -0,0 +1,61 @@
+//go:build lambdacomponents.custom
+
+package lambdacomponents
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/assembly"
+	"go.opentelemetry.io/collector/otelcol"
+)
+
+// The process's exit and output, replaced by tests.
+var (
+	exit             = os.Exit
+	stdout io.Writer = os.Stdout
+	stderr io.Writer = os.Stderr
+)
+
+// Components returns the factories of the components compiled into this layer.
+// This file replaces the upstream custom.go during the build, so the
+// registrations are checked and assembled by the assembly package rather than
+// read from the raw Factories slices. The error reports, among others,
+// components registered more than once under the same type and factories that
+// panic when they are created. When the configuration is a local file, only
+// the factories of the components it declares are constructed.
+//
+// When OCELOT_DRY_RUN is set, Components validates the registrations and the
+// configuration, prints its resolved pipelines and exits instead of
+// returning, so the collector never starts.
+func Components(extensionID string) (otelcol.Factories, error) {
+	if assembly.DryRunRequested() {
+		exit(dryRun(extensionID))
+	}
+	if cfg, ok := assembly.LocalConfig(); ok {
+		return assembly.BuildForConfig(extensionID, cfg)
+	}
+	return assembly.Build(extensionID)
+}
+
+// dryRun checks the local configuration and writes its resolved pipelines to
+// stdout, returning the exit code of the process.
+func dryRun(extensionID string) int {
+	cfg, ok := assembly.LocalConfig()
+	if !ok {
+		fmt.Fprintf(stderr, "%s needs a local configuration file that declares its components inline, not %s\n", assembly.DryRunEnvVar, assembly.ConfigLocation())
+		return 1
+	}
+	// Every compiled-in component is checked, not only those the
+	// configuration uses, so a broken layer is caught before it is deployed.
+	err := assembly.Validate(extensionID)
+	if err == nil {
+		err = assembly.DryRun(extensionID, cfg, stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: invalid configuration %s: %v\n", assembly.DryRunEnvVar, assembly.ConfigLocation(), err)
+		return 1
+	}
+	return 0
+}
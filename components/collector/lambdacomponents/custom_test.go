@@ -0,0 +1,771 @@
+//go:build lambdacomponents.custom
+
+package lambdacomponents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/assembly"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/buildhash"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/exporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/processor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	otelexporter "go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	otelprocessor "go.opentelemetry.io/collector/processor"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type fakeConfig struct{}
+
+// registerProcessor registers a processor of the given type under tag for the
+// duration of the test. It returns the number of times its factory has been
+// constructed.
+func registerProcessor(t *testing.T, tag, typ string) *int {
+	t.Helper()
+	saved := slices.Clone(processor.Factories)
+	t.Cleanup(func() { processor.Factories = saved })
+	constructed := new(int)
+	processor.Register(tag, "example.com/"+typ, typ, func(string) otelprocessor.Factory {
+		*constructed++
+		return otelprocessor.NewFactory(component.MustNewType(typ), func() component.Config { return &fakeConfig{} })
+	})
+	return constructed
+}
+
+type fakeExporterConfig struct {
+	Endpoint   string           `mapstructure:"endpoint"`
+	S3Uploader fakeUploadConfig `mapstructure:"s3uploader"`
+}
+
+type fakeUploadConfig struct {
+	S3Bucket string `mapstructure:"s3_bucket"`
+}
+
+// fakeBackend receives the traces exported by the exporters of a fake
+// exporter type.
+type fakeBackend struct {
+	mu       sync.Mutex
+	err      error
+	attempts int
+	traces   []ptrace.Traces
+	configs  []*fakeExporterConfig
+}
+
+func (b *fakeBackend) setErr(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.err = err
+}
+
+func (b *fakeBackend) exportAttempts() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.attempts
+}
+
+func (b *fakeBackend) received() []ptrace.Traces {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Clone(b.traces)
+}
+
+type fakeTraces struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
+
+// registerExporter registers a traces exporter of the given type for the
+// duration of the test, exporting to the returned backend.
+func registerExporter(t *testing.T, typ string) *fakeBackend {
+	t.Helper()
+	saved := slices.Clone(exporter.Factories)
+	t.Cleanup(func() { exporter.Factories = saved })
+	backend := &fakeBackend{}
+	createTraces := func(_ context.Context, _ otelexporter.Settings, cfg component.Config) (otelexporter.Traces, error) {
+		backend.mu.Lock()
+		backend.configs = append(backend.configs, cfg.(*fakeExporterConfig))
+		backend.mu.Unlock()
+		next, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+			backend.mu.Lock()
+			defer backend.mu.Unlock()
+			backend.attempts++
+			if backend.err != nil {
+				return backend.err
+			}
+			backend.traces = append(backend.traces, td)
+			return nil
+		})
+		return fakeTraces{Traces: next}, err
+	}
+	exporter.Register("lambdacomponents.exporter."+typ, "example.com/"+typ, typ, func(string) otelexporter.Factory {
+		return otelexporter.NewFactory(component.MustNewType(typ),
+			func() component.Config { return &fakeExporterConfig{} },
+			otelexporter.WithTraces(createTraces, component.StabilityLevelStable))
+	})
+	return backend
+}
+
+func exporterSettings(typ string, logger *zap.Logger) otelexporter.Settings {
+	return otelexporter.Settings{
+		ID: component.NewID(component.MustNewType(typ)),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         logger,
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+}
+
+// startTraces creates and starts a traces exporter of the given type from the
+// factories, with cfg applied to its default configuration.
+func startTraces(t *testing.T, factories otelcol.Factories, typ string, cfg *fakeExporterConfig, logger *zap.Logger) (otelexporter.Traces, error) {
+	t.Helper()
+	f, ok := factories.Exporters[component.MustNewType(typ)]
+	if !ok {
+		t.Fatalf("exporter %q is missing from the factories", typ)
+	}
+	defaultCfg := f.CreateDefaultConfig().(*fakeExporterConfig)
+	if cfg != nil {
+		*defaultCfg = *cfg
+	}
+	exp, err := f.CreateTraces(context.Background(), exporterSettings(typ, logger), defaultCfg)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+	return exp, exp.Start(context.Background(), nil)
+}
+
+func newTraces(spans int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for range spans {
+		ss.Spans().AppendEmpty()
+	}
+	return td
+}
+
+func TestComponents(t *testing.T) {
+	tests := []struct {
+		name     string
+		register map[string]string // build tag to component type
+		wantErr  string
+	}{
+		{
+			name:     "distinct types",
+			register: map[string]string{"lambdacomponents.processor.first": "first", "lambdacomponents.processor.second": "second"},
+		},
+		{
+			name:     "colliding types",
+			register: map[string]string{"lambdacomponents.processor.first": "fake", "lambdacomponents.processor.second": "fake"},
+			wantErr:  `processor "fake" is registered 2 times, by build tags lambdacomponents.processor.first, lambdacomponents.processor.second`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for tag, typ := range tt.register {
+				registerProcessor(t, tag, typ)
+			}
+			factories, err := Components("extension-id")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Components() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Components() = %v", err)
+			}
+			for _, typ := range tt.register {
+				if _, ok := factories.Processors[component.MustNewType(typ)]; !ok {
+					t.Errorf("Components() is missing processor %q", typ)
+				}
+			}
+		})
+	}
+}
+
+func TestComponentsSnapshotsFactories(t *testing.T) {
+	saved := slices.Clone(processor.Factories)
+	t.Cleanup(func() { processor.Factories = saved })
+	newFactory := func(typ string) func(string) otelprocessor.Factory {
+		return func(string) otelprocessor.Factory {
+			return otelprocessor.NewFactory(component.MustNewType(typ), func() component.Config { return &fakeConfig{} })
+		}
+	}
+	// Appended directly, as upstream files do, rather than registered.
+	processor.Factories = append(processor.Factories, newFactory("appended"))
+
+	factories, err := Components("extension-id")
+	if err != nil {
+		t.Fatalf("Components() = %v", err)
+	}
+	processor.Factories = append(processor.Factories, newFactory("later"))
+
+	tests := []struct {
+		typ  string
+		want bool
+	}{
+		{typ: "appended", want: true},
+		{typ: "later", want: false},
+	}
+	for _, tt := range tests {
+		if _, ok := factories.Processors[component.MustNewType(tt.typ)]; ok != tt.want {
+			t.Errorf("Components() has processor %q = %v, want %v", tt.typ, ok, tt.want)
+		}
+	}
+}
+
+func TestComponentsDisabled(t *testing.T) {
+	registerProcessor(t, "lambdacomponents.processor.first", "first")
+	registerProcessor(t, "lambdacomponents.processor.second", "second")
+
+	tests := []struct {
+		name     string
+		disabled string
+		want     []string
+	}{
+		{name: "empty", want: []string{"first", "second"}},
+		{name: "by type", disabled: "first", want: []string{"second"}},
+		{name: "by kind and type", disabled: " processor:second ,", want: []string{"first"}},
+		{name: "other kind", disabled: "exporter:first", want: []string{"first", "second"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OCELOT_DISABLE_COMPONENTS", tt.disabled)
+			factories, err := Components("extension-id")
+			if err != nil {
+				t.Fatalf("Components() = %v", err)
+			}
+			if got := processorTypes(factories.Processors); !slices.Equal(got, tt.want) {
+				t.Errorf("Components() processors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func processorTypes(processors map[component.Type]otelprocessor.Factory) []string {
+	var types []string
+	for typ := range processors {
+		types = append(types, typ.String())
+	}
+	slices.Sort(types)
+	return types
+}
+
+func TestComponentsEnabled(t *testing.T) {
+	registerProcessor(t, "lambdacomponents.processor.first", "first")
+	registerProcessor(t, "lambdacomponents.processor.second", "second")
+
+	tests := []struct {
+		name     string
+		enabled  string
+		disabled string
+		want     []string
+		wantErr  string
+	}{
+		{name: "by type", enabled: "second", want: []string{"second"}},
+		{name: "by kind and type", enabled: "processor:first,processor:second", want: []string{"first", "second"}},
+		{name: "empty", enabled: "", want: nil},
+		{name: "takes precedence", enabled: "first", disabled: "first", want: []string{"first"}},
+		{
+			name:    "not compiled",
+			enabled: "first,exporter:otlp",
+			wantErr: `OCELOT_ENABLED_COMPONENTS lists "exporter:otlp", which is not compiled into this layer`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OCELOT_ENABLED_COMPONENTS", tt.enabled)
+			t.Setenv("OCELOT_DISABLE_COMPONENTS", tt.disabled)
+			factories, err := Components("extension-id")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Components() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Components() = %v", err)
+			}
+			if got := processorTypes(factories.Processors); !slices.Equal(got, tt.want) {
+				t.Errorf("Components() processors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponentsForConfig(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, cfg string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(cfg), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	inline := write("inline.yaml", "processors:\n  first:\n")
+	referenced := write("referenced.yaml", "processors: ${file:"+inline+"}\n")
+
+	tests := []struct {
+		name     string
+		location string
+		want     []string
+	}{
+		{name: "local file", location: inline, want: []string{"first"}},
+		{name: "file URI", location: "file:" + inline, want: []string{"first"}},
+		{name: "sections from references", location: referenced, want: []string{"first", "second"}},
+		{name: "other scheme", location: "s3://bucket.s3.us-east-1.amazonaws.com/config.yaml", want: []string{"first", "second"}},
+		{name: "missing file", location: filepath.Join(dir, "missing.yaml"), want: []string{"first", "second"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := registerProcessor(t, "lambdacomponents.processor.first", "first")
+			second := registerProcessor(t, "lambdacomponents.processor.second", "second")
+			t.Setenv("OPENTELEMETRY_COLLECTOR_CONFIG_URI", tt.location)
+
+			factories, err := Components("extension-id")
+			if err != nil {
+				t.Fatalf("Components() = %v", err)
+			}
+			if got := processorTypes(factories.Processors); !slices.Equal(got, tt.want) {
+				t.Errorf("Components() processors = %v, want %v", got, tt.want)
+			}
+			if wantSecond := slices.Contains(tt.want, "second"); (*second > 0) != wantSecond {
+				t.Errorf("second constructed %d times, want it constructed: %v", *second, wantSecond)
+			}
+			if *first == 0 {
+				t.Error("first was never constructed")
+			}
+		})
+	}
+}
+
+func TestComponentsSelfTelemetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    string
+		wantLogged bool
+	}{
+		{name: "disabled", enabled: "false"},
+		{name: "enabled", enabled: "true", wantLogged: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := registerExporter(t, "fake")
+			t.Setenv("OCELOT_SELF_TELEMETRY", tt.enabled)
+			factories, err := Components("extension-id")
+			if err != nil {
+				t.Fatalf("Components() = %v", err)
+			}
+			core, logs := observer.New(zap.InfoLevel)
+			exp, err := startTraces(t, factories, "fake", nil, zap.New(core))
+			if err != nil {
+				t.Fatalf("Start() = %v", err)
+			}
+
+			backend.setErr(errors.New("backend unavailable"))
+			_ = exp.ConsumeTraces(context.Background(), newTraces(2))
+			if err := exp.Shutdown(context.Background()); err != nil {
+				t.Fatalf("Shutdown() = %v", err)
+			}
+
+			logged := logs.FilterMessage("Exporter self-telemetry").All()
+			if (len(logged) > 0) != tt.wantLogged {
+				t.Fatalf("self-telemetry logged %d times, want logged: %v", len(logged), tt.wantLogged)
+			}
+			if !tt.wantLogged {
+				return
+			}
+			// The fake exporter doesn't use the exporter helper, so the
+			// collector records nothing for it.
+			fields := logged[len(logged)-1].ContextMap()
+			for _, name := range []string{"queue_size", "send_failed", "enqueue_failed"} {
+				if fields[name] != int64(0) {
+					t.Errorf("logged %s=%v, want 0", name, fields[name])
+				}
+			}
+		})
+	}
+}
+
+func TestComponentsCircuitBreaker(t *testing.T) {
+	tests := []struct {
+		name         string
+		wrapped      string
+		wantAttempts int // of the 4 failing exports
+	}{
+		{name: "listed", wrapped: "fake", wantAttempts: 2},
+		{name: "all", wrapped: "*", wantAttempts: 2},
+		{name: "not listed", wrapped: "otlp", wantAttempts: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := registerExporter(t, "fake")
+			t.Setenv("OCELOT_CIRCUIT_BREAKER", tt.wrapped)
+			t.Setenv("OCELOT_CIRCUIT_BREAKER_THRESHOLD", "2")
+			t.Setenv("OCELOT_CIRCUIT_BREAKER_COOLDOWN", "1h")
+			factories, err := Components("extension-id")
+			if err != nil {
+				t.Fatalf("Components() = %v", err)
+			}
+			exp, err := startTraces(t, factories, "fake", nil, zap.NewNop())
+			if err != nil {
+				t.Fatalf("Start() = %v", err)
+			}
+
+			backend.setErr(errors.New("backend unavailable"))
+			for range 4 {
+				if err := exp.ConsumeTraces(context.Background(), newTraces(1)); err == nil {
+					t.Fatal("ConsumeTraces() = nil, want an error")
+				}
+			}
+			if got := backend.exportAttempts(); got != tt.wantAttempts {
+				t.Errorf("%d exports reached the backend, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestComponentsCircuitBreakerSettings(t *testing.T) {
+	registerExporter(t, "fake")
+	t.Setenv("OCELOT_CIRCUIT_BREAKER", "fake")
+	t.Setenv("OCELOT_CIRCUIT_BREAKER_THRESHOLD", "0")
+	_, err := Components("extension-id")
+	if want := "OCELOT_CIRCUIT_BREAKER_THRESHOLD must be a positive integer"; err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("Components() = %v, want an error containing %q", err, want)
+	}
+}
+
+func TestComponentsFallback(t *testing.T) {
+	tests := []struct {
+		name          string
+		backed        string
+		bucket        string
+		primaryErr    error
+		wantPrimary   int
+		wantSecondary int
+		wantErr       string
+	}{
+		{name: "primary succeeds", backed: "fake", bucket: "spill", wantPrimary: 1},
+		{name: "primary fails", backed: "fake", bucket: "spill", primaryErr: errors.New("backend unavailable"), wantSecondary: 1},
+		{name: "not backed", backed: "otlp", bucket: "spill", primaryErr: errors.New("backend unavailable"), wantErr: "backend unavailable"},
+		{name: "no bucket", backed: "fake", wantErr: "OCELOT_S3_FALLBACK requires OCELOT_S3_FALLBACK_BUCKET"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := registerExporter(t, "fake")
+			secondary := registerExporter(t, "awss3")
+			t.Setenv("OCELOT_S3_FALLBACK", tt.backed)
+			t.Setenv("OCELOT_S3_FALLBACK_BUCKET", tt.bucket)
+			factories, err := Components("extension-id")
+			if tt.bucket == "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Components() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Components() = %v", err)
+			}
+			exp, err := startTraces(t, factories, "fake", nil, zap.NewNop())
+			if err != nil {
+				t.Fatalf("Start() = %v", err)
+			}
+
+			primary.setErr(tt.primaryErr)
+			err = exp.ConsumeTraces(context.Background(), newTraces(1))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ConsumeTraces() = %v, want an error containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("ConsumeTraces() = %v", err)
+			}
+			if got := len(primary.received()); got != tt.wantPrimary {
+				t.Errorf("primary received %d batches, want %d", got, tt.wantPrimary)
+			}
+			if got := len(secondary.received()); got != tt.wantSecondary {
+				t.Errorf("fallback received %d batches, want %d", got, tt.wantSecondary)
+			}
+			for _, cfg := range secondary.configs {
+				if cfg.S3Uploader.S3Bucket != tt.bucket {
+					t.Errorf("fallback spills to bucket %q, want %q", cfg.S3Uploader.S3Bucket, tt.bucket)
+				}
+			}
+		})
+	}
+}
+
+func TestComponentsStartupProbe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unreachable := closed.Addr().String()
+	closed.Close()
+
+	tests := []struct {
+		name     string
+		mode     string
+		endpoint string
+		wantErr  string
+		wantWarn bool
+	}{
+		{name: "reachable", mode: "fail", endpoint: "http://" + listener.Addr().String()},
+		{name: "unreachable", mode: "fail", endpoint: unreachable, wantErr: "is unreachable"},
+		{name: "unreachable with warn", mode: "warn", endpoint: unreachable, wantWarn: true},
+		{name: "no endpoint", mode: "fail"},
+		{name: "disabled", mode: "", endpoint: unreachable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registerExporter(t, "fake")
+			t.Setenv("OCELOT_STARTUP_PROBE", tt.mode)
+			t.Setenv("OCELOT_STARTUP_PROBE_TIMEOUT", "500ms")
+			factories, err := Components("extension-id")
+			if err != nil {
+				t.Fatalf("Components() = %v", err)
+			}
+			core, logs := observer.New(zap.WarnLevel)
+			_, err = startTraces(t, factories, "fake", &fakeExporterConfig{Endpoint: tt.endpoint}, zap.New(core))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Start() = %v, want an error containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Start() = %v", err)
+			}
+			if warned := logs.Len() > 0; warned != tt.wantWarn {
+				t.Errorf("warned = %v, want %v", warned, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestComponentsBuildHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		register []string // processor types compiled in besides the exporter
+	}{
+		{name: "exporter only"},
+		{name: "with processors", register: []string{"first", "second"}},
+	}
+	hashes := make(map[string]bool)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := registerExporter(t, "fake")
+			for _, typ := range tt.register {
+				registerProcessor(t, "lambdacomponents.processor."+typ, typ)
+			}
+			factories, err := Components("extension-id")
+			if err != nil {
+				t.Fatalf("Components() = %v", err)
+			}
+			exp, err := startTraces(t, factories, "fake", nil, zap.NewNop())
+			if err != nil {
+				t.Fatalf("Start() = %v", err)
+			}
+			if err := exp.ConsumeTraces(context.Background(), newTraces(1)); err != nil {
+				t.Fatalf("ConsumeTraces() = %v", err)
+			}
+
+			received := backend.received()
+			if len(received) != 1 {
+				t.Fatalf("backend received %d batches, want 1", len(received))
+			}
+			got, ok := received[0].ResourceSpans().At(0).Resource().Attributes().Get(buildhash.AttributeKey)
+			if want := buildhash.Hash(assembly.Manifest()); !ok || got.Str() != want {
+				t.Errorf("%s = %q, want %q", buildhash.AttributeKey, got.Str(), want)
+			}
+			hashes[got.Str()] = true
+		})
+	}
+	if len(hashes) != len(tests) {
+		t.Errorf("builds with different components share a hash: %v", hashes)
+	}
+}
+
+func TestComponentsPanickingFactories(t *testing.T) {
+	boom := component.MustNewType("boom")
+	tests := []struct {
+		name       string
+		newFactory func(string) otelprocessor.Factory
+		wantErr    string
+	}{
+		{
+			name:       "panics",
+			newFactory: func(string) otelprocessor.Factory { panic("no region") },
+			wantErr:    `processor "boom" registered by build tag lambdacomponents.processor.boom: panicked creating the factory: no region`,
+		},
+		{
+			name: "default config panics",
+			newFactory: func(string) otelprocessor.Factory {
+				return otelprocessor.NewFactory(boom, func() component.Config { panic("no default") })
+			},
+			wantErr: "panicked creating the factory: no default",
+		},
+		{
+			name:       "no factory",
+			newFactory: func(string) otelprocessor.Factory { return nil },
+			wantErr:    `processor "boom" registered by build tag lambdacomponents.processor.boom: created no factory`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registerProcessor(t, "lambdacomponents.processor.healthy", "healthy")
+			processor.Register("lambdacomponents.processor.boom", "example.com/boom", "boom", tt.newFactory)
+
+			_, err := Components("extension-id")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Components() = %v, want an error containing %q", err, tt.wantErr)
+			}
+			if strings.Contains(err.Error(), "healthy") {
+				t.Errorf("Components() = %v, which blames the healthy processor", err)
+			}
+		})
+	}
+}
+
+func TestComponentsDryRun(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, cfg string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(cfg), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	pipeline := "service:\n  pipelines:\n    traces:\n      processors: [first]\n      exporters: [fake]\n"
+	valid := write("valid.yaml", "processors:\n  first:\nexporters:\n  fake:\n    endpoint: https://collector:4318\n"+pipeline)
+
+	tests := []struct {
+		name       string
+		dryRun     string
+		location   string
+		wantExit   int // -1 when the process must not exit
+		wantConfig map[string]any
+		wantStderr string
+		// broken registers a processor the configuration doesn't use, whose
+		// default config panics.
+		broken bool
+	}{
+		{
+			name:     "unset",
+			location: valid,
+			wantExit: -1,
+		},
+		{
+			name:     "valid",
+			dryRun:   "true",
+			location: valid,
+			wantExit: 0,
+			wantConfig: map[string]any{
+				"endpoint":   "https://collector:4318",
+				"s3uploader": map[string]any{"s3_bucket": ""},
+			},
+		},
+		{
+			name:       "component not compiled",
+			dryRun:     "true",
+			location:   write("missing.yaml", "processors:\n  decouple:\n"),
+			wantExit:   1,
+			wantStderr: `processor "decouple" is not compiled into this layer`,
+		},
+		{
+			name:       "unknown field",
+			dryRun:     "true",
+			location:   write("unknown.yaml", "exporters:\n  fake:\n    endpiont: https://collector:4318\n"),
+			wantExit:   1,
+			wantStderr: "endpiont",
+		},
+		{
+			name:       "unused component with a broken default",
+			dryRun:     "true",
+			location:   valid,
+			broken:     true,
+			wantExit:   1,
+			wantStderr: `processor "broken" registered by build tag lambdacomponents.processor.broken: panicked creating the factory: no default`,
+		},
+		{
+			name:       "not a local file",
+			dryRun:     "true",
+			location:   "s3://configs/collector.yaml",
+			wantExit:   1,
+			wantStderr: "OCELOT_DRY_RUN needs a local configuration file that declares its components inline, not s3://configs/collector.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registerProcessor(t, "lambdacomponents.processor.first", "first")
+			registerExporter(t, "fake")
+			if tt.broken {
+				processor.Register("lambdacomponents.processor.broken", "example.com/broken", "broken", func(string) otelprocessor.Factory {
+					return otelprocessor.NewFactory(component.MustNewType("broken"), func() component.Config { panic("no default") })
+				})
+			}
+			t.Setenv("OCELOT_DRY_RUN", tt.dryRun)
+			t.Setenv("OPENTELEMETRY_COLLECTOR_CONFIG_URI", tt.location)
+			var out, errOut strings.Builder
+			gotExit := -1
+			savedExit, savedStdout, savedStderr := exit, stdout, stderr
+			t.Cleanup(func() { exit, stdout, stderr = savedExit, savedStdout, savedStderr })
+			exit = func(code int) { gotExit = code }
+			stdout, stderr = &out, &errOut
+
+			_, _ = Components("extension-id")
+			if gotExit != tt.wantExit {
+				t.Fatalf("exit code = %d, want %d; stderr: %s", gotExit, tt.wantExit, errOut.String())
+			}
+			if !strings.Contains(errOut.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", errOut.String(), tt.wantStderr)
+			}
+			if tt.wantConfig == nil {
+				return
+			}
+			var graph struct {
+				Components []struct {
+					Kind   string         `json:"kind"`
+					ID     string         `json:"id"`
+					Config map[string]any `json:"config"`
+				} `json:"components"`
+			}
+			if err := json.Unmarshal([]byte(out.String()), &graph); err != nil {
+				t.Fatalf("stdout is not the JSON graph: %v\n%s", err, out.String())
+			}
+			var ids []string
+			for _, c := range graph.Components {
+				ids = append(ids, c.Kind+"/"+c.ID)
+				if c.Kind == "exporter" && !reflect.DeepEqual(c.Config, tt.wantConfig) {
+					t.Errorf("exporter %s config = %v, want %v", c.ID, c.Config, tt.wantConfig)
+				}
+			}
+			if want := []string{"processor/first", "exporter/fake"}; !slices.Equal(ids, want) {
+				t.Errorf("dry run components = %v, want %v", ids, want)
+			}
+		})
+	}
+}
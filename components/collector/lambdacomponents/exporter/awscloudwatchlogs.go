@@ -0,0 +1,31 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.awscloudwatchlogs) && !lambdacomponents.metricsonly
+
+package exporter
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awscloudwatchlogsexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.awscloudwatchlogs", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awscloudwatchlogsexporter", "awscloudwatchlogs", func(extensionId string) exporter.Factory {
+		// Default to the function's own log group and stream, so the logs sit
+		// next to the ones the runtime writes.
+		return defaults.Exporter(awscloudwatchlogsexporter.NewFactory(), func(cfg *awscloudwatchlogsexporter.Config) {
+			if group := lambdaenv.LogGroupName(); group != "" {
+				cfg.LogGroupName = group
+			}
+			if stream := lambdaenv.LogStreamName(); stream != "" {
+				cfg.LogStreamName = stream
+			}
+			if role := defaults.AssumeRoleARN(); role != "" {
+				cfg.AWSSessionSettings.RoleARN = role
+			}
+			if region := lambdaenv.Region(); region != "" {
+				cfg.Region = region
+			}
+		})
+	})
+}
@@ -0,0 +1,23 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.awscloudwatchlogs) && !lambdacomponents.metricsonly
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awscloudwatchlogsexporter"
+)
+
+func TestAWSCloudWatchLogsDefaults(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_LOG_GROUP_NAME", "/aws/lambda/checkout")
+	t.Setenv("AWS_LAMBDA_LOG_STREAM_NAME", "2024/03/05/[$LATEST]0123456789abcdef")
+	t.Setenv("AWS_REGION", "eu-central-1")
+
+	cfg := factory(t, "awscloudwatchlogs", "").CreateDefaultConfig().(*awscloudwatchlogsexporter.Config)
+	if cfg.LogGroupName != "/aws/lambda/checkout" || cfg.LogStreamName != "2024/03/05/[$LATEST]0123456789abcdef" {
+		t.Errorf("exports to %q/%q, want the function's log group and stream", cfg.LogGroupName, cfg.LogStreamName)
+	}
+	if cfg.Region != "eu-central-1" {
+		t.Errorf("region = %q, want eu-central-1", cfg.Region)
+	}
+}
@@ -0,0 +1,30 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.awsemf)
+
+package exporter
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.awsemf", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter", "awsemf", func(extensionId string) exporter.Factory {
+		// The exporter converts cumulative points to deltas from the previous
+		// point it saw, which a new execution environment hasn't: report the
+		// first point of each series rather than drop it as a baseline.
+		return defaults.Exporter(awsemfexporter.NewFactory(), func(cfg *awsemfexporter.Config) {
+			if name := lambdaenv.FunctionName(); name != "" {
+				cfg.Namespace = name
+			}
+			if region := lambdaenv.Region(); region != "" {
+				cfg.Region = region
+			}
+			if role := defaults.AssumeRoleARN(); role != "" {
+				cfg.AWSSessionSettings.RoleARN = role
+			}
+			cfg.RetainInitialValueOfDeltaMetric = true
+		})
+	})
+}
@@ -0,0 +1,24 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.awsemf)
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
+)
+
+func TestAWSEMFDefaults(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "checkout")
+	t.Setenv("AWS_REGION", "eu-west-1")
+	cfg := factory(t, "awsemf", "").CreateDefaultConfig().(*awsemfexporter.Config)
+	if cfg.Namespace != "checkout" {
+		t.Errorf("namespace = %q, want checkout", cfg.Namespace)
+	}
+	if cfg.Region != "eu-west-1" {
+		t.Errorf("region = %q, want eu-west-1", cfg.Region)
+	}
+	if !cfg.RetainInitialValueOfDeltaMetric {
+		t.Error("the first point of a series is dropped as a baseline, want it reported")
+	}
+}
@@ -0,0 +1,26 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.awskinesis)
+
+package exporter
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.awskinesis", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter", "awskinesis", func(extensionId string) exporter.Factory {
+		// Credentials come from the SDK default chain, which resolves the
+		// execution role, unless a role to assume is set. Outside Lambda the
+		// exporter's own region lookup applies.
+		return defaults.Exporter(awskinesisexporter.NewFactory(), func(cfg *awskinesisexporter.Config) {
+			if region := lambdaenv.Region(); region != "" {
+				cfg.AWS.Region = region
+			}
+			if role := defaults.AssumeRoleARN(); role != "" {
+				cfg.AWS.Role = role
+			}
+		})
+	})
+}
@@ -0,0 +1,38 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.awskinesis)
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+)
+
+func TestAWSKinesisDefaults(t *testing.T) {
+	upstream := awskinesisexporter.NewFactory().CreateDefaultConfig().(*awskinesisexporter.Config).AWS
+	tests := []struct {
+		name       string
+		region     string
+		role       string
+		wantRegion string
+		wantRole   string
+	}{
+		{name: "outside Lambda", wantRegion: upstream.Region, wantRole: upstream.Role},
+		{name: "in Lambda", region: "us-west-2", wantRegion: "us-west-2", wantRole: upstream.Role},
+		{name: "assumed role", region: "us-west-2", role: "arn:aws:iam::123456789012:role/kinesis", wantRegion: "us-west-2", wantRole: "arn:aws:iam::123456789012:role/kinesis"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AWS_REGION", tt.region)
+			t.Setenv(defaults.AssumeRoleARNEnvVar, tt.role)
+			cfg := factory(t, "awskinesis", "").CreateDefaultConfig().(*awskinesisexporter.Config)
+			if cfg.AWS.Region != tt.wantRegion {
+				t.Errorf("region = %q, want %q", cfg.AWS.Region, tt.wantRegion)
+			}
+			if cfg.AWS.Role != tt.wantRole {
+				t.Errorf("role = %q, want %q", cfg.AWS.Role, tt.wantRole)
+			}
+		})
+	}
+}
@@ -3,12 +3,35 @@
 package exporter
 
 import (
+	"strings"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/s3manifest"
 	"go.opentelemetry.io/collector/exporter"
 )
 
 func init() {
-	Factories = append(Factories, func(extensionId string) exporter.Factory {
-		return awss3exporter.NewFactory()
+	Register("lambdacomponents.exporter.awss3", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter", "awss3", func(extensionId string) exporter.Factory {
+		// Partition keys by day and extension ID, so collectors running in
+		// different execution environments never write over each other's
+		// objects and lifecycle rules can target each partition. The format is
+		// strftime-like, so '%' in the ID is escaped. Objects are gzipped,
+		// the compression the exporter supports, to reduce storage and transfer.
+		factory := defaults.Exporter(awss3exporter.NewFactory(), func(cfg *awss3exporter.Config) {
+			if role := defaults.AssumeRoleARN(); role != "" {
+				cfg.S3Uploader.RoleArn = role
+			}
+			cfg.S3Uploader.Compression = defaults.Compression(false)
+			cfg.S3Uploader.S3PartitionFormat = "year=%Y/month=%m/day=%d"
+			if ns := defaults.WithExtensionID(extensionId); ns.ID() != "" {
+				cfg.S3Uploader.S3PartitionFormat += "/ext=" + strings.ReplaceAll(ns.ID(), "%", "%%")
+			}
+		})
+		if lambdaenv.Enabled(s3manifest.EnvVar) {
+			return s3manifest.Exporter(factory, extensionId)
+		}
+		return factory
 	})
 }
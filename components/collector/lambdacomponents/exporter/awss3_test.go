@@ -0,0 +1,158 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.awss3)
+
+package exporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/itchyny/timefmt-go"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// awss3KeyPrefix returns the prefix of the keys the awss3 exporter of the
+// given extension writes at t with its default configuration.
+func awss3KeyPrefix(t *testing.T, extensionId string, at time.Time) string {
+	t.Helper()
+	cfg := factory(t, "awss3", extensionId).CreateDefaultConfig().(*awss3exporter.Config)
+	return path.Join(cfg.S3Uploader.S3Prefix, timefmt.Format(at, cfg.S3Uploader.S3PartitionFormat)) + "/"
+}
+
+func TestAWSS3KeyPrefix(t *testing.T) {
+	at := time.Date(2024, time.March, 5, 23, 59, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		extensionId string
+		want        string
+	}{
+		{name: "no extension ID", want: "year=2024/month=03/day=05/"},
+		{name: "extension ID", extensionId: "a1b2c3d4-ext", want: "year=2024/month=03/day=05/ext=a1b2c3d4-ext/"},
+		{name: "percent in the extension ID", extensionId: "100%Y", want: "year=2024/month=03/day=05/ext=100%Y/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := awss3KeyPrefix(t, tt.extensionId, at); got != tt.want {
+				t.Errorf("key prefix = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAWSS3KeyPrefixSeparatesExtensions(t *testing.T) {
+	at := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+	first := awss3KeyPrefix(t, "first-ext", at)
+	second := awss3KeyPrefix(t, "second-ext", at)
+	if first == second {
+		t.Errorf("extensions first-ext and second-ext share the key prefix %q", first)
+	}
+}
+
+func TestAWSS3Compression(t *testing.T) {
+	cfg := factory(t, "awss3", "").CreateDefaultConfig().(*awss3exporter.Config)
+	// The exporter doesn't support zstd.
+	if cfg.S3Uploader.Compression != configcompression.TypeGzip {
+		t.Errorf("compression = %q, want gzip", cfg.S3Uploader.Compression)
+	}
+}
+
+// assumeRoleResponse is what the stub STS endpoint answers AssumeRole with.
+const assumeRoleResponse = `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>ASSUMEDACCESSKEY</AccessKeyId>
+      <SecretAccessKey>assumed-secret</SecretAccessKey>
+      <SessionToken>assumed-token</SessionToken>
+      <Expiration>2099-01-01T00:00:00Z</Expiration>
+    </Credentials>
+    <AssumedRoleUser>
+      <Arn>arn:aws:sts::123456789012:assumed-role/export/ocelot</Arn>
+      <AssumedRoleId>AROAEXAMPLE:ocelot</AssumedRoleId>
+    </AssumedRoleUser>
+  </AssumeRoleResult>
+</AssumeRoleResponse>`
+
+func TestAWSS3AssumeRole(t *testing.T) {
+	const role = "arn:aws:iam::123456789012:role/export"
+	var mu sync.Mutex
+	var assumed []string
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		assumed = append(assumed, r.PostForm.Get("RoleArn"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(assumeRoleResponse))
+	}))
+	defer sts.Close()
+	var authorizations []string
+	s3 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		mu.Lock()
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		mu.Unlock()
+	}))
+	defer s3.Close()
+
+	t.Setenv(defaults.AssumeRoleARNEnvVar, role)
+	t.Setenv("AWS_ENDPOINT_URL_STS", sts.URL)
+	// The execution role's credentials, which only STS should see.
+	t.Setenv("AWS_ACCESS_KEY_ID", "EXECUTIONACCESSKEY")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "execution-secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	f := factory(t, "awss3", "")
+	cfg := f.CreateDefaultConfig().(*awss3exporter.Config)
+	cfg.S3Uploader.Region = "us-east-1"
+	cfg.S3Uploader.S3Bucket = "telemetry"
+	cfg.S3Uploader.Endpoint = s3.URL
+	cfg.S3Uploader.S3ForcePathStyle = true
+	exp, err := f.CreateTraces(context.Background(), settings(f), cfg)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if err := exp.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	for range 2 {
+		td := ptrace.NewTraces()
+		td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		if err := exp.ConsumeTraces(context.Background(), td); err != nil {
+			t.Fatalf("ConsumeTraces() = %v", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// The session is cached, so the role is assumed once for both uploads.
+	if len(assumed) != 1 || assumed[0] != role {
+		t.Errorf("assumed roles = %v, want [%s]", assumed, role)
+	}
+	if len(authorizations) != 2 {
+		t.Fatalf("%d objects uploaded, want 2", len(authorizations))
+	}
+	for _, auth := range authorizations {
+		if !strings.Contains(auth, "Credential=ASSUMEDACCESSKEY/") {
+			t.Errorf("upload signed with %q, want the assumed credentials", auth)
+		}
+	}
+}
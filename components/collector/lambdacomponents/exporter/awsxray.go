@@ -0,0 +1,28 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.awsxray) && !lambdacomponents.metricsonly
+
+package exporter
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.awsxray", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter", "awsxray", func(extensionId string) exporter.Factory {
+		// X-Ray accepts at most 50 annotations per segment, so attributes are
+		// only indexed when listed explicitly. The exporter's own telemetry
+		// reporter runs on a timer and stays off.
+		return defaults.Exporter(awsxrayexporter.NewFactory(), func(cfg *awsxrayexporter.Config) {
+			if region := lambdaenv.Region(); region != "" {
+				cfg.Region = region
+			}
+			if role := defaults.AssumeRoleARN(); role != "" {
+				cfg.AWSSessionSettings.RoleARN = role
+			}
+			cfg.IndexAllAttributes = false
+			cfg.TelemetryConfig.Enabled = false
+		})
+	})
+}
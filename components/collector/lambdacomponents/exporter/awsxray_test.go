@@ -0,0 +1,24 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.awsxray) && !lambdacomponents.metricsonly
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsxrayexporter"
+)
+
+func TestAWSXRayDefaults(t *testing.T) {
+	t.Setenv("AWS_REGION", "us-east-2")
+	cfg := factory(t, "awsxray", "").CreateDefaultConfig().(*awsxrayexporter.Config)
+	if cfg.Region != "us-east-2" {
+		t.Errorf("region = %q, want us-east-2", cfg.Region)
+	}
+	// X-Ray keeps at most 50 annotations per segment.
+	if cfg.IndexAllAttributes {
+		t.Error("all attributes indexed, want only the listed ones")
+	}
+	if cfg.TelemetryConfig.Enabled {
+		t.Error("X-Ray telemetry reporter enabled, want it off")
+	}
+}
@@ -0,0 +1,24 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.carbon)
+
+package exporter
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/carbonexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.carbon", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/carbonexporter", "carbon", func(extensionId string) exporter.Factory {
+		// Fail fast on an unreachable Carbon server instead of spending the
+		// invocation dialing it, and write on the calling goroutine so every
+		// batch has reached the socket before shutdown.
+		return defaults.Exporter(carbonexporter.NewFactory(), func(cfg *carbonexporter.Config) {
+			cfg.DialerConfig.Timeout = time.Second
+			cfg.TimeoutSettings.Timeout = 2 * time.Second
+			cfg.QueueConfig.Enabled = false
+		})
+	})
+}
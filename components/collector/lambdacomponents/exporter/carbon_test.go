@@ -0,0 +1,23 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.carbon)
+
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/carbonexporter"
+)
+
+func TestCarbonDefaults(t *testing.T) {
+	cfg := factory(t, "carbon", "").CreateDefaultConfig().(*carbonexporter.Config)
+	if cfg.DialerConfig.Timeout != time.Second {
+		t.Errorf("dial timeout = %v, want 1s", cfg.DialerConfig.Timeout)
+	}
+	if cfg.TimeoutSettings.Timeout != 2*time.Second {
+		t.Errorf("write timeout = %v, want 2s", cfg.TimeoutSettings.Timeout)
+	}
+	if cfg.QueueConfig.Enabled {
+		t.Error("sending queue enabled, want writes on the calling goroutine")
+	}
+}
@@ -1,14 +1,23 @@
-//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.clickhouse)
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.clickhouse) && !lambdacomponents.core
+
+// The core profile leaves out the ClickHouse client's large dependency tree.
 
 package exporter
 
 import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
 	"go.opentelemetry.io/collector/exporter"
 )
 
 func init() {
-	Factories = append(Factories, func(extensionId string) exporter.Factory {
-		return clickhouseexporter.NewFactory()
+	Register("lambdacomponents.exporter.clickhouse", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter", "clickhouse", func(extensionId string) exporter.Factory {
+		// Give each extension its own logs and traces tables. Without an
+		// extension ID the stock table names are kept.
+		return defaults.Exporter(clickhouseexporter.NewFactory(), func(cfg *clickhouseexporter.Config) {
+			ns := defaults.WithExtensionID(extensionId)
+			cfg.LogsTableName = ns.Join(cfg.LogsTableName, "_")
+			cfg.TracesTableName = ns.Join(cfg.TracesTableName, "_")
+		})
 	})
 }
@@ -0,0 +1,29 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.coralogix)
+
+package exporter
+
+import (
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/coralogixexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.coralogix", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/coralogixexporter", "coralogix", func(extensionId string) exporter.Factory {
+		// The domain and private key come from the environment so the key
+		// isn't written into the config. Send synchronously: a queue doesn't
+		// survive the environment being frozen.
+		return defaults.Exporter(coralogixexporter.NewFactory(), func(cfg *coralogixexporter.Config) {
+			if domain := os.Getenv("CORALOGIX_DOMAIN"); domain != "" {
+				cfg.Domain = domain
+			}
+			if key := os.Getenv("CORALOGIX_PRIVATE_KEY"); key != "" {
+				cfg.PrivateKey = configopaque.String(key)
+			}
+			cfg.QueueSettings.Enabled = false
+		})
+	})
+}
@@ -0,0 +1,40 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.coralogix)
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/coralogixexporter"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+func TestCoralogixDefaults(t *testing.T) {
+	upstream := coralogixexporter.NewFactory().CreateDefaultConfig().(*coralogixexporter.Config)
+	tests := []struct {
+		name       string
+		domain     string
+		key        string
+		wantDomain string
+		wantKey    configopaque.String
+	}{
+		{name: "unset", wantDomain: upstream.Domain, wantKey: upstream.PrivateKey},
+		{name: "from the environment", domain: "eu2.coralogix.com", key: "cx-key", wantDomain: "eu2.coralogix.com", wantKey: "cx-key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CORALOGIX_DOMAIN", tt.domain)
+			t.Setenv("CORALOGIX_PRIVATE_KEY", tt.key)
+			cfg := factory(t, "coralogix", "").CreateDefaultConfig().(*coralogixexporter.Config)
+			if cfg.Domain != tt.wantDomain {
+				t.Errorf("domain = %q, want %q", cfg.Domain, tt.wantDomain)
+			}
+			if cfg.PrivateKey != tt.wantKey {
+				t.Errorf("private key = %q, want %q", cfg.PrivateKey, tt.wantKey)
+			}
+			if cfg.QueueSettings.Enabled {
+				t.Error("sending queue enabled, want synchronous sends")
+			}
+		})
+	}
+}
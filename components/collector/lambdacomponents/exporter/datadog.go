@@ -0,0 +1,27 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.datadog) && !lambdacomponents.core
+
+// The core profile leaves out the Datadog agent libraries' large dependency tree.
+
+package exporter
+
+import (
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.datadog", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter", "datadog", func(extensionId string) exporter.Factory {
+		// Host metadata describes long-lived hosts and isn't meaningful for an
+		// execution environment, so it's off unless configured.
+		return defaults.Exporter(datadogexporter.NewFactory(), func(cfg *datadogexporter.Config) {
+			if key := os.Getenv("DD_API_KEY"); key != "" {
+				cfg.API.Key = configopaque.String(key)
+			}
+			cfg.HostMetadata.Enabled = false
+		})
+	})
+}
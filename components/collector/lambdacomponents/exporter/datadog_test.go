@@ -0,0 +1,33 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.datadog) && !lambdacomponents.core
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+func TestDatadogDefaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		apiKey  string
+		wantKey configopaque.String
+	}{
+		{name: "unset"},
+		{name: "from the environment", apiKey: "dd-key", wantKey: "dd-key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DD_API_KEY", tt.apiKey)
+			cfg := factory(t, "datadog", "").CreateDefaultConfig().(*datadogexporter.Config)
+			if cfg.API.Key != tt.wantKey {
+				t.Errorf("API key = %q, want %q", cfg.API.Key, tt.wantKey)
+			}
+			if cfg.HostMetadata.Enabled {
+				t.Error("host metadata enabled, want it off for execution environments")
+			}
+		})
+	}
+}
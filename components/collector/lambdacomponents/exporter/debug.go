@@ -0,0 +1,22 @@
+//go:build lambdacomponents.custom && lambdacomponents.exporter.debug
+
+// The debug exporter writes every signal to the function's log stream and must
+// be requested explicitly: it is deliberately left out of lambdacomponents.all
+// and exporter.all.
+
+package exporter
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/debugexporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.debug", "go.opentelemetry.io/collector/exporter/debugexporter", "debug", func(extensionId string) exporter.Factory {
+		return defaults.Exporter(debugexporter.NewFactory(), func(cfg *debugexporter.Config) {
+			cfg.Verbosity = configtelemetry.LevelBasic
+		})
+	})
+}
@@ -0,0 +1,17 @@
+//go:build lambdacomponents.custom && lambdacomponents.exporter.debug
+
+package exporter
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter/debugexporter"
+)
+
+func TestDebugVerbosity(t *testing.T) {
+	cfg := factory(t, "debug", "").CreateDefaultConfig().(*debugexporter.Config)
+	if cfg.Verbosity != configtelemetry.LevelBasic {
+		t.Errorf("verbosity = %v, want basic", cfg.Verbosity)
+	}
+}
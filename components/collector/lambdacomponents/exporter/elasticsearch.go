@@ -0,0 +1,23 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.elasticsearch)
+
+package exporter
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.elasticsearch", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter", "elasticsearch", func(extensionId string) exporter.Factory {
+		// The bulk indexer's flush timer doesn't run while the environment is
+		// frozen, so flush small batches often. Documents still buffered when
+		// the exporter shuts down are flushed when the indexer is closed.
+		return defaults.Exporter(elasticsearchexporter.NewFactory(), func(cfg *elasticsearchexporter.Config) {
+			cfg.Flush.Interval = 200 * time.Millisecond
+			cfg.Flush.Bytes = 256 * 1024
+		})
+	})
+}
@@ -0,0 +1,20 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.elasticsearch)
+
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+)
+
+func TestElasticsearchFlush(t *testing.T) {
+	cfg := factory(t, "elasticsearch", "").CreateDefaultConfig().(*elasticsearchexporter.Config)
+	if cfg.Flush.Interval != 200*time.Millisecond {
+		t.Errorf("flush interval = %v, want 200ms", cfg.Flush.Interval)
+	}
+	if cfg.Flush.Bytes != 256*1024 {
+		t.Errorf("flush size = %d bytes, want 256KiB", cfg.Flush.Bytes)
+	}
+}
@@ -0,0 +1,25 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.file)
+
+package exporter
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.file", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter", "file", func(extensionId string) exporter.Factory {
+		// /tmp is the only writable path in Lambda. Each extension writes its
+		// own file, and the buffer is flushed often so little is pending when
+		// the environment freezes; Shutdown flushes and closes the file. Files
+		// are compressed with zstd to save space in /tmp.
+		return defaults.Exporter(fileexporter.NewFactory(), func(cfg *fileexporter.Config) {
+			cfg.Path = defaults.WithExtensionID(extensionId).Join("/tmp/otelcol-signals", "-") + ".json.zst"
+			cfg.Compression = "zstd"
+			cfg.FlushInterval = 100 * time.Millisecond
+		})
+	})
+}
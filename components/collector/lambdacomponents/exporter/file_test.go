@@ -0,0 +1,74 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.file)
+
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestFileCompressedRoundTrip(t *testing.T) {
+	f := factory(t, "file", "")
+	cfg := f.CreateDefaultConfig().(*fileexporter.Config)
+	if cfg.Compression != "zstd" {
+		t.Errorf("compression = %q, want zstd", cfg.Compression)
+	}
+	cfg.Path = filepath.Join(t.TempDir(), "signals.json.zst")
+	exp, err := f.CreateTraces(context.Background(), settings(f), cfg)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if err := exp.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("checkout")
+	if err := exp.ConsumeTraces(context.Background(), td); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+	if err := exp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() = %v", err)
+	}
+
+	// Compressed messages are written one after the other, each prefixed with
+	// its length.
+	data, err := os.ReadFile(cfg.Path)
+	if err != nil {
+		t.Fatalf("ReadFile() = %v", err)
+	}
+	r := bytes.NewReader(data)
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		t.Fatalf("reading the message length: %v", err)
+	}
+	compressed := make([]byte, size)
+	if _, err := io.ReadFull(r, compressed); err != nil {
+		t.Fatalf("reading the message: %v", err)
+	}
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		t.Fatalf("zstd.NewReader() = %v", err)
+	}
+	defer dec.Close()
+	message, err := dec.DecodeAll(compressed, nil)
+	if err != nil {
+		t.Fatalf("the file isn't zstd compressed: %v", err)
+	}
+	got, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(message)
+	if err != nil {
+		t.Fatalf("UnmarshalTraces() = %v", err)
+	}
+	if got.SpanCount() != 1 || got.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name() != "checkout" {
+		t.Errorf("read back %d spans, want the checkout span", got.SpanCount())
+	}
+}
@@ -0,0 +1,22 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.kafka)
+
+package exporter
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.kafka", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter", "kafka", func(extensionId string) exporter.Factory {
+		// The sending queue lives in memory and is lost when the environment is
+		// frozen, so produce synchronously with a short timeout instead.
+		return defaults.Exporter(kafkaexporter.NewFactory(), func(cfg *kafkaexporter.Config) {
+			cfg.TimeoutSettings.Timeout = 2 * time.Second
+			cfg.QueueSettings.Enabled = false
+		})
+	})
+}
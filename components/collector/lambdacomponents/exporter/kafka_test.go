@@ -0,0 +1,24 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.kafka)
+
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+)
+
+func TestKafkaDefaults(t *testing.T) {
+	f := factory(t, "kafka", "")
+	if got := f.Type().String(); got != "kafka" {
+		t.Errorf("Type() = %q, want kafka", got)
+	}
+	cfg := f.CreateDefaultConfig().(*kafkaexporter.Config)
+	if cfg.TimeoutSettings.Timeout != 2*time.Second {
+		t.Errorf("timeout = %v, want 2s", cfg.TimeoutSettings.Timeout)
+	}
+	if cfg.QueueSettings.Enabled {
+		t.Error("sending queue enabled, want it disabled")
+	}
+}
@@ -0,0 +1,60 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.loadbalancing)
+
+package exporter
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.loadbalancing", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter", "loadbalancing", func(extensionId string) exporter.Factory {
+		return loadBalancingFactory{defaults.Exporter(loadbalancingexporter.NewFactory(), func(cfg *loadbalancingexporter.Config) {
+			cfg.RoutingKey = "traceID"
+		})}
+	})
+}
+
+// loadBalancingFactory fills in the DNS resolver timings when the resolver is
+// configured without them. The resolver is optional, so its defaults can't be
+// part of the default config without conflicting with the other resolvers.
+type loadBalancingFactory struct {
+	exporter.Factory
+}
+
+func (f loadBalancingFactory) CreateTraces(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+	return f.Factory.CreateTraces(ctx, set, withDNSResolverDefaults(cfg))
+}
+
+func (f loadBalancingFactory) CreateMetrics(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+	return f.Factory.CreateMetrics(ctx, set, withDNSResolverDefaults(cfg))
+}
+
+func (f loadBalancingFactory) CreateLogs(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+	return f.Factory.CreateLogs(ctx, set, withDNSResolverDefaults(cfg))
+}
+
+// withDNSResolverDefaults resolves the backends at most every 30s: the lookups
+// are cached across warm invocations and a short timeout keeps a slow resolver
+// from holding up an invocation. The resolver goroutine stops with the exporter.
+func withDNSResolverDefaults(cfg component.Config) component.Config {
+	lbCfg, ok := cfg.(*loadbalancingexporter.Config)
+	if !ok || lbCfg.Resolver.DNS == nil {
+		return cfg
+	}
+	dns := *lbCfg.Resolver.DNS
+	if dns.Interval == 0 {
+		dns.Interval = 30 * time.Second
+	}
+	if dns.Timeout == 0 {
+		dns.Timeout = time.Second
+	}
+	withDefaults := *lbCfg
+	withDefaults.Resolver.DNS = &dns
+	return &withDefaults
+}
@@ -0,0 +1,48 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.loadbalancing)
+
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+)
+
+func TestLoadBalancingRoutingKey(t *testing.T) {
+	cfg := factory(t, "loadbalancing", "").CreateDefaultConfig().(*loadbalancingexporter.Config)
+	if cfg.RoutingKey != "traceID" {
+		t.Errorf("routing key = %q, want traceID", cfg.RoutingKey)
+	}
+}
+
+func TestLoadBalancingDNSResolver(t *testing.T) {
+	tests := []struct {
+		name         string
+		dns          loadbalancingexporter.DNSResolver
+		wantInterval time.Duration
+		wantTimeout  time.Duration
+	}{
+		{name: "defaults", dns: loadbalancingexporter.DNSResolver{Hostname: "collectors.internal"}, wantInterval: 30 * time.Second, wantTimeout: time.Second},
+		{
+			name:         "configured",
+			dns:          loadbalancingexporter.DNSResolver{Hostname: "collectors.internal", Interval: time.Minute, Timeout: 3 * time.Second},
+			wantInterval: time.Minute,
+			wantTimeout:  3 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := factory(t, "loadbalancing", "").CreateDefaultConfig().(*loadbalancingexporter.Config)
+			cfg.Resolver.DNS = &tt.dns
+			dns := withDNSResolverDefaults(cfg).(*loadbalancingexporter.Config).Resolver.DNS
+			if dns.Interval != tt.wantInterval || dns.Timeout != tt.wantTimeout {
+				t.Errorf("resolves every %v timing out after %v, want %v and %v", dns.Interval, dns.Timeout, tt.wantInterval, tt.wantTimeout)
+			}
+			// The user's configuration is left as it is.
+			if cfg.Resolver.DNS != &tt.dns {
+				t.Error("withDNSResolverDefaults() modified the configuration it was given")
+			}
+		})
+	}
+}
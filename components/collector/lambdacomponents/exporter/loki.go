@@ -0,0 +1,23 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.loki) && !lambdacomponents.metricsonly
+
+package exporter
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.loki", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter", "loki", func(extensionId string) exporter.Factory {
+		// Push synchronously so logs are delivered before the environment is
+		// frozen, and fail fast enough to stay within the invocation.
+		return defaults.Exporter(lokiexporter.NewFactory(), func(cfg *lokiexporter.Config) {
+			cfg.QueueSettings.Enabled = false
+			cfg.ClientConfig.Timeout = 5 * time.Second
+			cfg.ClientConfig.Compression = defaults.Compression(false)
+		})
+	})
+}
@@ -0,0 +1,24 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.loki) && !lambdacomponents.metricsonly
+
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+func TestLokiDefaults(t *testing.T) {
+	cfg := factory(t, "loki", "").CreateDefaultConfig().(*lokiexporter.Config)
+	if cfg.QueueSettings.Enabled {
+		t.Error("sending queue enabled, want pushes before the environment is frozen")
+	}
+	if cfg.ClientConfig.Timeout != 5*time.Second {
+		t.Errorf("timeout = %v, want 5s", cfg.ClientConfig.Timeout)
+	}
+	if cfg.ClientConfig.Compression != configcompression.TypeGzip {
+		t.Errorf("compression = %q, want gzip", cfg.ClientConfig.Compression)
+	}
+}
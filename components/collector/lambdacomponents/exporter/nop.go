@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.nop)
+
+package exporter
+
+import (
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/nopexporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.nop", "go.opentelemetry.io/collector/exporter/nopexporter", "nop", func(extensionId string) exporter.Factory {
+		return nopexporter.NewFactory()
+	})
+}
@@ -0,0 +1,40 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.nop)
+
+package exporter
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestNopAcceptsEverySignal(t *testing.T) {
+	f := factory(t, "nop", "")
+	cfg := f.CreateDefaultConfig()
+	ctx := context.Background()
+
+	traces, err := f.CreateTraces(ctx, settings(f), cfg)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if err := traces.ConsumeTraces(ctx, ptrace.NewTraces()); err != nil {
+		t.Errorf("ConsumeTraces() = %v", err)
+	}
+	metrics, err := f.CreateMetrics(ctx, settings(f), cfg)
+	if err != nil {
+		t.Fatalf("CreateMetrics() = %v", err)
+	}
+	if err := metrics.ConsumeMetrics(ctx, pmetric.NewMetrics()); err != nil {
+		t.Errorf("ConsumeMetrics() = %v", err)
+	}
+	logs, err := f.CreateLogs(ctx, settings(f), cfg)
+	if err != nil {
+		t.Fatalf("CreateLogs() = %v", err)
+	}
+	if err := logs.ConsumeLogs(ctx, plog.NewLogs()); err != nil {
+		t.Errorf("ConsumeLogs() = %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.otlp || lambdacomponents.core)
+
+// The core profile exports OTLP over gRPC with this exporter.
+
+package exporter
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.otlp", "go.opentelemetry.io/collector/exporter/otlpexporter", "otlp", func(extensionId string) exporter.Factory {
+		// Ping idle connections so the first export after a thaw detects a
+		// connection the server closed while the environment was frozen.
+		return defaults.Exporter(otlpexporter.NewFactory(), func(cfg *otlpexporter.Config) {
+			cfg.ClientConfig.Compression = defaults.Compression(true)
+			cfg.RetryConfig = defaults.RetryBackOff()
+			if endpoint := defaults.Endpoint("OCELOT_OTLP_ENDPOINTS", defaults.WithExtensionID(extensionId)); endpoint != "" {
+				cfg.ClientConfig.Endpoint = endpoint
+			}
+			cfg.ClientConfig.Keepalive = &configgrpc.KeepaliveClientConfig{
+				Time:                30 * time.Second,
+				Timeout:             5 * time.Second,
+				PermitWithoutStream: true,
+			}
+		})
+	})
+}
@@ -0,0 +1,46 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.otlp || lambdacomponents.core)
+
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+)
+
+func TestOTLPDefaults(t *testing.T) {
+	cfg := factory(t, "otlp", "").CreateDefaultConfig().(*otlpexporter.Config)
+	if cfg.ClientConfig.Compression != configcompression.TypeZstd {
+		t.Errorf("compression = %q, want zstd", cfg.ClientConfig.Compression)
+	}
+	keepalive := cfg.ClientConfig.Keepalive
+	if keepalive == nil || keepalive.Time != 30*time.Second || keepalive.Timeout != 5*time.Second || !keepalive.PermitWithoutStream {
+		t.Errorf("keepalive = %+v, want pings every 30s timing out after 5s, without streams too", keepalive)
+	}
+	if cfg.RetryConfig.MaxElapsedTime != 5*time.Second {
+		t.Errorf("retries give up after %v, want 5s", cfg.RetryConfig.MaxElapsedTime)
+	}
+}
+
+func TestOTLPEndpoint(t *testing.T) {
+	upstream := otlpexporter.NewFactory().CreateDefaultConfig().(*otlpexporter.Config).ClientConfig.Endpoint
+	tests := []struct {
+		name      string
+		endpoints string
+		want      string
+	}{
+		{name: "unset", want: upstream},
+		{name: "single", endpoints: " collector.internal:4317, ", want: "collector.internal:4317"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OCELOT_OTLP_ENDPOINTS", tt.endpoints)
+			cfg := factory(t, "otlp", "ext-1").CreateDefaultConfig().(*otlpexporter.Config)
+			if cfg.ClientConfig.Endpoint != tt.want {
+				t.Errorf("endpoint = %q, want %q", cfg.ClientConfig.Endpoint, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.otlphttp || lambdacomponents.core)
+
+// The core profile exports OTLP over HTTP with this exporter.
+
+package exporter
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.otlphttp", "go.opentelemetry.io/collector/exporter/otlphttpexporter", "otlphttp", func(extensionId string) exporter.Factory {
+		// Keep connections alive across warm invocations, but drop idle ones
+		// before typical load balancer idle timeouts (60s) so a thawed
+		// environment doesn't reuse a connection the server already closed.
+		return defaults.Exporter(otlphttpexporter.NewFactory(), func(cfg *otlphttpexporter.Config) {
+			cfg.ClientConfig.DisableKeepAlives = false
+			cfg.ClientConfig.IdleConnTimeout = 50 * time.Second
+			cfg.ClientConfig.Compression = defaults.Compression(true)
+			cfg.RetryConfig = defaults.RetryBackOff()
+			if endpoint := defaults.Endpoint("OCELOT_OTLPHTTP_ENDPOINTS", defaults.WithExtensionID(extensionId)); endpoint != "" {
+				cfg.ClientConfig.Endpoint = endpoint
+			}
+		})
+	})
+}
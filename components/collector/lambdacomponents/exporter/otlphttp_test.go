@@ -0,0 +1,39 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.otlphttp || lambdacomponents.core)
+
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+)
+
+func TestOTLPHTTPDefaults(t *testing.T) {
+	cfg := factory(t, "otlphttp", "").CreateDefaultConfig().(*otlphttpexporter.Config)
+	if cfg.ClientConfig.DisableKeepAlives {
+		t.Error("keep-alives disabled, want connections reused across invocations")
+	}
+	// Below the 60s idle timeout of most load balancers.
+	if cfg.ClientConfig.IdleConnTimeout != 50*time.Second {
+		t.Errorf("idle connection timeout = %v, want 50s", cfg.ClientConfig.IdleConnTimeout)
+	}
+	if cfg.ClientConfig.Compression != configcompression.TypeZstd {
+		t.Errorf("compression = %q, want zstd", cfg.ClientConfig.Compression)
+	}
+}
+
+func TestOTLPHTTPEndpoint(t *testing.T) {
+	pool := map[string]bool{"https://a.internal:4318": true, "https://b.internal:4318": true}
+	t.Setenv("OCELOT_OTLPHTTP_ENDPOINTS", "https://a.internal:4318,https://b.internal:4318")
+
+	first := factory(t, "otlphttp", "ext-1").CreateDefaultConfig().(*otlphttpexporter.Config).ClientConfig.Endpoint
+	if !pool[first] {
+		t.Fatalf("endpoint = %q, want one of the pool", first)
+	}
+	// A collector keeps exporting to the endpoint it picked.
+	if again := factory(t, "otlphttp", "ext-1").CreateDefaultConfig().(*otlphttpexporter.Config).ClientConfig.Endpoint; again != first {
+		t.Errorf("endpoint = %q after a rebuild, want %q", again, first)
+	}
+}
@@ -0,0 +1,24 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.prometheus)
+
+package exporter
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.prometheus", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter", "prometheus", func(extensionId string) exporter.Factory {
+		// The endpoint can only be scraped while the environment is running,
+		// and a frozen environment misses scrapes entirely. Serve on loopback
+		// for a sidecar in the same environment, and keep series for long
+		// enough that a freeze between scrapes doesn't expire them.
+		return defaults.Exporter(prometheusexporter.NewFactory(), func(cfg *prometheusexporter.Config) {
+			cfg.ServerConfig.Endpoint = "localhost:8889"
+			cfg.MetricExpiration = time.Hour
+		})
+	})
+}
@@ -0,0 +1,21 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.prometheus)
+
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
+)
+
+func TestPrometheusDefaults(t *testing.T) {
+	cfg := factory(t, "prometheus", "").CreateDefaultConfig().(*prometheusexporter.Config)
+	if cfg.ServerConfig.Endpoint != "localhost:8889" {
+		t.Errorf("endpoint = %q, want localhost:8889 for a sidecar", cfg.ServerConfig.Endpoint)
+	}
+	// Longer than a freeze between two scrapes usually lasts.
+	if cfg.MetricExpiration != time.Hour {
+		t.Errorf("series expire after %v, want 1h", cfg.MetricExpiration)
+	}
+}
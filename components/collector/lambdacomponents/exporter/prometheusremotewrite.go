@@ -0,0 +1,21 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.prometheusremotewrite)
+
+package exporter
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.prometheusremotewrite", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter", "prometheusremotewrite", func(extensionId string) exporter.Factory {
+		// Send on the calling goroutine so every request has completed by the
+		// time the pipeline shuts down, rather than sitting in the async queue.
+		return defaults.Exporter(prometheusremotewriteexporter.NewFactory(), func(cfg *prometheusremotewriteexporter.Config) {
+			cfg.RemoteWriteQueue.Enabled = false
+			cfg.ClientConfig.Compression = defaults.Compression(false)
+			cfg.BackOffConfig = defaults.RetryBackOff()
+		})
+	})
+}
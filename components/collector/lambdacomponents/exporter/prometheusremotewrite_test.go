@@ -0,0 +1,26 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.prometheusremotewrite)
+
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+func TestPrometheusRemoteWriteDefaults(t *testing.T) {
+	cfg := factory(t, "prometheusremotewrite", "").CreateDefaultConfig().(*prometheusremotewriteexporter.Config)
+	// Requests are sent synchronously, so none is left queued when the
+	// pipeline shuts down.
+	if cfg.RemoteWriteQueue.Enabled {
+		t.Error("remote write queue enabled, want sends on the calling goroutine")
+	}
+	if cfg.ClientConfig.Compression != configcompression.TypeGzip {
+		t.Errorf("compression = %q, want gzip", cfg.ClientConfig.Compression)
+	}
+	if cfg.BackOffConfig.MaxElapsedTime != 5*time.Second {
+		t.Errorf("retries give up after %v, want 5s", cfg.BackOffConfig.MaxElapsedTime)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build lambdacomponents.custom
+
+package exporter
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/registry"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+// Registry builds and describes the exporters in Factories. Build is the
+// supported way to read the registrations; Factories is kept for existing
+// callers.
+var Registry = registry.NewKind("exporter", &Factories)
+
+// Register adds an exporter to Factories. See registry.Kind.Register.
+func Register(tag, module, typ string, newFactory func(extensionId string) exporter.Factory) {
+	Registry.Register(tag, module, typ, newFactory)
+}
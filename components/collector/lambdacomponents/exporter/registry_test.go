@@ -0,0 +1,42 @@
+//go:build lambdacomponents.custom
+
+package exporter
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// factory returns the registered factory of the exporter typ, built for the
+// given extension.
+func factory(t *testing.T, typ, extensionId string) exporter.Factory {
+	t.Helper()
+	componentType := component.MustNewType(typ)
+	factories, err := Registry.BuildTypes(extensionId, []component.Type{componentType})
+	if err != nil {
+		t.Fatalf("BuildTypes() = %v", err)
+	}
+	f, ok := factories[componentType]
+	if !ok {
+		t.Fatalf("exporter %q isn't registered", typ)
+	}
+	return f
+}
+
+// settings returns the settings the exporter of factory f is created with.
+func settings(f exporter.Factory) exporter.Settings {
+	return exporter.Settings{
+		ID: component.NewID(f.Type()),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+}
@@ -0,0 +1,65 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.signalfx)
+
+package exporter
+
+import (
+	"context"
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// signalFxCorrelationEnvVar enables trace correlation in the signalfx
+// exporter's traces pipeline.
+const signalFxCorrelationEnvVar = "OCELOT_SIGNALFX_CORRELATION"
+
+func init() {
+	Register("lambdacomponents.exporter.signalfx", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter", "signalfx", func(extensionId string) exporter.Factory {
+		// The realm and token default to the variables Splunk's own Lambda
+		// tooling uses, so they don't have to be written into the config.
+		return signalFxFactory{defaults.Exporter(signalfxexporter.NewFactory(), func(cfg *signalfxexporter.Config) {
+			if realm := os.Getenv("SPLUNK_REALM"); realm != "" {
+				cfg.Realm = realm
+			}
+			if token := os.Getenv("SPLUNK_ACCESS_TOKEN"); token != "" {
+				cfg.AccessToken = configopaque.String(token)
+			}
+			cfg.SyncHostMetadata = false
+		})}
+	})
+}
+
+// signalFxFactory disables trace correlation unless signalFxCorrelationEnvVar
+// is set. The exporter's traces pipeline only correlates services with hosts,
+// through a tracker that batches dimension updates in the background and
+// expires them on timers, which assumes a long-lived host. Without
+// correlation, spans sent to the exporter are dropped, as they would be by the
+// upstream exporter.
+type signalFxFactory struct {
+	exporter.Factory
+}
+
+func (f signalFxFactory) CreateTraces(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+	if lambdaenv.Enabled(signalFxCorrelationEnvVar) {
+		return f.Factory.CreateTraces(ctx, set, cfg)
+	}
+	set.Logger.Info("Trace correlation is disabled, set " + signalFxCorrelationEnvVar + " to enable it")
+	traces, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error { return nil })
+	if err != nil {
+		return nil, err
+	}
+	return discardTraces{Traces: traces}, nil
+}
+
+type discardTraces struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
@@ -0,0 +1,41 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.signalfx)
+
+package exporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+func TestSignalFxDefaults(t *testing.T) {
+	t.Setenv("SPLUNK_REALM", "us1")
+	t.Setenv("SPLUNK_ACCESS_TOKEN", "sfx-token")
+	cfg := factory(t, "signalfx", "").CreateDefaultConfig().(*signalfxexporter.Config)
+	if cfg.Realm != "us1" {
+		t.Errorf("realm = %q, want us1", cfg.Realm)
+	}
+	if cfg.AccessToken != configopaque.String("sfx-token") {
+		t.Errorf("access token = %q, want the one from the environment", cfg.AccessToken)
+	}
+	if cfg.SyncHostMetadata {
+		t.Error("host metadata synced, want it off for execution environments")
+	}
+}
+
+func TestSignalFxCorrelationDisabled(t *testing.T) {
+	t.Setenv(signalFxCorrelationEnvVar, "")
+	f := factory(t, "signalfx", "")
+	cfg := f.CreateDefaultConfig().(*signalfxexporter.Config)
+	cfg.Realm = "us1"
+	cfg.AccessToken = "sfx-token"
+	exp, err := f.CreateTraces(context.Background(), settings(f), cfg)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if _, ok := exp.(discardTraces); !ok {
+		t.Errorf("traces exporter = %T, want spans discarded without correlation", exp)
+	}
+}
@@ -0,0 +1,26 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.splunkhec)
+
+package exporter
+
+import (
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.splunkhec", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter", "splunk_hec", func(extensionId string) exporter.Factory {
+		// The HEC token comes from the environment so it isn't written into
+		// the config, and events are sent synchronously since a queue, in
+		// memory or on disk, doesn't survive the environment being frozen.
+		return defaults.Exporter(splunkhecexporter.NewFactory(), func(cfg *splunkhecexporter.Config) {
+			if token := os.Getenv("SPLUNK_HEC_TOKEN"); token != "" {
+				cfg.Token = configopaque.String(token)
+			}
+			cfg.QueueSettings.Enabled = false
+		})
+	})
+}
@@ -0,0 +1,21 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.splunkhec)
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+func TestSplunkHECDefaults(t *testing.T) {
+	t.Setenv("SPLUNK_HEC_TOKEN", "hec-token")
+	cfg := factory(t, "splunk_hec", "").CreateDefaultConfig().(*splunkhecexporter.Config)
+	if cfg.Token != configopaque.String("hec-token") {
+		t.Errorf("token = %q, want the one from the environment", cfg.Token)
+	}
+	if cfg.QueueSettings.Enabled {
+		t.Error("sending queue enabled, want synchronous sends")
+	}
+}
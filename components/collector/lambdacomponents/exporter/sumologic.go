@@ -0,0 +1,23 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.sumologic)
+
+package exporter
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sumologicexporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.sumologic", "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sumologicexporter", "sumologic", func(extensionId string) exporter.Factory {
+		// There is no durable disk across a freeze for a persistent queue to
+		// use, so send synchronously and fail fast enough to stay within the
+		// invocation.
+		return defaults.Exporter(sumologicexporter.NewFactory(), func(cfg *sumologicexporter.Config) {
+			cfg.QueueSettings.Enabled = false
+			cfg.ClientConfig.Timeout = 5 * time.Second
+		})
+	})
+}
@@ -0,0 +1,20 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.sumologic)
+
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sumologicexporter"
+)
+
+func TestSumoLogicDefaults(t *testing.T) {
+	cfg := factory(t, "sumologic", "").CreateDefaultConfig().(*sumologicexporter.Config)
+	if cfg.QueueSettings.Enabled {
+		t.Error("sending queue enabled, want synchronous sends")
+	}
+	if cfg.ClientConfig.Timeout != 5*time.Second {
+		t.Errorf("timeout = %v, want 5s", cfg.ClientConfig.Timeout)
+	}
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func init() {
-	Factories = append(Factories, func(extensionId string) extension.Factory {
+	Register("lambdacomponents.extension.asmauthextension", "github.com/dev7a/otelcol-ext-asmauth", "asmauthextension", func(extensionId string) extension.Factory {
 		return asmauthextension.NewFactory()
 	})
 }
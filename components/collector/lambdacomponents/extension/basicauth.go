@@ -0,0 +1,29 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.basicauth)
+
+package extension
+
+import (
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/extension"
+)
+
+func init() {
+	Register("lambdacomponents.extension.basicauth", "github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension", "basicauth", func(extensionId string) extension.Factory {
+		// Client credentials can come from the function's environment so they
+		// don't have to be written into the collector configuration.
+		return defaults.Extension(basicauthextension.NewFactory(), func(cfg *basicauthextension.Config) {
+			username, password := os.Getenv("OCELOT_BASICAUTH_USERNAME"), os.Getenv("OCELOT_BASICAUTH_PASSWORD")
+			if username == "" && password == "" {
+				return
+			}
+			cfg.ClientAuth = &basicauthextension.ClientAuthSettings{
+				Username: username,
+				Password: configopaque.String(password),
+			}
+		})
+	})
+}
@@ -0,0 +1,31 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.basicauth)
+
+package extension
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension"
+)
+
+func TestBasicAuthFromEnvironment(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		password string
+		want     *basicauthextension.ClientAuthSettings
+	}{
+		{name: "unset"},
+		{name: "set", username: "ocelot", password: "secret", want: &basicauthextension.ClientAuthSettings{Username: "ocelot", Password: "secret"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OCELOT_BASICAUTH_USERNAME", tt.username)
+			t.Setenv("OCELOT_BASICAUTH_PASSWORD", tt.password)
+			got := factory(t, "basicauth", "").CreateDefaultConfig().(*basicauthextension.Config).ClientAuth
+			if (got == nil) != (tt.want == nil) || got != nil && *got != *tt.want {
+				t.Errorf("client auth = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
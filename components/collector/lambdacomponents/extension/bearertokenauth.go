@@ -0,0 +1,26 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.bearertokenauth)
+
+package extension
+
+import (
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/extension"
+)
+
+func init() {
+	Register("lambdacomponents.extension.bearertokenauth", "github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension", "bearertokenauth", func(extensionId string) extension.Factory {
+		// The token can come from the function's environment so it doesn't
+		// have to be written into the collector configuration. The extension
+		// rejects a token together with `filename`, so leave the variable
+		// unset when reading the token from a file.
+		return defaults.Extension(bearertokenauthextension.NewFactory(), func(cfg *bearertokenauthextension.Config) {
+			if token := os.Getenv("OCELOT_BEARER_TOKEN"); token != "" {
+				cfg.BearerToken = configopaque.String(token)
+			}
+		})
+	})
+}
@@ -0,0 +1,31 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.bearertokenauth)
+
+package extension
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+func TestBearerTokenAuthFromEnvironment(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  configopaque.String
+	}{
+		// Left unset so the token can be read from `filename` instead.
+		{name: "unset"},
+		{name: "set", token: "secret", want: "secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OCELOT_BEARER_TOKEN", tt.token)
+			cfg := factory(t, "bearertokenauth", "").CreateDefaultConfig().(*bearertokenauthextension.Config)
+			if cfg.BearerToken != tt.want {
+				t.Errorf("token = %q, want %q", cfg.BearerToken, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.dbstorage)
+
+package extension
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/extension"
+)
+
+func init() {
+	Register("lambdacomponents.extension.dbstorage", "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage", "db_storage", func(extensionId string) extension.Factory {
+		// A SQLite database under /tmp, one per extension, works without
+		// further configuration, with the same lifetime as file_storage. Point `driver` and
+		// `datasource` at a PostgreSQL database (e.g. on RDS) for a store that
+		// outlives the execution environment and is shared between them.
+		return defaults.Extension(dbstorage.NewFactory(), func(cfg *dbstorage.Config) {
+			cfg.DriverName = "sqlite3"
+			cfg.DataSource = "file:" + defaults.WithExtensionID(extensionId).Join("/tmp/otel-storage", "-") + ".db"
+		})
+	})
+}
@@ -0,0 +1,55 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.dbstorage)
+
+package extension
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestDBStorageDefaults(t *testing.T) {
+	tests := []struct {
+		name           string
+		extensionId    string
+		wantDataSource string
+	}{
+		{name: "stock", wantDataSource: "file:/tmp/otel-storage.db"},
+		{name: "per extension", extensionId: "my-ext", wantDataSource: "file:/tmp/otel-storage-my_ext.db"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := factory(t, "db_storage", tt.extensionId).CreateDefaultConfig().(*dbstorage.Config)
+			if cfg.DriverName != "sqlite3" || cfg.DataSource != tt.wantDataSource {
+				t.Errorf("storage = %s %q, want sqlite3 %q", cfg.DriverName, cfg.DataSource, tt.wantDataSource)
+			}
+		})
+	}
+}
+
+func TestDBStorageSQLite(t *testing.T) {
+	f := factory(t, "db_storage", "")
+	cfg := f.CreateDefaultConfig().(*dbstorage.Config)
+	// Only the data source is configured, the driver stays the default.
+	dataSource := "file:" + filepath.Join(t.TempDir(), "storage.db")
+	if err := confmap.NewFromStringMap(map[string]any{"datasource": dataSource}).Unmarshal(cfg); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	if cfg.DriverName != "sqlite3" || cfg.DataSource != dataSource {
+		t.Fatalf("storage = %s %q, want sqlite3 %q", cfg.DriverName, cfg.DataSource, dataSource)
+	}
+	ext, err := f.Create(context.Background(), settings(f), cfg)
+	if err != nil {
+		t.Fatalf("Create() = %v", err)
+	}
+	if err := ext.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	if err := ext.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() = %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.ecstaskobserver)
+
+package extension
+
+import (
+	"context"
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+func init() {
+	Register("lambdacomponents.extension.ecstaskobserver", "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver", "ecs_task_observer", func(extensionId string) extension.Factory {
+		return ecsTaskObserverFactory{ecstaskobserver.NewFactory()}
+	})
+}
+
+// ecsTaskObserverFactory lets the same layer run in Lambda and in an ECS task.
+// Without a task metadata endpoint the observer would fail to start, so it is
+// replaced by one that never reports an endpoint.
+type ecsTaskObserverFactory struct {
+	extension.Factory
+}
+
+func (f ecsTaskObserverFactory) Create(ctx context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	if ecsCfg, ok := cfg.(*ecstaskobserver.Config); ok && ecsCfg.Endpoint == "" && os.Getenv("ECS_CONTAINER_METADATA_URI_V4") == "" {
+		set.Logger.Info("Not running in an ECS task, ecs_task_observer reports no endpoints")
+		return inertObserver{}, nil
+	}
+	return f.Factory.Create(ctx, set, cfg)
+}
+
+// inertObserver is an observer.Observable with nothing to observe.
+type inertObserver struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (inertObserver) ListAndWatch(observer.Notify) {}
+
+func (inertObserver) Unsubscribe(observer.Notify) {}
@@ -0,0 +1,38 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.ecstaskobserver)
+
+package extension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver"
+)
+
+func TestECSTaskObserverOutsideECS(t *testing.T) {
+	tests := []struct {
+		name        string
+		metadataURI string
+		endpoint    string
+		wantInert   bool
+	}{
+		{name: "Lambda", wantInert: true},
+		{name: "ECS task", metadataURI: "http://169.254.170.2/v4/0123"},
+		{name: "configured endpoint", endpoint: "http://localhost:51678/v4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ECS_CONTAINER_METADATA_URI_V4", tt.metadataURI)
+			f := factory(t, "ecs_task_observer", "")
+			cfg := f.CreateDefaultConfig().(*ecstaskobserver.Config)
+			cfg.Endpoint = tt.endpoint
+			ext, err := f.Create(context.Background(), settings(f), cfg)
+			if err != nil {
+				t.Fatalf("Create() = %v", err)
+			}
+			if _, inert := ext.(inertObserver); inert != tt.wantInert {
+				t.Errorf("observer = %T, want it inert: %t", ext, tt.wantInert)
+			}
+		})
+	}
+}
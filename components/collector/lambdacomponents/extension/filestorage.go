@@ -0,0 +1,26 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.filestorage)
+
+package extension
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/extension"
+)
+
+// fileStorageDirectory is under /tmp, the only writable path in the execution
+// environment. Its contents survive freezes but not a new execution environment.
+const fileStorageDirectory = "/tmp/otel-storage"
+
+func init() {
+	Register("lambdacomponents.extension.filestorage", "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage", "file_storage", func(extensionId string) extension.Factory {
+		// Each extension gets its own directory, created when the extension
+		// starts, not at build time.
+		return defaults.Extension(filestorage.NewFactory(), func(cfg *filestorage.Config) {
+			dir := defaults.WithExtensionID(extensionId).Join(fileStorageDirectory, "/")
+			cfg.Directory = dir
+			cfg.CreateDirectory = true
+			cfg.Compaction.Directory = dir
+		})
+	})
+}
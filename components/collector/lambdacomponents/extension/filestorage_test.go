@@ -0,0 +1,31 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.filestorage)
+
+package extension
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+)
+
+func TestFileStorageDirectory(t *testing.T) {
+	tests := []struct {
+		name        string
+		extensionId string
+		want        string
+	}{
+		{name: "stock", want: "/tmp/otel-storage"},
+		{name: "per extension", extensionId: "my-ext", want: "/tmp/otel-storage/my_ext"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := factory(t, "file_storage", tt.extensionId).CreateDefaultConfig().(*filestorage.Config)
+			if cfg.Directory != tt.want || cfg.Compaction.Directory != tt.want {
+				t.Errorf("directory = %q, compaction directory = %q, want %q", cfg.Directory, cfg.Compaction.Directory, tt.want)
+			}
+			if !cfg.CreateDirectory {
+				t.Error("directory not created on start")
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.headerssetter)
+
+package extension
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension"
+	"go.opentelemetry.io/collector/extension"
+)
+
+func init() {
+	Register("lambdacomponents.extension.headerssetter", "github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension", "headers_setter", func(extensionId string) extension.Factory {
+		return headerssetterextension.NewFactory()
+	})
+}
@@ -0,0 +1,66 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.headerssetter)
+
+package extension
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestHeadersSetterTenant(t *testing.T) {
+	var tenant string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant = r.Header.Get("X-Scope-OrgID")
+	}))
+	defer backend.Close()
+
+	f := factory(t, "headers_setter", "")
+	cfg := f.CreateDefaultConfig()
+	if err := confmap.NewFromStringMap(map[string]any{
+		"headers": []any{map[string]any{"key": "X-Scope-OrgID", "from_context": "tenant_id"}},
+	}).Unmarshal(cfg); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	ext, err := f.Create(context.Background(), settings(f), cfg)
+	if err != nil {
+		t.Fatalf("Create() = %v", err)
+	}
+	if err := ext.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = ext.Shutdown(context.Background()) })
+
+	auth, ok := ext.(interface {
+		RoundTripper(base http.RoundTripper) (http.RoundTripper, error)
+	})
+	if !ok {
+		t.Fatalf("%T isn't an HTTP client authenticator", ext)
+	}
+	rt, err := auth.RoundTripper(http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("RoundTripper() = %v", err)
+	}
+	// The tenant arrives with the data, as metadata of the receiving client.
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"tenant_id": {"acme"}}),
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, backend.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext() = %v", err)
+	}
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		t.Fatalf("Do() = %v", err)
+	}
+	resp.Body.Close()
+
+	if tenant != "acme" {
+		t.Errorf("X-Scope-OrgID = %q, want acme", tenant)
+	}
+}
@@ -0,0 +1,19 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.healthcheck)
+
+package extension
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/extension"
+)
+
+func init() {
+	Register("lambdacomponents.extension.healthcheck", "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension", "health_check", func(extensionId string) extension.Factory {
+		// Only the function in the same execution environment can reach the
+		// collector, so bind the IPv4 loopback explicitly.
+		return defaults.Extension(healthcheckextension.NewFactory(), func(cfg *healthcheckextension.Config) {
+			cfg.ServerConfig.Endpoint = "127.0.0.1:13133"
+		})
+	})
+}
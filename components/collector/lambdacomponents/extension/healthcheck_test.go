@@ -0,0 +1,16 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.healthcheck)
+
+package extension
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+)
+
+func TestHealthCheckLoopback(t *testing.T) {
+	cfg := factory(t, "health_check", "").CreateDefaultConfig().(*healthcheckextension.Config)
+	if cfg.ServerConfig.Endpoint != "127.0.0.1:13133" {
+		t.Errorf("endpoint = %q, want the IPv4 loopback", cfg.ServerConfig.Endpoint)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.oauth2client)
+
+package extension
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension"
+	"go.opentelemetry.io/collector/extension"
+)
+
+func init() {
+	Register("lambdacomponents.extension.oauth2client", "github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension", "oauth2client", func(extensionId string) extension.Factory {
+		// Each extension instance keeps a reusable token source, so a token is
+		// fetched once and shared by warm invocations until it expires.
+		return oauth2clientauthextension.NewFactory()
+	})
+}
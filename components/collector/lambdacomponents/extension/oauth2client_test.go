@@ -0,0 +1,72 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.oauth2client)
+
+package extension
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestOAuth2ClientCachesToken(t *testing.T) {
+	var fetched atomic.Int32
+	tokens := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token-1","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokens.Close()
+	var authorizations []string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+	}))
+	defer backend.Close()
+
+	f := factory(t, "oauth2client", "")
+	cfg := f.CreateDefaultConfig().(*oauth2clientauthextension.Config)
+	cfg.ClientID = "ocelot"
+	cfg.ClientSecret = "secret"
+	cfg.TokenURL = tokens.URL
+	ext, err := f.Create(context.Background(), settings(f), cfg)
+	if err != nil {
+		t.Fatalf("Create() = %v", err)
+	}
+	if err := ext.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = ext.Shutdown(context.Background()) })
+
+	auth, ok := ext.(interface {
+		RoundTripper(base http.RoundTripper) (http.RoundTripper, error)
+	})
+	if !ok {
+		t.Fatalf("%T isn't an HTTP client authenticator", ext)
+	}
+	rt, err := auth.RoundTripper(http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("RoundTripper() = %v", err)
+	}
+	// Two exports, as two warm invocations would make.
+	client := &http.Client{Transport: rt}
+	for range 2 {
+		resp, err := client.Get(backend.URL)
+		if err != nil {
+			t.Fatalf("Get() = %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if got := fetched.Load(); got != 1 {
+		t.Errorf("token fetched %d times, want 1", got)
+	}
+	for _, auth := range authorizations {
+		if auth != "Bearer token-1" {
+			t.Errorf("request authorized with %q, want the fetched token", auth)
+		}
+	}
+}
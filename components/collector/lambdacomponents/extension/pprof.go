@@ -0,0 +1,16 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.pprof)
+
+package extension
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/toggle"
+	"go.opentelemetry.io/collector/extension"
+)
+
+func init() {
+	Register("lambdacomponents.extension.pprof", "github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension", "pprof", func(extensionId string) extension.Factory {
+		// The profiling endpoint is only bound when OCELOT_PPROF_ENABLED is set.
+		return toggle.Extension(pprofextension.NewFactory(), "OCELOT_PPROF_ENABLED")
+	})
+}
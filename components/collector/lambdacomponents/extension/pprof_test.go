@@ -0,0 +1,48 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.pprof)
+
+package extension
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestPprofEndpoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		enabled   string
+		wantBound bool
+	}{
+		{name: "unset"},
+		{name: "disabled", enabled: "false"},
+		{name: "enabled", enabled: "true", wantBound: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OCELOT_PPROF_ENABLED", tt.enabled)
+			f := factory(t, "pprof", "")
+			cfg := f.CreateDefaultConfig().(*pprofextension.Config)
+			cfg.TCPAddr.Endpoint = freeAddr(t)
+			ext, err := f.Create(context.Background(), settings(f), cfg)
+			if err != nil {
+				t.Fatalf("Create() = %v", err)
+			}
+			if err := ext.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+				t.Fatalf("Start() = %v", err)
+			}
+			t.Cleanup(func() { _ = ext.Shutdown(context.Background()) })
+
+			resp, err := http.Get("http://" + cfg.TCPAddr.Endpoint + "/debug/pprof/")
+			if err == nil {
+				resp.Body.Close()
+			}
+			if bound := err == nil && resp.StatusCode == http.StatusOK; bound != tt.wantBound {
+				t.Errorf("endpoint bound = %t (%v), want %t", bound, err, tt.wantBound)
+			}
+		})
+	}
+}
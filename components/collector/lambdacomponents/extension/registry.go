@@ -0,0 +1,18 @@
+//go:build lambdacomponents.custom
+
+package extension
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/registry"
+	"go.opentelemetry.io/collector/extension"
+)
+
+// Registry builds and describes the extensions in Factories. Build is the
+// supported way to read the registrations; Factories is kept for existing
+// callers.
+var Registry = registry.NewKind("extension", &Factories)
+
+// Register adds an extension to Factories. See registry.Kind.Register.
+func Register(tag, module, typ string, newFactory func(extensionId string) extension.Factory) {
+	Registry.Register(tag, module, typ, newFactory)
+}
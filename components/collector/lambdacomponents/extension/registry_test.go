@@ -0,0 +1,54 @@
+//go:build lambdacomponents.custom
+
+package extension
+
+import (
+	"net"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// factory returns the registered factory of the extension typ, built for the
+// given extension.
+func factory(t *testing.T, typ, extensionId string) extension.Factory {
+	t.Helper()
+	componentType := component.MustNewType(typ)
+	factories, err := Registry.BuildTypes(extensionId, []component.Type{componentType})
+	if err != nil {
+		t.Fatalf("BuildTypes() = %v", err)
+	}
+	f, ok := factories[componentType]
+	if !ok {
+		t.Fatalf("extension %q isn't registered", typ)
+	}
+	return f
+}
+
+// settings returns the settings the extension of factory f is created with.
+func settings(f extension.Factory) extension.Settings {
+	return extension.Settings{
+		ID: component.NewID(f.Type()),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+}
+
+// freeAddr returns a loopback address nothing listens on.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() = %v", err)
+	}
+	defer l.Close()
+	return l.Addr().String()
+}
@@ -0,0 +1,21 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.sigv4auth)
+
+package extension
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/sigv4authextension"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"go.opentelemetry.io/collector/extension"
+)
+
+func init() {
+	Register("lambdacomponents.extension.sigv4auth", "github.com/open-telemetry/opentelemetry-collector-contrib/extension/sigv4authextension", "sigv4auth", func(extensionId string) extension.Factory {
+		// Credentials are left to the SDK default chain, which resolves the
+		// execution role from the environment the runtime sets up.
+		return defaults.Extension(sigv4authextension.NewFactory(), func(cfg *sigv4authextension.Config) {
+			cfg.Region = lambdaenv.Region()
+			cfg.AssumeRole.STSRegion = lambdaenv.Region()
+		})
+	})
+}
@@ -0,0 +1,20 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.sigv4auth)
+
+package extension
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/sigv4authextension"
+)
+
+func TestSigV4AuthRegion(t *testing.T) {
+	t.Setenv("AWS_REGION", "ap-southeast-2")
+	cfg := factory(t, "sigv4auth", "").CreateDefaultConfig().(*sigv4authextension.Config)
+	if cfg.Region != "ap-southeast-2" {
+		t.Errorf("region = %q, want the function's region", cfg.Region)
+	}
+	if cfg.AssumeRole.STSRegion != "ap-southeast-2" {
+		t.Errorf("STS region = %q, want the function's region", cfg.AssumeRole.STSRegion)
+	}
+}
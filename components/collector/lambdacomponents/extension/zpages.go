@@ -0,0 +1,20 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.zpages)
+
+package extension
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/toggle"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/zpagesextension"
+)
+
+func init() {
+	Register("lambdacomponents.extension.zpages", "go.opentelemetry.io/collector/extension/zpagesextension", "zpages", func(extensionId string) extension.Factory {
+		// The pages are only served when OCELOT_ZPAGES_ENABLED is set, and only
+		// on loopback by default.
+		return toggle.Extension(defaults.Extension(zpagesextension.NewFactory(), func(cfg *zpagesextension.Config) {
+			cfg.ServerConfig.Endpoint = "localhost:55679"
+		}), "OCELOT_ZPAGES_ENABLED")
+	})
+}
@@ -0,0 +1,54 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.extension.all || lambdacomponents.extension.zpages)
+
+package extension
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/zpagesextension"
+)
+
+func TestZPagesDefaults(t *testing.T) {
+	cfg := factory(t, "zpages", "").CreateDefaultConfig().(*zpagesextension.Config)
+	if cfg.ServerConfig.Endpoint != "localhost:55679" {
+		t.Errorf("endpoint = %q, want localhost:55679", cfg.ServerConfig.Endpoint)
+	}
+}
+
+func TestZPagesToggle(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    string
+		wantServed bool
+	}{
+		{name: "unset"},
+		{name: "enabled", enabled: "true", wantServed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OCELOT_ZPAGES_ENABLED", tt.enabled)
+			f := factory(t, "zpages", "")
+			cfg := f.CreateDefaultConfig().(*zpagesextension.Config)
+			cfg.ServerConfig.Endpoint = freeAddr(t)
+			ext, err := f.Create(context.Background(), settings(f), cfg)
+			if err != nil {
+				t.Fatalf("Create() = %v", err)
+			}
+			if err := ext.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+				t.Fatalf("Start() = %v", err)
+			}
+			t.Cleanup(func() { _ = ext.Shutdown(context.Background()) })
+
+			resp, err := http.Get("http://" + cfg.ServerConfig.Endpoint + "/debug/tracez")
+			if err == nil {
+				resp.Body.Close()
+			}
+			if served := err == nil && resp.StatusCode == http.StatusOK; served != tt.wantServed {
+				t.Errorf("pages served = %t (%v), want %t", served, err, tt.wantServed)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+//go:build lambdacomponents.custom && lambdacomponents.metricsonly
+
+package lambdacomponents
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/assembly"
+	"go.opentelemetry.io/collector/component"
+)
+
+// Run with the selection tags of a build, e.g.
+// -tags lambdacomponents.custom,lambdacomponents.all,lambdacomponents.metricsonly.
+func TestMetricsOnlyProfile(t *testing.T) {
+	factories, err := assembly.Build("extension-id")
+	if err != nil {
+		t.Fatalf("Build() = %v", err)
+	}
+	undefined := component.StabilityLevelUndefined
+
+	tests := []struct {
+		kind string
+		// withoutMetrics lists the types of the components that neither
+		// receive, process nor export metrics.
+		withoutMetrics func() []component.Type
+	}{
+		{kind: "receiver", withoutMetrics: func() (types []component.Type) {
+			for typ, f := range factories.Receivers {
+				if f.MetricsStability() == undefined {
+					types = append(types, typ)
+				}
+			}
+			return types
+		}},
+		{kind: "processor", withoutMetrics: func() (types []component.Type) {
+			for typ, f := range factories.Processors {
+				if f.MetricsStability() == undefined {
+					types = append(types, typ)
+				}
+			}
+			return types
+		}},
+		{kind: "exporter", withoutMetrics: func() (types []component.Type) {
+			for typ, f := range factories.Exporters {
+				if f.MetricsStability() == undefined {
+					types = append(types, typ)
+				}
+			}
+			return types
+		}},
+		// A connector needs metrics pipelines to export from.
+		{kind: "connector", withoutMetrics: func() (types []component.Type) {
+			for typ, f := range factories.Connectors {
+				if f.MetricsToMetricsStability() == undefined && f.MetricsToTracesStability() == undefined && f.MetricsToLogsStability() == undefined {
+					types = append(types, typ)
+				}
+			}
+			return types
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			if types := tt.withoutMetrics(); len(types) > 0 {
+				t.Errorf("%s components without metrics are compiled into the metrics-only profile: %v; add && !lambdacomponents.metricsonly to their build constraint", tt.kind, types)
+			}
+		})
+	}
+}
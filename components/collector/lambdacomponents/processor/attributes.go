@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.attributes)
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/attributesprocessor"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.attributes", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/attributesprocessor", "attributes", func(extensionId string) processor.Factory {
+		return attributesprocessor.NewFactory()
+	})
+}
@@ -0,0 +1,54 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.attributes)
+
+package processor
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestAttributesRedaction(t *testing.T) {
+	f := factory(t, "attributes", "")
+	cfg := f.CreateDefaultConfig()
+	if err := confmap.NewFromStringMap(map[string]any{
+		"actions": []any{
+			map[string]any{"key": "http.request.header.authorization", "action": "delete"},
+			map[string]any{"key": "user.email", "action": "hash"},
+		},
+	}).Unmarshal(cfg); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	sink := new(consumertest.TracesSink)
+	p, err := f.CreateTraces(context.Background(), settings(f), cfg, sink)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	attrs := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes()
+	attrs.PutStr("http.request.header.authorization", "Bearer secret")
+	attrs.PutStr("user.email", "jane@example.com")
+	attrs.PutStr("http.route", "/orders")
+	if err := p.ConsumeTraces(context.Background(), td); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+
+	got := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw()
+	if email, _ := got["user.email"].(string); email == "" || email == "jane@example.com" {
+		t.Errorf("user.email = %v, want it hashed", got["user.email"])
+	}
+	delete(got, "user.email")
+	if want := map[string]any{"http.route": "/orders"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("other attributes = %v, want %v", got, want)
+	}
+}
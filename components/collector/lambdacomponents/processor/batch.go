@@ -0,0 +1,24 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.batch || lambdacomponents.core)
+
+// The core profile batches with this processor.
+
+package processor
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/batchprocessor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.batch", "go.opentelemetry.io/collector/processor/batchprocessor", "batch", func(extensionId string) processor.Factory {
+		// The batch timer doesn't run while the environment is frozen, so keep
+		// the window short. Whatever is still buffered when the invocation ends
+		// is flushed by the processor's Shutdown.
+		return defaults.Processor(batchprocessor.NewFactory(), func(cfg *batchprocessor.Config) {
+			cfg.Timeout = 200 * time.Millisecond
+		})
+	})
+}
@@ -0,0 +1,50 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.batch || lambdacomponents.core)
+
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/batchprocessor"
+)
+
+func TestBatchShutdownDrains(t *testing.T) {
+	f := factory(t, "batch", "")
+	cfg := f.CreateDefaultConfig().(*batchprocessor.Config)
+	if cfg.Timeout != 200*time.Millisecond {
+		t.Errorf("timeout = %v, want 200ms", cfg.Timeout)
+	}
+	// Nothing is flushed by the timer while the test runs, as when the
+	// environment is frozen.
+	cfg.Timeout = time.Hour
+	sink := new(consumertest.TracesSink)
+	p, err := f.CreateTraces(context.Background(), settings(f), cfg, sink)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty()
+	spans.AppendEmpty()
+	if err := p.ConsumeTraces(context.Background(), td); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+	if got := sink.SpanCount(); got != 0 {
+		t.Fatalf("%d spans exported before Shutdown, want them buffered", got)
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() = %v", err)
+	}
+	if got := sink.SpanCount(); got != 2 {
+		t.Errorf("Shutdown() flushed %d spans, want 2", got)
+	}
+}
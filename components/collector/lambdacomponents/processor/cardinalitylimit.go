@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.cardinalitylimit)
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/cardinalitylimitprocessor"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.cardinalitylimit", "github.com/open-telemetry/opentelemetry-lambda/collector/common/cardinalitylimitprocessor", "cardinalitylimit", func(extensionId string) processor.Factory {
+		return cardinalitylimitprocessor.NewFactory()
+	})
+}
@@ -0,0 +1,21 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.cumulativetodelta)
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.cumulativetodelta", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor", "cumulativetodelta", func(extensionId string) processor.Factory {
+		// Conversion state starts empty in every new execution environment, so
+		// the first point of a series only sets the baseline. The initial value
+		// type is internal to the processor and has to be unmarshaled.
+		return defaults.Processor(cumulativetodeltaprocessor.NewFactory(), func(cfg *cumulativetodeltaprocessor.Config) {
+			_ = confmap.NewFromStringMap(map[string]any{"initial_value": "drop"}).Unmarshal(cfg)
+		})
+	})
+}
@@ -0,0 +1,65 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.cumulativetodelta)
+
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestCumulativeToDeltaDropsInitialValue(t *testing.T) {
+	f := factory(t, "cumulativetodelta", "")
+	sink := new(consumertest.MetricsSink)
+	p, err := f.CreateMetrics(context.Background(), settings(f), f.CreateDefaultConfig(), sink)
+	if err != nil {
+		t.Fatalf("CreateMetrics() = %v", err)
+	}
+	if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+	// The series starts after the processor, so the upstream "auto" initial
+	// value would report its first point in full.
+	start := time.Now()
+	for i, value := range []int64{10, 15} {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("invocations")
+		sum := m.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Duration(i+1) * time.Second)))
+		dp.SetIntValue(value)
+		if err := p.ConsumeMetrics(context.Background(), md); err != nil {
+			t.Fatalf("ConsumeMetrics() = %v", err)
+		}
+	}
+
+	if got := sink.DataPointCount(); got != 1 {
+		t.Fatalf("%d points exported, want only the delta of the second", got)
+	}
+	for _, md := range sink.AllMetrics() {
+		for i := range md.ResourceMetrics().Len() {
+			for j := range md.ResourceMetrics().At(i).ScopeMetrics().Len() {
+				metrics := md.ResourceMetrics().At(i).ScopeMetrics().At(j).Metrics()
+				for k := range metrics.Len() {
+					dps := metrics.At(k).Sum().DataPoints()
+					for l := range dps.Len() {
+						if got := dps.At(l).IntValue(); got != 5 {
+							t.Errorf("delta = %d, want 5", got)
+						}
+					}
+				}
+			}
+		}
+	}
+}
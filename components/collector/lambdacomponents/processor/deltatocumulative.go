@@ -0,0 +1,23 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.deltatocumulative)
+
+package processor
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.deltatocumulative", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor", "deltatocumulative", func(extensionId string) processor.Factory {
+		// Accumulated streams only live as long as the execution environment.
+		// Expire idle ones sooner and cap how many are tracked so the state
+		// stays small across warm invocations.
+		return defaults.Processor(deltatocumulativeprocessor.NewFactory(), func(cfg *deltatocumulativeprocessor.Config) {
+			cfg.MaxStale = time.Minute
+			cfg.MaxStreams = 1000
+		})
+	})
+}
@@ -0,0 +1,20 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.deltatocumulative)
+
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+)
+
+func TestDeltaToCumulativeDefaults(t *testing.T) {
+	cfg := factory(t, "deltatocumulative", "").CreateDefaultConfig().(*deltatocumulativeprocessor.Config)
+	if cfg.MaxStale != time.Minute {
+		t.Errorf("streams expire after %v idle, want 1m", cfg.MaxStale)
+	}
+	if cfg.MaxStreams != 1000 {
+		t.Errorf("%d streams tracked, want 1000", cfg.MaxStreams)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.deltatorate)
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatorateprocessor"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.deltatorate", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatorateprocessor", "deltatorate", func(extensionId string) processor.Factory {
+		return deltatorateprocessor.NewFactory()
+	})
+}
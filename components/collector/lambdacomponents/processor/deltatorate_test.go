@@ -0,0 +1,54 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.deltatorate)
+
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestDeltaToRate(t *testing.T) {
+	f := factory(t, "deltatorate", "")
+	cfg := f.CreateDefaultConfig()
+	if err := confmap.NewFromStringMap(map[string]any{"metrics": []any{"invocations"}}).Unmarshal(cfg); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	sink := new(consumertest.MetricsSink)
+	p, err := f.CreateMetrics(context.Background(), settings(f), cfg, sink)
+	if err != nil {
+		t.Fatalf("CreateMetrics() = %v", err)
+	}
+	if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+	start := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("invocations")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(start.Add(5 * time.Second)))
+	dp.SetIntValue(10)
+	if err := p.ConsumeMetrics(context.Background(), md); err != nil {
+		t.Fatalf("ConsumeMetrics() = %v", err)
+	}
+
+	got := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	if got.Type() != pmetric.MetricTypeGauge {
+		t.Fatalf("metric type = %v, want a gauge", got.Type())
+	}
+	if rate := got.Gauge().DataPoints().At(0).DoubleValue(); rate != 2 {
+		t.Errorf("rate = %v per second, want 2", rate)
+	}
+}
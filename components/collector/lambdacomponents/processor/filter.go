@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.filter)
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.filter", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor", "filter", func(extensionId string) processor.Factory {
+		return filterprocessor.NewFactory()
+	})
+}
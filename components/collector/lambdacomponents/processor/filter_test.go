@@ -0,0 +1,52 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.filter)
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestFilterDropsHealthChecks(t *testing.T) {
+	f := factory(t, "filter", "")
+	cfg := f.CreateDefaultConfig()
+	if err := confmap.NewFromStringMap(map[string]any{
+		"traces": map[string]any{"span": []any{`name == "/health"`}},
+	}).Unmarshal(cfg); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	sink := new(consumertest.TracesSink)
+	p, err := f.CreateTraces(context.Background(), settings(f), cfg, sink)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, name := range []string{"/health", "/orders", "/health"} {
+		spans.AppendEmpty().SetName(name)
+	}
+	if err := p.ConsumeTraces(context.Background(), td); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+
+	var got []string
+	for _, td := range sink.AllTraces() {
+		spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for i := range spans.Len() {
+			got = append(got, spans.At(i).Name())
+		}
+	}
+	if len(got) != 1 || got[0] != "/orders" {
+		t.Errorf("exported spans = %v, want [/orders]", got)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.geoip)
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+)
+
+// geoipDatabasePath is where a layer bundles the MaxMind database, so that it
+// doesn't have to be downloaded when the environment starts.
+const geoipDatabasePath = "/opt/geoip/GeoLite2-City.mmdb"
+
+func init() {
+	Register("lambdacomponents.processor.geoip", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor", "geoip", func(extensionId string) processor.Factory {
+		return defaults.Processor(geoipprocessor.NewFactory(), func(cfg *geoipprocessor.Config) {
+			// Provider configurations are internal to the processor and can only
+			// be set through its unmarshaler. If that fails the default has no
+			// provider and the processor reports it when validated.
+			_ = confmap.NewFromStringMap(map[string]any{
+				"providers": map[string]any{
+					"maxmind": map[string]any{"database_path": geoipDatabasePath},
+				},
+			}).Unmarshal(cfg)
+		})
+	})
+}
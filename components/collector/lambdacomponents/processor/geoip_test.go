@@ -0,0 +1,19 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.geoip)
+
+package processor
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestGeoIPBundledDatabase(t *testing.T) {
+	conf := confmap.New()
+	if err := conf.Marshal(factory(t, "geoip", "").CreateDefaultConfig()); err != nil {
+		t.Fatalf("Marshal() = %v", err)
+	}
+	if got := conf.Get("providers::maxmind::database_path"); got != geoipDatabasePath {
+		t.Errorf("maxmind database = %v, want the bundled %s", got, geoipDatabasePath)
+	}
+}
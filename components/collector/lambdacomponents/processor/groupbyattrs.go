@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.groupbyattrs)
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.groupbyattrs", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor", "groupbyattrs", func(extensionId string) processor.Factory {
+		return groupbyattrsprocessor.NewFactory()
+	})
+}
@@ -0,0 +1,51 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.groupbyattrs)
+
+package processor
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestGroupByAttrsTenant(t *testing.T) {
+	f := factory(t, "groupbyattrs", "")
+	cfg := f.CreateDefaultConfig()
+	if err := confmap.NewFromStringMap(map[string]any{"keys": []any{"tenant"}}).Unmarshal(cfg); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	sink := new(consumertest.TracesSink)
+	p, err := f.CreateTraces(context.Background(), settings(f), cfg, sink)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, tenant := range []string{"acme", "globex", "acme"} {
+		spans.AppendEmpty().Attributes().PutStr("tenant", tenant)
+	}
+	if err := p.ConsumeTraces(context.Background(), td); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+
+	var tenants []string
+	got := sink.AllTraces()[0].ResourceSpans()
+	for i := range got.Len() {
+		tenant, _ := got.At(i).Resource().Attributes().Get("tenant")
+		tenants = append(tenants, tenant.Str())
+	}
+	slices.Sort(tenants)
+	if want := []string{"acme", "globex"}; !slices.Equal(tenants, want) {
+		t.Errorf("resources of tenants %v, want %v", tenants, want)
+	}
+}
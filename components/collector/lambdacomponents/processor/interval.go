@@ -0,0 +1,101 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.interval)
+
+package processor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.interval", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor", "interval", func(extensionId string) processor.Factory {
+		// The interval timer doesn't fire while the environment is frozen, so
+		// keep the window short.
+		return intervalFactory{defaults.Processor(intervalprocessor.NewFactory(), func(cfg *intervalprocessor.Config) {
+			cfg.Interval = time.Second
+		})}
+	})
+}
+
+// intervalFactory makes the processor emit the metrics of the current window
+// when it shuts down. The upstream processor only emits when its timer fires,
+// so Shutdown waits for one more tick, bounded by the interval and the
+// shutdown deadline, when metrics have been received since the last emission.
+type intervalFactory struct {
+	processor.Factory
+}
+
+func (f intervalFactory) CreateMetrics(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Metrics) (processor.Metrics, error) {
+	interval := time.Second
+	if c, ok := cfg.(*intervalprocessor.Config); ok {
+		interval = c.Interval
+	}
+	p := &flushingInterval{next: next, interval: interval, emitted: make(chan struct{}, 1)}
+	upstream, err := f.Factory.CreateMetrics(ctx, set, cfg, emitted{p})
+	if err != nil {
+		return nil, err
+	}
+	p.Metrics = upstream
+	return p, nil
+}
+
+type flushingInterval struct {
+	processor.Metrics
+	next     consumer.Metrics
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending bool
+	emitted chan struct{}
+}
+
+func (p *flushingInterval) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	p.mu.Lock()
+	p.pending = true
+	p.mu.Unlock()
+	return p.Metrics.ConsumeMetrics(ctx, md)
+}
+
+func (p *flushingInterval) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	pending := p.pending
+	p.mu.Unlock()
+	if pending {
+		timer := time.NewTimer(p.interval + p.interval/10)
+		select {
+		case <-p.emitted:
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+		timer.Stop()
+	}
+	return p.Metrics.Shutdown(ctx)
+}
+
+// emitted forwards what the upstream processor emits, noting the emission.
+type emitted struct {
+	p *flushingInterval
+}
+
+func (e emitted) Capabilities() consumer.Capabilities {
+	return e.p.next.Capabilities()
+}
+
+func (e emitted) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	e.p.mu.Lock()
+	e.p.pending = false
+	e.p.mu.Unlock()
+	select {
+	case e.p.emitted <- struct{}{}:
+	default:
+	}
+	return e.p.next.ConsumeMetrics(ctx, md)
+}
@@ -0,0 +1,52 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.interval)
+
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestIntervalShutdownEmits(t *testing.T) {
+	f := factory(t, "interval", "")
+	cfg := f.CreateDefaultConfig().(*intervalprocessor.Config)
+	if cfg.Interval != time.Second {
+		t.Errorf("interval = %v, want 1s", cfg.Interval)
+	}
+	sink := new(consumertest.MetricsSink)
+	p, err := f.CreateMetrics(context.Background(), settings(f), cfg, sink)
+	if err != nil {
+		t.Fatalf("CreateMetrics() = %v", err)
+	}
+	if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+
+	// Cumulative sums are held until the window closes.
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("invocations")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	dp.SetIntValue(3)
+	if err := p.ConsumeMetrics(context.Background(), md); err != nil {
+		t.Fatalf("ConsumeMetrics() = %v", err)
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() = %v", err)
+	}
+
+	if got := sink.DataPointCount(); got != 1 {
+		t.Errorf("%d points emitted by shutdown, want 1", got)
+	}
+}
@@ -0,0 +1,78 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.k8sattributes)
+
+package processor
+
+import (
+	"context"
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.k8sattributes", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor", "k8sattributes", func(extensionId string) processor.Factory {
+		return k8sAttributesFactory{k8sattributesprocessor.NewFactory()}
+	})
+}
+
+// k8sAttributesFactory lets the same layer run in Lambda and in a Kubernetes
+// pod (e.g. EKS on Fargate). Outside a cluster the processor can't reach the
+// API server with its service account and would fail to start, so it is
+// replaced by one that passes data through unmodified.
+type k8sAttributesFactory struct {
+	processor.Factory
+}
+
+func (f k8sAttributesFactory) CreateTraces(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+	if !k8sAttributesAvailable(set, cfg) {
+		return passthroughTraces{Traces: next}, nil
+	}
+	return f.Factory.CreateTraces(ctx, set, cfg, next)
+}
+
+func (f k8sAttributesFactory) CreateMetrics(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Metrics) (processor.Metrics, error) {
+	if !k8sAttributesAvailable(set, cfg) {
+		return passthroughMetrics{Metrics: next}, nil
+	}
+	return f.Factory.CreateMetrics(ctx, set, cfg, next)
+}
+
+func (f k8sAttributesFactory) CreateLogs(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+	if !k8sAttributesAvailable(set, cfg) {
+		return passthroughLogs{Logs: next}, nil
+	}
+	return f.Factory.CreateLogs(ctx, set, cfg, next)
+}
+
+// k8sAttributesAvailable reports whether the processor can authenticate. Only
+// the service account auth type depends on running inside a cluster, which is
+// detected the same way the Kubernetes client does.
+func k8sAttributesAvailable(set processor.Settings, cfg component.Config) bool {
+	k8sCfg, ok := cfg.(*k8sattributesprocessor.Config)
+	if !ok || string(k8sCfg.AuthType) != "serviceAccount" || os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return true
+	}
+	set.Logger.Info("Not running in a Kubernetes cluster, k8sattributes passes data through unmodified")
+	return false
+}
+
+type passthroughTraces struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
+
+type passthroughMetrics struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Metrics
+}
+
+type passthroughLogs struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Logs
+}
@@ -0,0 +1,63 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.k8sattributes)
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestK8sAttributesOutsideCluster(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	f := factory(t, "k8sattributes", "")
+	sink := new(consumertest.TracesSink)
+	p, err := f.CreateTraces(context.Background(), settings(f), f.CreateDefaultConfig(), sink)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	if err := p.ConsumeTraces(context.Background(), td); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+	if got := sink.SpanCount(); got != 1 {
+		t.Errorf("%d spans passed through, want 1", got)
+	}
+}
+
+func TestK8sAttributesAvailable(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceHost string
+		authType    string
+		want        bool
+	}{
+		{name: "service account outside a cluster", authType: "serviceAccount"},
+		{name: "service account in a cluster", serviceHost: "10.0.0.1", authType: "serviceAccount", want: true},
+		{name: "kubeconfig outside a cluster", authType: "kubeConfig", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KUBERNETES_SERVICE_HOST", tt.serviceHost)
+			f := factory(t, "k8sattributes", "")
+			cfg := f.CreateDefaultConfig().(*k8sattributesprocessor.Config)
+			if err := confmap.NewFromStringMap(map[string]any{"auth_type": tt.authType}).Unmarshal(cfg); err != nil {
+				t.Fatalf("Unmarshal() = %v", err)
+			}
+			if got := k8sAttributesAvailable(settings(f), cfg); got != tt.want {
+				t.Errorf("k8sAttributesAvailable() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
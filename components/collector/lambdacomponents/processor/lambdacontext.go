@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.lambdacontext) && !lambdacomponents.metricsonly
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdacontextprocessor"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.lambdacontext", "github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdacontextprocessor", "lambdacontext", func(extensionId string) processor.Factory {
+		return lambdacontextprocessor.NewFactory()
+	})
+}
@@ -0,0 +1,22 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.logdedup) && !lambdacomponents.metricsonly
+
+package processor
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.logdedup", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor", "logdedup", func(extensionId string) processor.Factory {
+		// Deduplicated logs are only emitted when the window closes, and the
+		// timer doesn't run while the environment is frozen. A short window
+		// emits them within the invocation that produced them.
+		return defaults.Processor(logdedupprocessor.NewFactory(), func(cfg *logdedupprocessor.Config) {
+			cfg.Interval = time.Second
+		})
+	})
+}
@@ -0,0 +1,17 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.logdedup) && !lambdacomponents.metricsonly
+
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+)
+
+func TestLogDedupInterval(t *testing.T) {
+	cfg := factory(t, "logdedup", "").CreateDefaultConfig().(*logdedupprocessor.Config)
+	if cfg.Interval != time.Second {
+		t.Errorf("window = %v, want 1s so logs are emitted within the invocation", cfg.Interval)
+	}
+}
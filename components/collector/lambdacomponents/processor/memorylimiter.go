@@ -0,0 +1,36 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.memorylimiter || lambdacomponents.core)
+
+// The core profile bounds the collector's memory with this processor.
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+)
+
+// minFunctionMemoryMiB is the smallest memory size Lambda allows, used when the
+// configured size can't be read from the environment.
+const minFunctionMemoryMiB = 128
+
+func init() {
+	Register("lambdacomponents.processor.memorylimiter", "go.opentelemetry.io/collector/processor/memorylimiterprocessor", "memory_limiter", func(extensionId string) processor.Factory {
+		return defaults.Processor(memorylimiterprocessor.NewFactory(), func(cfg *memorylimiterprocessor.Config) {
+			cfg.MemoryLimitMiB, cfg.MemorySpikeLimitMiB = memoryLimits()
+		})
+	})
+}
+
+// memoryLimits sizes the limiter from the function's memory. The collector
+// shares that memory with the runtime and the function code, so only a quarter
+// of it is claimed, with a fifth of the limit reserved for spikes.
+func memoryLimits() (limit, spike uint32) {
+	size, ok := lambdaenv.MemorySizeMiB()
+	if !ok {
+		size = minFunctionMemoryMiB
+	}
+	limit = size / 4
+	return limit, limit / 5
+}
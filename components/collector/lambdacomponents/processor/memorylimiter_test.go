@@ -0,0 +1,33 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.memorylimiter || lambdacomponents.core)
+
+package processor
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+)
+
+func TestMemoryLimiterDefaults(t *testing.T) {
+	tests := []struct {
+		name       string
+		memorySize string
+		wantLimit  uint32
+		wantSpike  uint32
+	}{
+		{name: "function memory", memorySize: "1024", wantLimit: 256, wantSpike: 51},
+		// The smallest function, 128 MiB, is assumed.
+		{name: "unset", wantLimit: 32, wantSpike: 6},
+		{name: "invalid", memorySize: "1GB", wantLimit: 32, wantSpike: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", tt.memorySize)
+			cfg := factory(t, "memory_limiter", "").CreateDefaultConfig().(*memorylimiterprocessor.Config)
+			if cfg.MemoryLimitMiB != tt.wantLimit || cfg.MemorySpikeLimitMiB != tt.wantSpike {
+				t.Errorf("limit_mib = %d, spike_limit_mib = %d, want %d and %d",
+					cfg.MemoryLimitMiB, cfg.MemorySpikeLimitMiB, tt.wantLimit, tt.wantSpike)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.metricstransform)
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.metricstransform", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor", "metricstransform", func(extensionId string) processor.Factory {
+		return metricstransformprocessor.NewFactory()
+	})
+}
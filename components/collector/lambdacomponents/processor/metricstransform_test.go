@@ -0,0 +1,44 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.metricstransform)
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMetricsTransformRename(t *testing.T) {
+	f := factory(t, "metricstransform", "")
+	cfg := f.CreateDefaultConfig()
+	if err := confmap.NewFromStringMap(map[string]any{
+		"transforms": []any{map[string]any{"include": "cold_starts", "action": "update", "new_name": "faas.coldstarts"}},
+	}).Unmarshal(cfg); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	sink := new(consumertest.MetricsSink)
+	p, err := f.CreateMetrics(context.Background(), settings(f), cfg, sink)
+	if err != nil {
+		t.Fatalf("CreateMetrics() = %v", err)
+	}
+	if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("cold_starts")
+	m.SetEmptySum().DataPoints().AppendEmpty().SetIntValue(1)
+	if err := p.ConsumeMetrics(context.Background(), md); err != nil {
+		t.Fatalf("ConsumeMetrics() = %v", err)
+	}
+
+	if got := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name(); got != "faas.coldstarts" {
+		t.Errorf("metric name = %q, want faas.coldstarts", got)
+	}
+}
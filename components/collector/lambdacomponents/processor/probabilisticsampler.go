@@ -0,0 +1,64 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.probabilisticsampler) && !lambdacomponents.metricsonly
+
+package processor
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.probabilisticsampler", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor", "probabilistic_sampler", func(extensionId string) processor.Factory {
+		// The upstream defaults are kept: the hash seed is a fixed value, so a
+		// trace ID gets the same decision in every invocation and environment.
+		factory := probabilisticsamplerprocessor.NewFactory()
+		percent, ok, err := defaults.SamplingOverride()
+		if !ok && err == nil {
+			return factory
+		}
+		return probabilisticSamplerOverride{Factory: factory, percent: percent, err: err}
+	})
+}
+
+// probabilisticSamplerOverride creates samplers keeping the percentage set in
+// OCELOT_SAMPLING_OVERRIDE, whatever their configuration says. err is the
+// error reading the variable, returned when a sampler is created.
+type probabilisticSamplerOverride struct {
+	processor.Factory
+	percent float64
+	err     error
+}
+
+func (f probabilisticSamplerOverride) override(cfg component.Config) (component.Config, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	samplerCfg, ok := cfg.(*probabilisticsamplerprocessor.Config)
+	if !ok {
+		return cfg, nil
+	}
+	overridden := *samplerCfg
+	overridden.SamplingPercentage = float32(f.percent)
+	return &overridden, nil
+}
+
+func (f probabilisticSamplerOverride) CreateTraces(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+	cfg, err := f.override(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return f.Factory.CreateTraces(ctx, set, cfg, next)
+}
+
+func (f probabilisticSamplerOverride) CreateLogs(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+	cfg, err := f.override(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return f.Factory.CreateLogs(ctx, set, cfg, next)
+}
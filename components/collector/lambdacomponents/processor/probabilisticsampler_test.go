@@ -0,0 +1,63 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.probabilisticsampler) && !lambdacomponents.metricsonly
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestProbabilisticSamplerOverride(t *testing.T) {
+	tests := []struct {
+		name      string
+		override  string
+		wantSpans int
+		wantErr   string
+	}{
+		// The sampler is configured to drop every trace.
+		{name: "absent", wantSpans: 0},
+		{name: "full sampling", override: "100", wantSpans: 10},
+		{name: "invalid", override: "all", wantErr: `OCELOT_SAMPLING_OVERRIDE must be a percentage between 0 and 100, got "all"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OCELOT_SAMPLING_OVERRIDE", tt.override)
+			f := factory(t, "probabilistic_sampler", "")
+			cfg := f.CreateDefaultConfig().(*probabilisticsamplerprocessor.Config)
+			cfg.SamplingPercentage = 0
+			sink := new(consumertest.TracesSink)
+			p, err := f.CreateTraces(context.Background(), settings(f), cfg, sink)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("CreateTraces() = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateTraces() = %v", err)
+			}
+			if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+				t.Fatalf("Start() = %v", err)
+			}
+			t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+			td := ptrace.NewTraces()
+			spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+			for i := range 10 {
+				spans.AppendEmpty().SetTraceID(pcommon.TraceID{byte(i + 1), 0xab, 0xcd, 15: byte(i + 1)})
+			}
+			if err := p.ConsumeTraces(context.Background(), td); err != nil {
+				t.Fatalf("ConsumeTraces() = %v", err)
+			}
+			if got := sink.SpanCount(); got != tt.wantSpans {
+				t.Errorf("%d spans sampled, want %d", got, tt.wantSpans)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.ratelimit)
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/ratelimitprocessor"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.ratelimit", "github.com/open-telemetry/opentelemetry-lambda/collector/common/ratelimitprocessor", "ratelimit", func(extensionId string) processor.Factory {
+		return ratelimitprocessor.NewFactory()
+	})
+}
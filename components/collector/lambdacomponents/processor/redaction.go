@@ -0,0 +1,26 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.redaction)
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/processor"
+)
+
+// redactionBlockedValues mask credentials that commonly leak into attributes.
+// Configuring `blocked_values` replaces the list.
+var redactionBlockedValues = []string{
+	// AWS access key IDs, long-term (AKIA) and temporary (ASIA).
+	`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+	// Bearer tokens in authorization values.
+	`(?i)bearer\s+[a-z0-9\-._~+/]+=*`,
+}
+
+func init() {
+	Register("lambdacomponents.processor.redaction", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor", "redaction", func(extensionId string) processor.Factory {
+		return defaults.Processor(redactionprocessor.NewFactory(), func(cfg *redactionprocessor.Config) {
+			cfg.BlockedValues = append([]string(nil), redactionBlockedValues...)
+		})
+	})
+}
@@ -0,0 +1,18 @@
+//go:build lambdacomponents.custom
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/registry"
+	"go.opentelemetry.io/collector/processor"
+)
+
+// Registry builds and describes the processors in Factories. Build is the
+// supported way to read the registrations; Factories is kept for existing
+// callers.
+var Registry = registry.NewKind("processor", &Factories)
+
+// Register adds a processor to Factories. See registry.Kind.Register.
+func Register(tag, module, typ string, newFactory func(extensionId string) processor.Factory) {
+	Registry.Register(tag, module, typ, newFactory)
+}
@@ -0,0 +1,105 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.resource)
+
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourceprocessor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.resource", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourceprocessor", "resource", func(extensionId string) processor.Factory {
+		// The action type lives in an internal contrib package, so the defaults
+		// are unmarshaled the same way user configuration is. The registry
+		// reports a panic creating the default configuration as a build error.
+		f := defaults.Processor(resourceprocessor.NewFactory(), func(cfg *resourceprocessor.Config) {
+			if err := withLambdaActions(cfg); err != nil {
+				panic(err)
+			}
+		})
+		return resourceFactory{Factory: f}
+	})
+}
+
+// resourceFactory adds the Lambda upserts to the processors it creates when the
+// user's `attributes` list, which replaces the default one, leaves them out.
+type resourceFactory struct {
+	processor.Factory
+}
+
+func (f resourceFactory) CreateTraces(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+	cfg, err := lambdaActionsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return f.Factory.CreateTraces(ctx, set, cfg, next)
+}
+
+func (f resourceFactory) CreateMetrics(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Metrics) (processor.Metrics, error) {
+	cfg, err := lambdaActionsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return f.Factory.CreateMetrics(ctx, set, cfg, next)
+}
+
+func (f resourceFactory) CreateLogs(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+	cfg, err := lambdaActionsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return f.Factory.CreateLogs(ctx, set, cfg, next)
+}
+
+// lambdaActionsConfig returns a copy of cfg with the Lambda upserts added, or
+// cfg itself if it isn't a resource processor configuration.
+func lambdaActionsConfig(cfg component.Config) (component.Config, error) {
+	c, ok := cfg.(*resourceprocessor.Config)
+	if !ok {
+		return cfg, nil
+	}
+	merged := *c
+	if err := withLambdaActions(&merged); err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+// withLambdaActions puts an upsert for each faas and cloud resource attribute
+// that the execution environment exposes before the actions of cfg, unless
+// one of them already acts on its key. The actions of cfg run last, so they
+// still override the Lambda values.
+func withLambdaActions(cfg *resourceprocessor.Config) error {
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err != nil {
+		return fmt.Errorf("failed to add the Lambda resource attributes: %w", err)
+	}
+	configured, _ := conf.Get("attributes").([]any)
+	keys := make(map[string]bool, len(configured))
+	for _, action := range configured {
+		if action, ok := action.(map[string]any); ok {
+			keys[fmt.Sprint(action["key"])] = true
+		}
+	}
+	var actions []any
+	for _, attr := range lambdaenv.ResourceAttributes() {
+		if !keys[attr.Key] {
+			actions = append(actions, map[string]any{"key": attr.Key, "value": attr.Value, "action": "upsert"})
+		}
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+	if err := confmap.NewFromStringMap(map[string]any{"attributes": append(actions, configured...)}).Unmarshal(cfg); err != nil {
+		return fmt.Errorf("failed to add the Lambda resource attributes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.resource)
+
+package processor
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestResourceDefaults(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "checkout")
+	t.Setenv("AWS_LAMBDA_FUNCTION_VERSION", "$LATEST")
+	t.Setenv("AWS_REGION", "eu-west-1")
+
+	conf := confmap.New()
+	if err := conf.Marshal(factory(t, "resource", "").CreateDefaultConfig()); err != nil {
+		t.Fatalf("Marshal() = %v", err)
+	}
+	got := make(map[string]any)
+	for _, action := range conf.Get("attributes").([]any) {
+		action := action.(map[string]any)
+		if action["action"] != "upsert" {
+			t.Errorf("action on %v = %v, want upsert", action["key"], action["action"])
+		}
+		got[action["key"].(string)] = action["value"]
+	}
+	want := map[string]any{"faas.name": "checkout", "faas.version": "$LATEST", "cloud.region": "eu-west-1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("default upserts = %v, want %v", got, want)
+	}
+}
+
+func TestResourceUserAttributes(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "checkout")
+	t.Setenv("AWS_LAMBDA_FUNCTION_VERSION", "$LATEST")
+	t.Setenv("AWS_REGION", "eu-west-1")
+
+	tests := []struct {
+		name       string
+		attributes []any
+		want       map[string]any
+	}{
+		{
+			name: "defaults",
+			want: map[string]any{"faas.name": "checkout", "faas.version": "$LATEST", "cloud.region": "eu-west-1"},
+		},
+		{
+			name: "user attributes",
+			attributes: []any{
+				map[string]any{"key": "faas.name", "value": "orders", "action": "upsert"},
+				map[string]any{"key": "cloud.region", "action": "delete"},
+				map[string]any{"key": "team", "value": "payments", "action": "insert"},
+			},
+			want: map[string]any{"faas.name": "orders", "faas.version": "$LATEST", "team": "payments"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory(t, "resource", "")
+			cfg := f.CreateDefaultConfig()
+			if tt.attributes != nil {
+				if err := confmap.NewFromStringMap(map[string]any{"attributes": tt.attributes}).Unmarshal(cfg); err != nil {
+					t.Fatalf("Unmarshal() = %v", err)
+				}
+			}
+			sink := new(consumertest.TracesSink)
+			p, err := f.CreateTraces(context.Background(), settings(f), cfg, sink)
+			if err != nil {
+				t.Fatalf("CreateTraces() = %v", err)
+			}
+			if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+				t.Fatalf("Start() = %v", err)
+			}
+			t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+			td := ptrace.NewTraces()
+			td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			if err := p.ConsumeTraces(context.Background(), td); err != nil {
+				t.Fatalf("ConsumeTraces() = %v", err)
+			}
+			got := sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().AsRaw()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resource attributes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
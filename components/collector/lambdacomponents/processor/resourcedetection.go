@@ -0,0 +1,19 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.resourcedetection)
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.resourcedetection", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor", "resourcedetection", func(extensionId string) processor.Factory {
+		// The lambda detector reads the faas and cloud attributes from the
+		// execution environment without any network calls.
+		return defaults.Processor(resourcedetectionprocessor.NewFactory(), func(cfg *resourcedetectionprocessor.Config) {
+			cfg.Detectors = []string{"env", "lambda"}
+		})
+	})
+}
@@ -0,0 +1,48 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.resourcedetection)
+
+package processor
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestResourceDetectionLambda(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "checkout")
+	t.Setenv("AWS_REGION", "eu-west-1")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "team=payments")
+
+	f := factory(t, "resourcedetection", "")
+	cfg := f.CreateDefaultConfig().(*resourcedetectionprocessor.Config)
+	if want := []string{"env", "lambda"}; !slices.Equal(cfg.Detectors, want) {
+		t.Errorf("detectors = %v, want %v", cfg.Detectors, want)
+	}
+	sink := new(consumertest.TracesSink)
+	p, err := f.CreateTraces(context.Background(), settings(f), cfg, sink)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	if err := p.ConsumeTraces(context.Background(), td); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+
+	got := sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().AsRaw()
+	for key, want := range map[string]string{"faas.name": "checkout", "cloud.region": "eu-west-1", "team": "payments"} {
+		if got[key] != want {
+			t.Errorf("%s = %v, want %q", key, got[key], want)
+		}
+	}
+}
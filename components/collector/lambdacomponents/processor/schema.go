@@ -0,0 +1,171 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.schema)
+
+package processor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+)
+
+const (
+	// schemaPathEnvVar names the directory schema files are read from. A
+	// schema URL is resolved to the file named after its last path element, so
+	// https://opentelemetry.io/schemas/1.26.0 is read from <dir>/1.26.0.
+	schemaPathEnvVar  = "OCELOT_SCHEMA_PATH"
+	defaultSchemaPath = "/tmp/otel-schemas"
+	schemaFilesType   = "ocelot_schema_files"
+)
+
+func init() {
+	Register("lambdacomponents.processor.schema", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor", "schema", func(extensionId string) processor.Factory {
+		return schemaFactory{schemaprocessor.NewFactory()}
+	})
+}
+
+// schemaFactory reads schema files from the local filesystem instead of
+// fetching them, which would add a network round trip to every cold start.
+// The processor fetches schemas with the HTTP client from its configuration,
+// so when no authenticator is configured it is given one whose transport
+// serves the files from OCELOT_SCHEMA_PATH.
+type schemaFactory struct {
+	processor.Factory
+}
+
+func (f schemaFactory) CreateTraces(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+	cfg, host := schemaFilesConfig(cfg)
+	p, err := f.Factory.CreateTraces(ctx, set, cfg, next)
+	if err != nil || host == nil {
+		return p, err
+	}
+	return schemaTraces{Traces: p, files: host}, nil
+}
+
+func (f schemaFactory) CreateMetrics(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Metrics) (processor.Metrics, error) {
+	cfg, host := schemaFilesConfig(cfg)
+	p, err := f.Factory.CreateMetrics(ctx, set, cfg, next)
+	if err != nil || host == nil {
+		return p, err
+	}
+	return schemaMetrics{Metrics: p, files: host}, nil
+}
+
+func (f schemaFactory) CreateLogs(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+	cfg, host := schemaFilesConfig(cfg)
+	p, err := f.Factory.CreateLogs(ctx, set, cfg, next)
+	if err != nil || host == nil {
+		return p, err
+	}
+	return schemaLogs{Logs: p, files: host}, nil
+}
+
+// schemaFilesConfig returns a copy of cfg that authenticates with the schema
+// files transport, and the authenticator to add to the host. It leaves a
+// configuration with its own authenticator unchanged.
+func schemaFilesConfig(cfg component.Config) (component.Config, *schemaFiles) {
+	schemaCfg, ok := cfg.(*schemaprocessor.Config)
+	if !ok || schemaCfg.Auth != nil {
+		return cfg, nil
+	}
+	dir := os.Getenv(schemaPathEnvVar)
+	if dir == "" {
+		dir = defaultSchemaPath
+	}
+	files := &schemaFiles{
+		id:  component.NewID(component.MustNewType(schemaFilesType)),
+		dir: dir,
+	}
+	local := *schemaCfg
+	local.Auth = &configauth.Config{AuthenticatorID: files.id}
+	return &local, files
+}
+
+// schemaFiles is the client authenticator whose transport reads schema files
+// from dir. It never sends a request over the network.
+type schemaFiles struct {
+	component.StartFunc
+	component.ShutdownFunc
+	id  component.ID
+	dir string
+}
+
+func (s *schemaFiles) RoundTripper(http.RoundTripper) (http.RoundTripper, error) {
+	return s, nil
+}
+
+func (s *schemaFiles) RoundTrip(req *http.Request) (*http.Response, error) {
+	name := path.Base(req.URL.Path)
+	if name == "/" || name == "." {
+		return nil, fmt.Errorf("no schema file for %s", req.URL)
+	}
+	f, err := os.Open(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("no schema file for %s in %s: %w", req.URL, s.dir, err)
+	}
+	return &http.Response{
+		Status:     http.StatusText(http.StatusOK),
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       io.NopCloser(f),
+		Request:    req,
+	}, nil
+}
+
+// host adds the authenticator to the extensions of the collector's host.
+func (s *schemaFiles) host(host component.Host) component.Host {
+	return schemaHost{Host: host, files: s}
+}
+
+type schemaHost struct {
+	component.Host
+	files *schemaFiles
+}
+
+func (h schemaHost) GetExtensions() map[component.ID]component.Component {
+	extensions := make(map[component.ID]component.Component)
+	for id, ext := range h.Host.GetExtensions() {
+		extensions[id] = ext
+	}
+	extensions[h.files.id] = h.files
+	return extensions
+}
+
+type schemaTraces struct {
+	processor.Traces
+	files *schemaFiles
+}
+
+func (p schemaTraces) Start(ctx context.Context, host component.Host) error {
+	return p.Traces.Start(ctx, p.files.host(host))
+}
+
+type schemaMetrics struct {
+	processor.Metrics
+	files *schemaFiles
+}
+
+func (p schemaMetrics) Start(ctx context.Context, host component.Host) error {
+	return p.Metrics.Start(ctx, p.files.host(host))
+}
+
+type schemaLogs struct {
+	processor.Logs
+	files *schemaFiles
+}
+
+func (p schemaLogs) Start(ctx context.Context, host component.Host) error {
+	return p.Logs.Start(ctx, p.files.host(host))
+}
@@ -0,0 +1,60 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.schema)
+
+package processor
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+)
+
+func TestSchemaFilesConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(schemaPathEnvVar, dir)
+	if err := os.WriteFile(filepath.Join(dir, "1.26.0"), []byte("file_format: 1.1.0\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() = %v", err)
+	}
+
+	cfg := factory(t, "schema", "").CreateDefaultConfig().(*schemaprocessor.Config)
+	local, files := schemaFilesConfig(cfg)
+	if files == nil {
+		t.Fatal("schemaFilesConfig() = nil authenticator without a configured one")
+	}
+	if auth := local.(*schemaprocessor.Config).Auth; auth == nil || auth.AuthenticatorID != files.id {
+		t.Errorf("auth = %+v, want the schema files authenticator", auth)
+	}
+	if cfg.Auth != nil {
+		t.Error("schemaFilesConfig() modified the configuration it was given")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://opentelemetry.io/schemas/1.26.0", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() = %v", err)
+	}
+	resp, err := files.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() = %v", err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "file_format: 1.1.0\n" {
+		t.Errorf("schema = %q, want the file in %s", body, dir)
+	}
+	req.URL.Path = "/schemas/1.27.0"
+	if _, err := files.RoundTrip(req); err == nil {
+		t.Error("RoundTrip() = nil for a schema without a file")
+	}
+}
+
+func TestSchemaOwnAuthenticator(t *testing.T) {
+	cfg := factory(t, "schema", "").CreateDefaultConfig().(*schemaprocessor.Config)
+	cfg.Auth = &configauth.Config{AuthenticatorID: component.MustNewID("oauth2client")}
+	if got, files := schemaFilesConfig(cfg); got != component.Config(cfg) || files != nil {
+		t.Errorf("schemaFilesConfig() = %+v, %v, want the configuration unchanged", got, files)
+	}
+}
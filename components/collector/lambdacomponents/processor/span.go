@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.span) && !lambdacomponents.metricsonly
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanprocessor"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.span", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanprocessor", "span", func(extensionId string) processor.Factory {
+		return spanprocessor.NewFactory()
+	})
+}
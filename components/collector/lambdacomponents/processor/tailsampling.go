@@ -0,0 +1,63 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.tailsampling) && !lambdacomponents.metricsonly
+
+package processor
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.tailsampling", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor", "tail_sampling", func(extensionId string) processor.Factory {
+		// Traces produced by a single invocation complete quickly, and the
+		// environment may be frozen before a long decision window elapses.
+		// The trace and decision caches outlive invocations, so bound them.
+		factory := defaults.Processor(tailsamplingprocessor.NewFactory(), func(cfg *tailsamplingprocessor.Config) {
+			cfg.DecisionWait = 2 * time.Second
+			cfg.NumTraces = 5000
+			cfg.DecisionCache.SampledCacheSize = 1000
+			cfg.DecisionCache.NonSampledCacheSize = 1000
+		})
+		percent, ok, err := defaults.SamplingOverride()
+		if !ok && err == nil {
+			return factory
+		}
+		return tailSamplingOverride{Factory: factory, percent: percent, err: err}
+	})
+}
+
+// tailSamplingOverride creates samplers whose configured policies are
+// replaced by a single one keeping the percentage set in
+// OCELOT_SAMPLING_OVERRIDE. err is the error reading the variable, returned
+// when a sampler is created.
+type tailSamplingOverride struct {
+	processor.Factory
+	percent float64
+	err     error
+}
+
+func (f tailSamplingOverride) CreateTraces(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if samplerCfg, ok := cfg.(*tailsamplingprocessor.Config); ok {
+		var policy tailsamplingprocessor.PolicyCfg
+		policy.Name = "ocelot-sampling-override"
+		if f.percent >= 100 {
+			policy.Type = tailsamplingprocessor.AlwaysSample
+		} else {
+			policy.Type = tailsamplingprocessor.Probabilistic
+			policy.ProbabilisticCfg.SamplingPercentage = f.percent
+		}
+		overridden := *samplerCfg
+		overridden.PolicyCfgs = []tailsamplingprocessor.PolicyCfg{policy}
+		cfg = &overridden
+	}
+	return f.Factory.CreateTraces(ctx, set, cfg, next)
+}
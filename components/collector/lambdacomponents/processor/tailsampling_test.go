@@ -0,0 +1,34 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.tailsampling) && !lambdacomponents.metricsonly
+
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestTailSamplingDefaults(t *testing.T) {
+	cfg := factory(t, "tail_sampling", "").CreateDefaultConfig().(*tailsamplingprocessor.Config)
+	if cfg.DecisionWait != 2*time.Second {
+		t.Errorf("decision wait = %v, want 2s", cfg.DecisionWait)
+	}
+	if cfg.NumTraces != 5000 {
+		t.Errorf("traces held = %d, want 5000", cfg.NumTraces)
+	}
+	if cfg.DecisionCache.SampledCacheSize != 1000 || cfg.DecisionCache.NonSampledCacheSize != 1000 {
+		t.Errorf("decision cache = %+v, want 1000 sampled and 1000 not sampled", cfg.DecisionCache)
+	}
+}
+
+func TestTailSamplingInvalidOverride(t *testing.T) {
+	t.Setenv("OCELOT_SAMPLING_OVERRIDE", "-5")
+	f := factory(t, "tail_sampling", "")
+	_, err := f.CreateTraces(context.Background(), settings(f), f.CreateDefaultConfig(), consumertest.NewNop())
+	if want := `OCELOT_SAMPLING_OVERRIDE must be a percentage between 0 and 100, got "-5"`; err == nil || err.Error() != want {
+		t.Errorf("CreateTraces() = %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.transform)
+
+package processor
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func init() {
+	Register("lambdacomponents.processor.transform", "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor", "transform", func(extensionId string) processor.Factory {
+		return transformprocessor.NewFactory()
+	})
+}
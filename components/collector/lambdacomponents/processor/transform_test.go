@@ -0,0 +1,48 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.processor.all || lambdacomponents.processor.transform)
+
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestTransformStatements(t *testing.T) {
+	f := factory(t, "transform", "")
+	cfg := f.CreateDefaultConfig()
+	if err := confmap.NewFromStringMap(map[string]any{
+		"log_statements": []any{`set(log.severity_text, "ERROR") where IsMatch(log.body, "^ERROR ")`},
+	}).Unmarshal(cfg); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	sink := new(consumertest.LogsSink)
+	p, err := f.CreateLogs(context.Background(), settings(f), cfg, sink)
+	if err != nil {
+		t.Fatalf("CreateLogs() = %v", err)
+	}
+	if err := p.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range []string{"ERROR payment declined", "order created"} {
+		records.AppendEmpty().Body().SetStr(body)
+	}
+	if err := p.ConsumeLogs(context.Background(), ld); err != nil {
+		t.Fatalf("ConsumeLogs() = %v", err)
+	}
+
+	got := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	for i, want := range []string{"ERROR", ""} {
+		if severity := got.At(i).SeverityText(); severity != want {
+			t.Errorf("severity of %q = %q, want %q", got.At(i).Body().Str(), severity, want)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.awscloudwatch)
+
+package receiver
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscloudwatchmetricsreceiver"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+func init() {
+	Register("lambdacomponents.receiver.awscloudwatch", "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscloudwatchmetricsreceiver", "awscloudwatchmetrics", func(extensionId string) receiver.Factory {
+		return awscloudwatchmetricsreceiver.NewFactory()
+	})
+}
@@ -0,0 +1,38 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.awscloudwatch)
+
+package receiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscloudwatchmetricsreceiver"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestAWSCloudWatchMetricsConfig(t *testing.T) {
+	f := factory(t, "awscloudwatchmetrics", "")
+	cfg := f.CreateDefaultConfig().(*awscloudwatchmetricsreceiver.Config)
+	if err := confmap.NewFromStringMap(map[string]any{
+		"region":        "eu-west-1",
+		"poll_interval": "5m",
+		"metrics": map[string]any{
+			"named": []any{map[string]any{
+				"namespace":       "AWS/Lambda",
+				"metric_name":     "Throttles",
+				"period":          "5m",
+				"aws_aggregation": "Sum",
+				"dimensions":      []any{map[string]any{"Name": "FunctionName", "Value": "checkout"}},
+			}},
+		},
+	}).Unmarshal(cfg); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	if _, err := f.CreateMetrics(context.Background(), settings(f), cfg, consumertest.NewNop()); err != nil {
+		t.Errorf("CreateMetrics() = %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.awsfirehose)
+
+package receiver
+
+import (
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+func init() {
+	Register("lambdacomponents.receiver.awsfirehose", "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver", "awsfirehose", func(extensionId string) receiver.Factory {
+		// Listen on loopback, for a delivery stream reaching the function
+		// through its own handler or a proxy in front of it, and take the
+		// access key Firehose sends from the environment rather than the
+		// config.
+		return defaults.Receiver(awsfirehosereceiver.NewFactory(), func(cfg *awsfirehosereceiver.Config) {
+			cfg.ServerConfig.Endpoint = "localhost:4433"
+			if key := os.Getenv("OCELOT_FIREHOSE_ACCESS_KEY"); key != "" {
+				cfg.AccessKey = configopaque.String(key)
+			}
+		})
+	})
+}
@@ -0,0 +1,21 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.awsfirehose)
+
+package receiver
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsfirehosereceiver"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+func TestAWSFirehoseDefaults(t *testing.T) {
+	t.Setenv("OCELOT_FIREHOSE_ACCESS_KEY", "firehose-key")
+	cfg := factory(t, "awsfirehose", "").CreateDefaultConfig().(*awsfirehosereceiver.Config)
+	if cfg.ServerConfig.Endpoint != "localhost:4433" {
+		t.Errorf("endpoint = %q, want localhost:4433", cfg.ServerConfig.Endpoint)
+	}
+	if cfg.AccessKey != configopaque.String("firehose-key") {
+		t.Errorf("access key = %q, want the one from the environment", cfg.AccessKey)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.filelog) && !lambdacomponents.metricsonly
+
+package receiver
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+func init() {
+	Register("lambdacomponents.receiver.filelog", "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver", "filelog", func(extensionId string) receiver.Factory {
+		// Start at the end of the files so a restarted collector doesn't ship
+		// lines again. Offsets are only kept across restarts when `storage`
+		// points at a storage extension, e.g. `storage: file_storage`; it is not
+		// defaulted because the receiver fails to start if that extension isn't
+		// configured.
+		return defaults.Receiver(filelogreceiver.NewFactory(), func(cfg *filelogreceiver.FileLogConfig) {
+			cfg.InputConfig.StartAt = "end"
+		})
+	})
+}
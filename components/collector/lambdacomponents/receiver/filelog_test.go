@@ -0,0 +1,21 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.filelog) && !lambdacomponents.metricsonly
+
+package receiver
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver"
+)
+
+func TestFileLogStartsAtEnd(t *testing.T) {
+	cfg := factory(t, "filelog", "").CreateDefaultConfig().(*filelogreceiver.FileLogConfig)
+	if cfg.InputConfig.StartAt != "end" {
+		t.Errorf("start_at = %q, want end so a restarted collector doesn't ship lines again", cfg.InputConfig.StartAt)
+	}
+	// Storage is left to the user, as the receiver fails to start without the
+	// extension it names.
+	if cfg.StorageID != nil {
+		t.Errorf("storage = %v, want none by default", cfg.StorageID)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.otlp || lambdacomponents.core)
+
+// The core profile receives the function's telemetry with this receiver.
+
+package receiver
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver"
+)
+
+func init() {
+	Register("lambdacomponents.receiver.otlp", "go.opentelemetry.io/collector/receiver/otlpreceiver", "otlp", func(extensionId string) receiver.Factory {
+		// The function sends to the extension over the loopback interface of
+		// the shared execution environment. Protocols that are not listed in
+		// the user configuration are still disabled by the receiver.
+		return defaults.Receiver(otlpreceiver.NewFactory(), func(cfg *otlpreceiver.Config) {
+			if cfg.Protocols.GRPC != nil {
+				cfg.Protocols.GRPC.NetAddr.Endpoint = "127.0.0.1:4317"
+			}
+			if cfg.Protocols.HTTP != nil {
+				cfg.Protocols.HTTP.ServerConfig.Endpoint = "127.0.0.1:4318"
+			}
+		})
+	})
+}
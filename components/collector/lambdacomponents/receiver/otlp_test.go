@@ -0,0 +1,35 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.otlp || lambdacomponents.core)
+
+package receiver
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver"
+)
+
+func TestOTLPLoopback(t *testing.T) {
+	cfg := factory(t, "otlp", "").CreateDefaultConfig().(*otlpreceiver.Config)
+	if cfg.Protocols.GRPC == nil || cfg.Protocols.GRPC.NetAddr.Endpoint != "127.0.0.1:4317" {
+		t.Errorf("gRPC protocol = %+v, want it on 127.0.0.1:4317", cfg.Protocols.GRPC)
+	}
+	if cfg.Protocols.HTTP == nil || cfg.Protocols.HTTP.ServerConfig.Endpoint != "127.0.0.1:4318" {
+		t.Errorf("HTTP protocol = %+v, want it on 127.0.0.1:4318", cfg.Protocols.HTTP)
+	}
+}
+
+func TestOTLPListedProtocols(t *testing.T) {
+	cfg := factory(t, "otlp", "").CreateDefaultConfig().(*otlpreceiver.Config)
+	if err := confmap.NewFromStringMap(map[string]any{
+		"protocols": map[string]any{"http": map[string]any{}},
+	}).Unmarshal(cfg); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	if cfg.Protocols.GRPC != nil {
+		t.Errorf("gRPC protocol = %+v, want it disabled when only http is listed", cfg.Protocols.GRPC)
+	}
+	if cfg.Protocols.HTTP == nil || cfg.Protocols.HTTP.ServerConfig.Endpoint != "127.0.0.1:4318" {
+		t.Errorf("HTTP protocol = %+v, want it on 127.0.0.1:4318", cfg.Protocols.HTTP)
+	}
+}
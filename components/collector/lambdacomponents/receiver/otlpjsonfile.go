@@ -0,0 +1,22 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.otlpjson)
+
+package receiver
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+func init() {
+	Register("lambdacomponents.receiver.otlpjson", "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver", "otlpjsonfile", func(extensionId string) receiver.Factory {
+		// Replay captures from the start: by default, files the file exporter
+		// writes under /tmp. The receiver can't read the zstd files it writes
+		// by default, so captures meant for replay are written uncompressed,
+		// with `compression: ""` and a path ending in .json.
+		return defaults.Receiver(otlpjsonfilereceiver.NewFactory(), func(cfg *otlpjsonfilereceiver.Config) {
+			cfg.Include = []string{"/tmp/otelcol-signals*.json"}
+			cfg.StartAt = "beginning"
+		})
+	})
+}
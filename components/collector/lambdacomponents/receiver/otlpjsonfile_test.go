@@ -0,0 +1,65 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.otlpjson)
+
+package receiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestOTLPJSONFileDefaults(t *testing.T) {
+	cfg := factory(t, "otlpjsonfile", "").CreateDefaultConfig().(*otlpjsonfilereceiver.Config)
+	if want := []string{"/tmp/otelcol-signals*.json"}; !slices.Equal(cfg.Include, want) {
+		t.Errorf("include = %v, want %v", cfg.Include, want)
+	}
+	if cfg.StartAt != "beginning" {
+		t.Errorf("start_at = %q, want beginning", cfg.StartAt)
+	}
+}
+
+func TestOTLPJSONFileReplay(t *testing.T) {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("captured")
+	line, err := (&ptrace.JSONMarshaler{}).MarshalTraces(td)
+	if err != nil {
+		t.Fatalf("MarshalTraces() = %v", err)
+	}
+	// Captured before the receiver starts, as a previous invocation would.
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "otelcol-signals.json"), append(line, '\n'), 0o600); err != nil {
+		t.Fatalf("WriteFile() = %v", err)
+	}
+
+	f := factory(t, "otlpjsonfile", "")
+	cfg := f.CreateDefaultConfig().(*otlpjsonfilereceiver.Config)
+	cfg.Include = []string{filepath.Join(dir, "otelcol-signals*.json")}
+	sink := new(consumertest.TracesSink)
+	r, err := f.CreateTraces(context.Background(), settings(f), cfg, sink)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if err := r.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	deadline := time.Now().Add(5 * time.Second)
+	for sink.SpanCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if got := sink.SpanCount(); got != 1 {
+		t.Fatalf("%d spans replayed, want 1", got)
+	}
+	if name := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name(); name != "captured" {
+		t.Errorf("replayed span %q, want captured", name)
+	}
+}
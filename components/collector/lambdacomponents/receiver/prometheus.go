@@ -0,0 +1,49 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.prometheus)
+
+package receiver
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"github.com/prometheus/common/model"
+	promconfig "github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/discovery"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+func init() {
+	Register("lambdacomponents.receiver.prometheus", "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver", "prometheus", func(extensionId string) receiver.Factory {
+		// There is no service discovery in Lambda, so scrape the endpoint the
+		// function itself exposes on loopback. Scrapes don't run while the
+		// environment is frozen; a short interval makes one happen in most
+		// invocations. A `config` in the collector configuration replaces
+		// this one entirely.
+		return defaults.Receiver(prometheusreceiver.NewFactory(), func(cfg *prometheusreceiver.Config) {
+			cfg.PrometheusConfig = lambdaScrapeConfig()
+		})
+	})
+}
+
+func lambdaScrapeConfig() *prometheusreceiver.PromConfig {
+	interval := model.Duration(5 * time.Second)
+	timeout := model.Duration(2 * time.Second)
+
+	global := promconfig.DefaultGlobalConfig
+	global.ScrapeInterval = interval
+	global.ScrapeTimeout = timeout
+
+	scrape := promconfig.DefaultScrapeConfig
+	scrape.JobName = "lambda"
+	scrape.ScrapeInterval = interval
+	scrape.ScrapeTimeout = timeout
+	scrape.ServiceDiscoveryConfigs = discovery.Configs{
+		discovery.StaticConfig{{Targets: []model.LabelSet{{model.AddressLabel: "localhost:9090"}}}},
+	}
+
+	return &prometheusreceiver.PromConfig{
+		GlobalConfig:  global,
+		ScrapeConfigs: []*promconfig.ScrapeConfig{&scrape},
+	}
+}
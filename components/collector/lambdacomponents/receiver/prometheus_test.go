@@ -0,0 +1,33 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.prometheus)
+
+package receiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/discovery"
+)
+
+func TestPrometheusScrapeConfig(t *testing.T) {
+	cfg := factory(t, "prometheus", "").CreateDefaultConfig().(*prometheusreceiver.Config)
+	if cfg.PrometheusConfig == nil || len(cfg.PrometheusConfig.ScrapeConfigs) != 1 {
+		t.Fatalf("prometheus config = %+v, want a single scrape job", cfg.PrometheusConfig)
+	}
+	scrape := cfg.PrometheusConfig.ScrapeConfigs[0]
+	if scrape.JobName != "lambda" {
+		t.Errorf("job = %q, want lambda", scrape.JobName)
+	}
+	if scrape.ScrapeInterval != model.Duration(5*time.Second) || scrape.ScrapeTimeout != model.Duration(2*time.Second) {
+		t.Errorf("scrapes every %v timing out after %v, want 5s and 2s", scrape.ScrapeInterval, scrape.ScrapeTimeout)
+	}
+	if len(scrape.ServiceDiscoveryConfigs) != 1 {
+		t.Fatalf("service discovery = %v, want the static target only", scrape.ServiceDiscoveryConfigs)
+	}
+	static, ok := scrape.ServiceDiscoveryConfigs[0].(discovery.StaticConfig)
+	if !ok || len(static) != 1 || len(static[0].Targets) != 1 || static[0].Targets[0][model.AddressLabel] != "localhost:9090" {
+		t.Errorf("targets = %v, want localhost:9090 only", scrape.ServiceDiscoveryConfigs)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build lambdacomponents.custom
+
+package receiver
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/registry"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// Registry builds and describes the receivers in Factories. Build is the
+// supported way to read the registrations; Factories is kept for existing
+// callers.
+var Registry = registry.NewKind("receiver", &Factories)
+
+// Register adds a receiver to Factories. See registry.Kind.Register.
+func Register(tag, module, typ string, newFactory func(extensionId string) receiver.Factory) {
+	Registry.Register(tag, module, typ, newFactory)
+}
@@ -0,0 +1,27 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.statsd)
+
+package receiver
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+func init() {
+	Register("lambdacomponents.receiver.statsd", "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver", "statsd", func(extensionId string) receiver.Factory {
+		// Packets still in the socket buffer or metrics not yet aggregated
+		// when the environment freezes are lost, and the aggregation timer
+		// doesn't run while it is frozen. Listen on loopback for the function
+		// only, and aggregate over a short interval so metrics flush within
+		// the invocation that produced them.
+		return defaults.Receiver(statsdreceiver.NewFactory(), func(cfg *statsdreceiver.Config) {
+			cfg.NetAddr.Endpoint = "localhost:8125"
+			cfg.NetAddr.Transport = confignet.TransportTypeUDP
+			cfg.AggregationInterval = time.Second
+		})
+	})
+}
@@ -0,0 +1,21 @@
+//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.statsd)
+
+package receiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver"
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+func TestStatsDDefaults(t *testing.T) {
+	cfg := factory(t, "statsd", "").CreateDefaultConfig().(*statsdreceiver.Config)
+	if cfg.NetAddr.Endpoint != "localhost:8125" || cfg.NetAddr.Transport != confignet.TransportTypeUDP {
+		t.Errorf("listens on %s %q, want udp on localhost:8125", cfg.NetAddr.Transport, cfg.NetAddr.Endpoint)
+	}
+	if cfg.AggregationInterval != time.Second {
+		t.Errorf("aggregation interval = %v, want 1s", cfg.AggregationInterval)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build lambdacomponents.custom && lambdacomponents.receiver.synthetic
+
+// The synthetic receiver is a self-test aid and must be requested explicitly:
+// it is deliberately left out of lambdacomponents.all and receiver.all.
+
+package receiver
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/syntheticreceiver"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+func init() {
+	Register("lambdacomponents.receiver.synthetic", "github.com/open-telemetry/opentelemetry-lambda/collector/common/syntheticreceiver", "synthetic", func(extensionId string) receiver.Factory {
+		return syntheticreceiver.NewFactory()
+	})
+}
@@ -0,0 +1,160 @@
+//go:build lambdacomponents.custom
+
+// Package assembly is the entry point the collector uses to check and gather
+// the components registered by every lambdacomponents package.
+package assembly
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/buildhash"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/circuitbreaker"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/fallback"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/registry"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/selftelemetry"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/startupprobe"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/connector"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/exporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/extension"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/processor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/receiver"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/otelcol"
+)
+
+// Build assembles the registered components of every kind into the factories
+// the collector service is created from. It fails if OCELOT_ENABLED_COMPONENTS
+// asks for a component that isn't compiled in. Exporters are instrumented when
+// OCELOT_SELF_TELEMETRY is set, guarded by a circuit breaker when listed in
+// OCELOT_CIRCUIT_BREAKER, backed by S3 when listed in OCELOT_S3_FALLBACK and
+// probe their backend when they start if OCELOT_STARTUP_PROBE is set. Every
+// exporter stamps the data it exports with the ocelot.build.hash resource
+// attribute, the hash of Manifest, and the components are drained by Shutdown
+// when the environment shuts down, as OCELOT_ON_FREEZE selects.
+func Build(extensionId string) (otelcol.Factories, error) {
+	receivers, rErr := receiver.Registry.Build(extensionId)
+	processors, pErr := processor.Registry.Build(extensionId)
+	exporters, eErr := exporter.Registry.Build(extensionId)
+	connectors, cErr := connector.Registry.Build(extensionId)
+	extensions, xErr := extension.Registry.Build(extensionId)
+	if err := errors.Join(rErr, pErr, eErr, cErr, xErr); err != nil {
+		return otelcol.Factories{}, err
+	}
+	if err := registry.CheckEnabled(map[string][]component.Type{
+		"receiver":  registry.Types(receivers),
+		"processor": registry.Types(processors),
+		"exporter":  registry.Types(exporters),
+		"connector": registry.Types(connectors),
+		"extension": registry.Types(extensions),
+	}); err != nil {
+		return otelcol.Factories{}, err
+	}
+	return decorate(otelcol.Factories{
+		Receivers:  receivers,
+		Processors: processors,
+		Exporters:  exporters,
+		Connectors: connectors,
+		Extensions: extensions,
+	})
+}
+
+// BuildForConfig is Build limited to the components declared in the YAML
+// collector configuration cfg: the factories of the other compiled-in
+// components are never constructed, which shortens the cold start. The
+// components the configuration converters add are constructed too. It fails
+// if cfg declares a component that isn't compiled in, naming the build tag
+// that selects it, rather than leaving the collector to reject an unknown
+// type, and if a connector lacks a pipeline on one of its sides, which would
+// drop what it is given.
+func BuildForConfig(extensionId string, cfg []byte) (otelcol.Factories, error) {
+	if err := errors.Join(
+		ValidateConfigComponents(cfg),
+		ValidateConnectorPipelines(extensionId, cfg),
+	); err != nil {
+		return otelcol.Factories{}, err
+	}
+	types, err := configTypes(cfg)
+	if err != nil {
+		return otelcol.Factories{}, err
+	}
+	// The resource defaults converter adds a resource processor the
+	// configuration doesn't declare.
+	if len(lambdaenv.ResourceAttributes()) > 0 && !slices.Contains(types["processor"], resourceType) {
+		types["processor"] = append(types["processor"], resourceType)
+	}
+	receivers, rErr := receiver.Registry.BuildTypes(extensionId, types["receiver"])
+	processors, pErr := processor.Registry.BuildTypes(extensionId, types["processor"])
+	exporters, eErr := exporter.Registry.BuildTypes(extensionId, types["exporter"])
+	connectors, cErr := connector.Registry.BuildTypes(extensionId, types["connector"])
+	extensions, xErr := extension.Registry.BuildTypes(extensionId, types["extension"])
+	if err := errors.Join(rErr, pErr, eErr, cErr, xErr); err != nil {
+		return otelcol.Factories{}, err
+	}
+	compiled := make(map[string][]component.Type)
+	for _, info := range Manifest() {
+		compiled[info.Kind] = append(compiled[info.Kind], component.MustNewType(info.Name))
+	}
+	if err := registry.CheckEnabled(compiled); err != nil {
+		return otelcol.Factories{}, err
+	}
+	return decorate(otelcol.Factories{
+		Receivers:  receivers,
+		Processors: processors,
+		Exporters:  exporters,
+		Connectors: connectors,
+		Extensions: extensions,
+	})
+}
+
+// decorate wraps the assembled factories with the opt-in behavior selected by
+// environment variables.
+func decorate(factories otelcol.Factories) (otelcol.Factories, error) {
+	// The fallback wraps the circuit breaker, so exports an open breaker
+	// rejects are spilled to S3 too.
+	factories, err := startupprobe.Wrap(factories)
+	if err != nil {
+		return otelcol.Factories{}, err
+	}
+	if factories, err = circuitbreaker.Wrap(factories); err != nil {
+		return otelcol.Factories{}, err
+	}
+	if factories, err = fallback.Wrap(factories); err != nil {
+		return otelcol.Factories{}, err
+	}
+	// The build hash is stamped before the fallback, so spilled data carries
+	// it too.
+	factories = buildhash.Wrap(factories, Manifest())
+	// Tracking comes last, so Shutdown drains the components through every
+	// other wrapper.
+	return track(selftelemetry.Wrap(factories))
+}
+
+// Validate checks the registrations of every component kind, and that the
+// default configuration of every component can be created. It constructs
+// every compiled-in factory, so the dry run calls it rather than the cold
+// start.
+func Validate(extensionId string) error {
+	if _, err := Build(extensionId); err != nil {
+		return err
+	}
+	return errors.Join(
+		receiver.Registry.Validate(extensionId),
+		processor.Registry.Validate(extensionId),
+		exporter.Registry.Validate(extensionId),
+		connector.Registry.Validate(extensionId),
+		extension.Registry.Validate(extensionId),
+	)
+}
+
+// Manifest lists every component compiled into the collector, grouped by kind.
+func Manifest() []registry.ComponentInfo {
+	var infos []registry.ComponentInfo
+	infos = append(infos, receiver.Registry.Manifest()...)
+	infos = append(infos, processor.Registry.Manifest()...)
+	infos = append(infos, exporter.Registry.Manifest()...)
+	infos = append(infos, connector.Registry.Manifest()...)
+	infos = append(infos, extension.Registry.Manifest()...)
+	return infos
+}
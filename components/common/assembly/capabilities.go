@@ -0,0 +1,104 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"errors"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/connector"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/exporter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/extension"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/processor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/receiver"
+	"go.opentelemetry.io/collector/component"
+)
+
+// ComponentCapability describes the signals a component handles. Inputs are
+// the signals it consumes and Outputs the signals it emits: a receiver has no
+// inputs, an exporter no outputs and an extension neither. A connector
+// supports the Connections listed, each from one of its inputs to one of its
+// outputs.
+type ComponentCapability struct {
+	Kind        string       `json:"kind"`
+	Inputs      []string     `json:"inputs"`
+	Outputs     []string     `json:"outputs"`
+	Connections []Connection `json:"connections,omitempty"`
+}
+
+// Connection is a pair of signals a connector connects.
+type Connection struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Capabilities reports the capabilities of every component available in this
+// layer, keyed by kind and type (e.g. "exporter/awss3"), since components of
+// different kinds can share a type. Only the factories are created: the
+// signals are read from the stability they declare for each. Components left
+// out by OCELOT_ENABLED_COMPONENTS or OCELOT_DISABLE_COMPONENTS aren't
+// reported.
+func Capabilities() (map[string]ComponentCapability, error) {
+	receivers, rErr := receiver.Registry.Build("")
+	processors, pErr := processor.Registry.Build("")
+	exporters, eErr := exporter.Registry.Build("")
+	connectors, cErr := connector.Registry.Build("")
+	extensions, xErr := extension.Registry.Build("")
+	if err := errors.Join(rErr, pErr, eErr, cErr, xErr); err != nil {
+		return nil, err
+	}
+
+	capabilities := make(map[string]ComponentCapability)
+	add := func(kind string, typ component.Type, c ComponentCapability) {
+		c.Kind = kind
+		capabilities[kind+"/"+typ.String()] = c
+	}
+	for typ, f := range receivers {
+		add("receiver", typ, ComponentCapability{Inputs: []string{}, Outputs: supportedSignals(f.TracesStability, f.MetricsStability, f.LogsStability)})
+	}
+	for typ, f := range processors {
+		supported := supportedSignals(f.TracesStability, f.MetricsStability, f.LogsStability)
+		add("processor", typ, ComponentCapability{Inputs: supported, Outputs: supported})
+	}
+	for typ, f := range exporters {
+		add("exporter", typ, ComponentCapability{Inputs: supportedSignals(f.TracesStability, f.MetricsStability, f.LogsStability), Outputs: []string{}})
+	}
+	for typ, f := range connectors {
+		c := ComponentCapability{Inputs: []string{}, Outputs: []string{}}
+		inputs := make(map[string]bool)
+		outputs := make(map[string]bool)
+		for _, from := range signals {
+			for _, to := range signals {
+				if connectionSupported(f, from, to) {
+					c.Connections = append(c.Connections, Connection{From: from, To: to})
+					inputs[from], outputs[to] = true, true
+				}
+			}
+		}
+		// Listed in the order of signals, like the connections.
+		for _, s := range signals {
+			if inputs[s] {
+				c.Inputs = append(c.Inputs, s)
+			}
+			if outputs[s] {
+				c.Outputs = append(c.Outputs, s)
+			}
+		}
+		add("connector", typ, c)
+	}
+	for typ := range extensions {
+		add("extension", typ, ComponentCapability{Inputs: []string{}, Outputs: []string{}})
+	}
+	return capabilities, nil
+}
+
+// supportedSignals returns the signals, in the order of signals, whose
+// stability isn't undefined.
+func supportedSignals(traces, metrics, logs func() component.StabilityLevel) []string {
+	supported := []string{}
+	for i, stability := range []func() component.StabilityLevel{traces, metrics, logs} {
+		if stability() != component.StabilityLevelUndefined {
+			supported = append(supported, signals[i])
+		}
+	}
+	return supported
+}
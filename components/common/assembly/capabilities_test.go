@@ -0,0 +1,90 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/receiver"
+	"go.opentelemetry.io/collector/component"
+	otelconnector "go.opentelemetry.io/collector/connector"
+	otelreceiver "go.opentelemetry.io/collector/receiver"
+)
+
+func TestCapabilities(t *testing.T) {
+	saved := slices.Clone(receiver.Factories)
+	t.Cleanup(func() { receiver.Factories = saved })
+	receiver.Register("lambdacomponents.receiver.fakereceiver", "example.com/fakereceiver", "fakereceiver", func(string) otelreceiver.Factory {
+		return otelreceiver.NewFactory(component.MustNewType("fakereceiver"),
+			func() component.Config { return &fakeConfig{} },
+			otelreceiver.WithMetrics(nil, component.StabilityLevelBeta),
+			otelreceiver.WithLogs(nil, component.StabilityLevelAlpha))
+	})
+	registerProcessor(t, "lambdacomponents.processor.fake", "fake")
+	registerExporter(t, "fakeexporter", nil)
+	registerConnector(t, "spans",
+		otelconnector.WithLogsToMetrics(nil, component.StabilityLevelAlpha),
+		otelconnector.WithTracesToMetrics(nil, component.StabilityLevelStable),
+		otelconnector.WithTracesToTraces(nil, component.StabilityLevelStable))
+
+	capabilities, err := Capabilities()
+	if err != nil {
+		t.Fatalf("Capabilities() = %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want ComponentCapability
+	}{
+		{
+			key:  "receiver/fakereceiver",
+			want: ComponentCapability{Kind: "receiver", Inputs: []string{}, Outputs: []string{"metrics", "logs"}},
+		},
+		{
+			key:  "processor/fake",
+			want: ComponentCapability{Kind: "processor", Inputs: []string{}, Outputs: []string{}},
+		},
+		{
+			key:  "exporter/fakeexporter",
+			want: ComponentCapability{Kind: "exporter", Inputs: []string{"traces"}, Outputs: []string{}},
+		},
+		{
+			key: "connector/spans",
+			want: ComponentCapability{
+				Kind:    "connector",
+				Inputs:  []string{"traces", "logs"},
+				Outputs: []string{"traces", "metrics"},
+				Connections: []Connection{
+					{From: "traces", To: "traces"},
+					{From: "traces", To: "metrics"},
+					{From: "logs", To: "metrics"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := capabilities[tt.key]
+			if !ok {
+				t.Fatalf("Capabilities() has no %q", tt.key)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Capabilities()[%q] = %+v, want %+v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapabilitiesPanickingFactory(t *testing.T) {
+	saved := slices.Clone(receiver.Factories)
+	t.Cleanup(func() { receiver.Factories = saved })
+	receiver.Register("lambdacomponents.receiver.broken", "example.com/broken", "broken", func(string) otelreceiver.Factory {
+		panic("boom")
+	})
+
+	if capabilities, err := Capabilities(); err == nil {
+		t.Fatalf("Capabilities() = %v, want an error", capabilities)
+	}
+}
@@ -0,0 +1,94 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/registry"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// configSections maps the top level sections of a collector configuration to
+// the kind of the components declared in them.
+var configSections = []struct{ section, kind string }{
+	{"receivers", "receiver"},
+	{"processors", "processor"},
+	{"exporters", "exporter"},
+	{"connectors", "connector"},
+	{"extensions", "extension"},
+}
+
+// ValidateConfigComponents checks that every component declared in the YAML
+// collector configuration cfg is compiled into this layer. The error names each
+// missing component and, when this repository provides it, the build tag that
+// selects it.
+func ValidateConfigComponents(cfg []byte) error {
+	conf, err := parseConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	compiled := make(map[string]bool)
+	for _, info := range Manifest() {
+		compiled[info.Kind+"/"+info.Name] = true
+	}
+
+	var errs []error
+	for _, s := range configSections {
+		components, _ := conf.Get(s.section).(map[string]any)
+		for _, key := range slices.Sorted(maps.Keys(components)) {
+			var id component.ID
+			if err := id.UnmarshalText([]byte(key)); err != nil {
+				errs = append(errs, fmt.Errorf("%s %q: %w", s.kind, key, err))
+				continue
+			}
+			if compiled[s.kind+"/"+id.Type().String()] {
+				continue
+			}
+			if tag, ok := registry.BuildTag(s.kind, id.Type()); ok {
+				errs = append(errs, fmt.Errorf("%s %q is not compiled into this layer; build it with the %s tag", s.kind, key, tag))
+			} else {
+				errs = append(errs, fmt.Errorf("%s %q is not compiled into this layer and isn't provided by any build tag", s.kind, key))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// configTypes returns the component types declared in the YAML collector
+// configuration cfg, keyed by kind. Keys that aren't valid component IDs are
+// skipped; the collector reports them when it loads the configuration.
+func configTypes(cfg []byte) (map[string][]component.Type, error) {
+	conf, err := parseConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	types := make(map[string][]component.Type)
+	for _, s := range configSections {
+		components, _ := conf.Get(s.section).(map[string]any)
+		for _, key := range slices.Sorted(maps.Keys(components)) {
+			var id component.ID
+			if err := id.UnmarshalText([]byte(key)); err == nil && !slices.Contains(types[s.kind], id.Type()) {
+				types[s.kind] = append(types[s.kind], id.Type())
+			}
+		}
+	}
+	return types, nil
+}
+
+func parseConfig(cfg []byte) (*confmap.Conf, error) {
+	retrieved, err := confmap.NewRetrievedFromYAML(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse collector configuration: %w", err)
+	}
+	conf, err := retrieved.AsConf()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse collector configuration: %w", err)
+	}
+	return conf, nil
+}
@@ -0,0 +1,87 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/processor"
+	"go.opentelemetry.io/collector/component"
+	otelprocessor "go.opentelemetry.io/collector/processor"
+)
+
+type fakeConfig struct{}
+
+// registerProcessor registers a processor of the given type for the duration
+// of the test.
+func registerProcessor(t *testing.T, tag, typ string) {
+	t.Helper()
+	saved := slices.Clone(processor.Factories)
+	t.Cleanup(func() { processor.Factories = saved })
+	processor.Register(tag, "example.com/"+typ, typ, func(string) otelprocessor.Factory {
+		return otelprocessor.NewFactory(component.MustNewType(typ), func() component.Config { return &fakeConfig{} })
+	})
+}
+
+func TestValidateConfigComponents(t *testing.T) {
+	registerProcessor(t, "lambdacomponents.processor.fake", "fake")
+
+	tests := []struct {
+		name    string
+		cfg     string
+		wantErr string
+	}{
+		{
+			name: "compiled",
+			cfg:  "processors:\n  fake:\n  fake/2:\n",
+		},
+		{
+			name:    "tag differs from type",
+			cfg:     "receivers:\n  otlpjsonfile:\n",
+			wantErr: `receiver "otlpjsonfile" is not compiled into this layer; build it with the lambdacomponents.receiver.otlpjson tag`,
+		},
+		{
+			name:    "type with an underscore",
+			cfg:     "processors:\n  memory_limiter:\n",
+			wantErr: "build it with the lambdacomponents.processor.memorylimiter tag",
+		},
+		{
+			name:    "not provided by this repository",
+			cfg:     "processors:\n  decouple:\n",
+			wantErr: `processor "decouple" is not compiled into this layer and isn't provided by any build tag`,
+		},
+		{
+			name:    "invalid ID",
+			cfg:     "exporters:\n  \"otlp/\":\n",
+			wantErr: `exporter "otlp/"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfigComponents([]byte(tt.cfg))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateConfigComponents() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateConfigComponents() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildForConfigComponents(t *testing.T) {
+	registerProcessor(t, "lambdacomponents.processor.fake", "fake")
+
+	if _, err := BuildForConfig("extension", []byte("processors:\n  fake:\n")); err != nil {
+		t.Fatalf("BuildForConfig() = %v", err)
+	}
+	_, err := BuildForConfig("extension", []byte("processors:\n  fake:\n  decouple:\n"))
+	if want := `processor "decouple" is not compiled into this layer`; err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("BuildForConfig() = %v, want an error containing %q", err, want)
+	}
+}
@@ -0,0 +1,149 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/connector"
+	"go.opentelemetry.io/collector/component"
+	otelconnector "go.opentelemetry.io/collector/connector"
+)
+
+// signals are the pipeline signals connectors are checked for, in the order
+// errors list them.
+var signals = []string{"traces", "metrics", "logs"}
+
+// ValidateConnectorPipelines checks that every connector used in the pipelines
+// of the YAML collector configuration cfg has pipelines on both sides it can
+// connect: for each pipeline it exports from, a pipeline of a signal it can
+// emit that it receives into, and the other way around. A connector missing
+// one would silently drop what it is given. Connectors that aren't compiled in
+// are left to ValidateConfigComponents.
+func ValidateConnectorPipelines(extensionId string, cfg []byte) error {
+	conf, err := parseConfig(cfg)
+	if err != nil {
+		return err
+	}
+	types, err := configTypes(cfg)
+	if err != nil {
+		return err
+	}
+	factories, err := connector.Registry.BuildTypes(extensionId, types["connector"])
+	if err != nil {
+		return err
+	}
+
+	declared, _ := conf.Get("connectors").(map[string]any)
+	pipelines, _ := conf.Get("service::pipelines").(map[string]any)
+	// exportsFrom and receivesInto map a connector to the signals of the
+	// pipelines it is an exporter, or a receiver, of. A signal maps to the
+	// first such pipeline, which errors name.
+	exportsFrom := make(map[string]map[string]string)
+	receivesInto := make(map[string]map[string]string)
+	for _, name := range slices.Sorted(maps.Keys(pipelines)) {
+		signal, _, _ := strings.Cut(name, "/")
+		if !slices.Contains(signals, signal) {
+			continue
+		}
+		pipeline, _ := pipelines[name].(map[string]any)
+		for _, side := range []struct {
+			key  string
+			uses map[string]map[string]string
+		}{{"exporters", exportsFrom}, {"receivers", receivesInto}} {
+			ids, _ := pipeline[side.key].([]any)
+			for _, id := range ids {
+				key, _ := id.(string)
+				if _, ok := declared[key]; !ok {
+					continue
+				}
+				if side.uses[key] == nil {
+					side.uses[key] = make(map[string]string)
+				}
+				if _, ok := side.uses[key][signal]; !ok {
+					side.uses[key][signal] = name
+				}
+			}
+		}
+	}
+
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(declared)) {
+		var id component.ID
+		if err := id.UnmarshalText([]byte(key)); err != nil {
+			continue
+		}
+		factory, ok := factories[id.Type()]
+		if !ok || (exportsFrom[key] == nil && receivesInto[key] == nil) {
+			continue
+		}
+		for _, from := range signals {
+			pipeline, ok := exportsFrom[key][from]
+			if !ok {
+				continue
+			}
+			var supported []string
+			connected := false
+			for _, to := range signals {
+				if connectionSupported(factory, from, to) {
+					supported = append(supported, to)
+					_, used := receivesInto[key][to]
+					connected = connected || used
+				}
+			}
+			if !connected {
+				errs = append(errs, fmt.Errorf("connector %q is an exporter in pipeline %q but a receiver in no %s pipeline",
+					key, pipeline, signalList(supported)))
+			}
+		}
+		for _, to := range signals {
+			pipeline, ok := receivesInto[key][to]
+			if !ok {
+				continue
+			}
+			var supported []string
+			connected := false
+			for _, from := range signals {
+				if connectionSupported(factory, from, to) {
+					supported = append(supported, from)
+					_, used := exportsFrom[key][from]
+					connected = connected || used
+				}
+			}
+			if !connected {
+				errs = append(errs, fmt.Errorf("connector %q is a receiver in pipeline %q but an exporter in no %s pipeline",
+					key, pipeline, signalList(supported)))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// connectionSupported reports whether factory creates connectors from signal
+// from to signal to.
+func connectionSupported(factory otelconnector.Factory, from, to string) bool {
+	stability := map[string]func() component.StabilityLevel{
+		"traces/traces":   factory.TracesToTracesStability,
+		"traces/metrics":  factory.TracesToMetricsStability,
+		"traces/logs":     factory.TracesToLogsStability,
+		"metrics/traces":  factory.MetricsToTracesStability,
+		"metrics/metrics": factory.MetricsToMetricsStability,
+		"metrics/logs":    factory.MetricsToLogsStability,
+		"logs/traces":     factory.LogsToTracesStability,
+		"logs/metrics":    factory.LogsToMetricsStability,
+		"logs/logs":       factory.LogsToLogsStability,
+	}[from+"/"+to]
+	return stability != nil && stability() != component.StabilityLevelUndefined
+}
+
+// signalList names signals for an error, e.g. "metrics or logs".
+func signalList(signals []string) string {
+	if len(signals) == 0 {
+		return "supported"
+	}
+	return strings.Join(signals, " or ")
+}
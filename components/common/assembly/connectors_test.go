@@ -0,0 +1,126 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/connector"
+	"go.opentelemetry.io/collector/component"
+	otelconnector "go.opentelemetry.io/collector/connector"
+)
+
+// registerConnector registers a connector of the given type for the duration
+// of the test, which supports the connections options declare.
+func registerConnector(t *testing.T, typ string, options ...otelconnector.FactoryOption) {
+	t.Helper()
+	saved := slices.Clone(connector.Factories)
+	t.Cleanup(func() { connector.Factories = saved })
+	connector.Register("lambdacomponents.connector."+typ, "example.com/"+typ, typ, func(string) otelconnector.Factory {
+		return otelconnector.NewFactory(component.MustNewType(typ), func() component.Config { return &fakeConfig{} }, options...)
+	})
+}
+
+func TestValidateConnectorPipelines(t *testing.T) {
+	registerConnector(t, "spans", otelconnector.WithTracesToMetrics(nil, component.StabilityLevelStable))
+
+	tests := []struct {
+		name    string
+		cfg     string
+		wantErr string
+	}{
+		{
+			name: "connected",
+			cfg: `
+connectors:
+  spans:
+service:
+  pipelines:
+    traces:
+      exporters: [spans]
+    metrics/spans:
+      receivers: [spans]
+`,
+		},
+		{
+			name: "no receiving pipeline",
+			cfg: `
+connectors:
+  spans:
+service:
+  pipelines:
+    traces:
+      exporters: [spans]
+`,
+			wantErr: `connector "spans" is an exporter in pipeline "traces" but a receiver in no metrics pipeline`,
+		},
+		{
+			name: "no exporting pipeline",
+			cfg: `
+connectors:
+  spans/2:
+service:
+  pipelines:
+    metrics:
+      receivers: [spans/2]
+`,
+			wantErr: `connector "spans/2" is a receiver in pipeline "metrics" but an exporter in no traces pipeline`,
+		},
+		{
+			name: "unsupported signal",
+			cfg: `
+connectors:
+  spans:
+service:
+  pipelines:
+    traces:
+      exporters: [spans]
+    logs/a:
+      receivers: [spans]
+    logs/b:
+      receivers: [spans]
+`,
+			wantErr: `connector "spans" is an exporter in pipeline "traces" but a receiver in no metrics pipeline` + "\n" +
+				`connector "spans" is a receiver in pipeline "logs/a" but an exporter in no supported pipeline`,
+		},
+		{
+			name: "not in a pipeline",
+			cfg:  "connectors:\n  spans:\n",
+		},
+		{
+			name: "not compiled",
+			cfg: `
+connectors:
+  unknown:
+service:
+  pipelines:
+    traces:
+      exporters: [unknown]
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConnectorPipelines("extension", []byte(tt.cfg))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateConnectorPipelines() = %v, want nil", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ValidateConnectorPipelines() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildForConfigConnectorPipelines(t *testing.T) {
+	registerConnector(t, "spans", otelconnector.WithTracesToMetrics(nil, component.StabilityLevelStable))
+
+	cfg := "connectors:\n  spans:\nservice:\n  pipelines:\n    traces:\n      exporters: [spans]\n"
+	_, err := BuildForConfig("extension", []byte(cfg))
+	if want := `connector "spans" is an exporter in pipeline "traces" but a receiver in no metrics pipeline`; err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("BuildForConfig() = %v, want an error containing %q", err, want)
+	}
+}
@@ -0,0 +1,87 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/registry"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/processor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// lambdaResourceProcessor is the ID of the resource processor injected by the
+// resource defaults converter.
+const lambdaResourceProcessor = "resource/lambda"
+
+var resourceType = component.MustNewType("resource")
+
+// ConfigConverterFactories returns the configuration converters the collector
+// runs on its resolved configuration, before the collector service is built
+// from it: the upstream ones, followed by those this layer adds. The pipelines
+// converter runs last, so it orders the shutdown by the pipelines the others
+// changed.
+func ConfigConverterFactories(upstream ...confmap.ConverterFactory) []confmap.ConverterFactory {
+	return append(slices.Clip(upstream),
+		confmap.NewConverterFactory(func(confmap.ConverterSettings) confmap.Converter {
+			return resourceDefaultsConverter{}
+		}),
+		confmap.NewConverterFactory(func(confmap.ConverterSettings) confmap.Converter {
+			return pipelinesConverter{}
+		}),
+	)
+}
+
+// resourceDefaultsConverter adds the faas and cloud resource attributes of the
+// execution environment to every traces, metrics and logs pipeline, through a
+// resource processor placed first in each of them. Configurations that already
+// declare a resource processor are left alone, as are layers built without
+// one.
+type resourceDefaultsConverter struct{}
+
+func (resourceDefaultsConverter) Convert(_ context.Context, conf *confmap.Conf) error {
+	attrs := lambdaenv.ResourceAttributes()
+	if len(attrs) == 0 || !resourceProcessorCompiled() {
+		return nil
+	}
+	processors, _ := conf.Get("processors").(map[string]any)
+	for key := range processors {
+		if key == "resource" || strings.HasPrefix(key, "resource/") {
+			return nil
+		}
+	}
+
+	patched := make(map[string]any)
+	pipelines, _ := conf.Get("service::pipelines").(map[string]any)
+	for id, pipeline := range pipelines {
+		signal, _, _ := strings.Cut(id, "/")
+		if signal != "traces" && signal != "metrics" && signal != "logs" {
+			continue
+		}
+		settings, _ := pipeline.(map[string]any)
+		existing, _ := settings["processors"].([]any)
+		patched[id] = map[string]any{"processors": append([]any{lambdaResourceProcessor}, existing...)}
+	}
+	if len(patched) == 0 {
+		return nil
+	}
+
+	actions := make([]any, 0, len(attrs))
+	for _, attr := range attrs {
+		actions = append(actions, map[string]any{"key": attr.Key, "value": attr.Value, "action": "upsert"})
+	}
+	return conf.Merge(confmap.NewFromStringMap(map[string]any{
+		"processors": map[string]any{lambdaResourceProcessor: map[string]any{"attributes": actions}},
+		"service":    map[string]any{"pipelines": patched},
+	}))
+}
+
+func resourceProcessorCompiled() bool {
+	return slices.ContainsFunc(processor.Registry.Manifest(), func(info registry.ComponentInfo) bool {
+		return info.Name == resourceType.String()
+	})
+}
@@ -0,0 +1,143 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// convert runs the converters of ConfigConverterFactories on conf, in order.
+func convert(t *testing.T, conf map[string]any, upstream ...confmap.ConverterFactory) map[string]any {
+	t.Helper()
+	c := confmap.NewFromStringMap(conf)
+	for _, f := range ConfigConverterFactories(upstream...) {
+		if err := f.Create(confmap.ConverterSettings{}).Convert(context.Background(), c); err != nil {
+			t.Fatalf("Convert() = %v", err)
+		}
+	}
+	return c.ToStringMap()
+}
+
+// pipelines returns a configuration with a traces pipeline running the given
+// processors, which declares processors.
+func pipelines(processors map[string]any, pipelineProcessors ...any) map[string]any {
+	pipeline := map[string]any{"receivers": []any{"otlp"}, "exporters": []any{"otlphttp"}}
+	if len(pipelineProcessors) > 0 {
+		pipeline["processors"] = pipelineProcessors
+	}
+	conf := map[string]any{
+		"receivers": map[string]any{"otlp": nil},
+		"exporters": map[string]any{"otlphttp": nil},
+		"service":   map[string]any{"pipelines": map[string]any{"traces": pipeline}},
+	}
+	if processors != nil {
+		conf["processors"] = processors
+	}
+	return conf
+}
+
+func TestResourceDefaultsConverter(t *testing.T) {
+	lambdaResource := map[string]any{"attributes": []any{
+		map[string]any{"key": "faas.name", "value": "checkout", "action": "upsert"},
+		map[string]any{"key": "faas.version", "value": "$LATEST", "action": "upsert"},
+		map[string]any{"key": "cloud.region", "value": "eu-west-1", "action": "upsert"},
+	}}
+	tests := []struct {
+		name         string
+		env          bool
+		withResource bool
+		conf         map[string]any
+		want         map[string]any
+	}{
+		{
+			name:         "bare config",
+			env:          true,
+			withResource: true,
+			conf:         pipelines(nil),
+			want:         pipelines(map[string]any{lambdaResourceProcessor: lambdaResource}, lambdaResourceProcessor),
+		},
+		{
+			name:         "placed first",
+			env:          true,
+			withResource: true,
+			conf:         pipelines(map[string]any{"batch": nil}, "batch"),
+			want:         pipelines(map[string]any{"batch": nil, lambdaResourceProcessor: lambdaResource}, lambdaResourceProcessor, "batch"),
+		},
+		{
+			name:         "resource processor declared",
+			env:          true,
+			withResource: true,
+			conf:         pipelines(map[string]any{"resource": map[string]any{"attributes": []any{}}}, "resource"),
+			want:         pipelines(map[string]any{"resource": map[string]any{"attributes": []any{}}}, "resource"),
+		},
+		{
+			name:         "named resource processor declared",
+			env:          true,
+			withResource: true,
+			conf:         pipelines(map[string]any{"resource/team": nil}),
+			want:         pipelines(map[string]any{"resource/team": nil}),
+		},
+		{
+			name:         "outside Lambda",
+			withResource: true,
+			conf:         pipelines(nil),
+			want:         pipelines(nil),
+		},
+		{
+			name: "resource processor not compiled",
+			env:  true,
+			conf: pipelines(nil),
+			want: pipelines(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.withResource {
+				registerProcessor(t, "lambdacomponents.processor.resource", "resource")
+			}
+			for key, value := range map[string]string{
+				"AWS_LAMBDA_FUNCTION_NAME":    "checkout",
+				"AWS_LAMBDA_FUNCTION_VERSION": "$LATEST",
+				"AWS_REGION":                  "eu-west-1",
+			} {
+				if !tt.env {
+					value = ""
+				}
+				t.Setenv(key, value)
+			}
+			if got := convert(t, tt.conf); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("converted configuration = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// The converters of this layer run after the upstream ones, so they see the
+// pipelines those add.
+func TestConfigConverterFactoriesOrder(t *testing.T) {
+	registerProcessor(t, "lambdacomponents.processor.resource", "resource")
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "checkout")
+	upstream := confmap.NewConverterFactory(func(confmap.ConverterSettings) confmap.Converter {
+		return converterFunc(func(_ context.Context, conf *confmap.Conf) error {
+			return conf.Merge(confmap.NewFromStringMap(map[string]any{"service": map[string]any{"pipelines": map[string]any{
+				"logs": map[string]any{"receivers": []any{"otlp"}, "exporters": []any{"otlphttp"}},
+			}}}))
+		})
+	})
+
+	got := convert(t, pipelines(nil), upstream)
+	logs, _ := confmap.NewFromStringMap(got).Get("service::pipelines::logs::processors").([]any)
+	if !reflect.DeepEqual(logs, []any{lambdaResourceProcessor}) {
+		t.Errorf("logs pipeline processors = %v, want [%s]", logs, lambdaResourceProcessor)
+	}
+}
+
+type converterFunc func(context.Context, *confmap.Conf) error
+
+func (f converterFunc) Convert(ctx context.Context, conf *confmap.Conf) error {
+	return f(ctx, conf)
+}
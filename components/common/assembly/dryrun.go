@@ -0,0 +1,170 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/otelcol"
+)
+
+// DryRunEnvVar names the variable that, when set to a true value, makes the
+// collector print the resolved pipelines with DryRun and exit instead of
+// starting.
+const DryRunEnvVar = "OCELOT_DRY_RUN"
+
+// DryRunRequested reports whether DryRunEnvVar is set.
+func DryRunRequested() bool {
+	return lambdaenv.Enabled(DryRunEnvVar)
+}
+
+type dryRunComponent struct {
+	Kind   string         `json:"kind"`
+	ID     string         `json:"id"`
+	Config map[string]any `json:"config"`
+}
+
+type dryRunPipeline struct {
+	ID         string   `json:"id"`
+	Receivers  []string `json:"receivers"`
+	Processors []string `json:"processors"`
+	Exporters  []string `json:"exporters"`
+}
+
+type dryRunEdge struct {
+	Pipeline string `json:"pipeline"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
+type dryRunGraph struct {
+	Components []dryRunComponent `json:"components"`
+	Pipelines  []dryRunPipeline  `json:"pipelines"`
+	Edges      []dryRunEdge      `json:"edges"`
+}
+
+// DryRun writes the components and pipelines of the YAML collector
+// configuration cfg to w as JSON. Each component is listed with its effective
+// configuration, the defaults of this layer with cfg applied on top, and each
+// pipeline with the edges data flows along, between components named as
+// kind/id. Sensitive values are redacted as they are in the collector's logs.
+func DryRun(extensionId string, cfg []byte, w io.Writer) error {
+	conf, err := parseConfig(cfg)
+	if err != nil {
+		return err
+	}
+	factories, err := BuildForConfig(extensionId, cfg)
+	if err != nil {
+		return err
+	}
+
+	var graph dryRunGraph
+	for _, s := range configSections {
+		components, _ := conf.Get(s.section).(map[string]any)
+		for _, key := range slices.Sorted(maps.Keys(components)) {
+			resolved, err := resolvedConfig(factories, s.kind, key, conf.Get(s.section+"::"+key))
+			if err != nil {
+				return err
+			}
+			graph.Components = append(graph.Components, dryRunComponent{Kind: s.kind, ID: key, Config: resolved})
+		}
+	}
+
+	connectors, _ := conf.Get("connectors").(map[string]any)
+	kindOf := func(id, kind string) string {
+		if _, ok := connectors[id]; ok {
+			return "connector/" + id
+		}
+		return kind + "/" + id
+	}
+	pipelines, _ := conf.Get("service::pipelines").(map[string]any)
+	for _, name := range slices.Sorted(maps.Keys(pipelines)) {
+		p, _ := pipelines[name].(map[string]any)
+		pipeline := dryRunPipeline{
+			ID:         name,
+			Receivers:  stringList(p["receivers"]),
+			Processors: stringList(p["processors"]),
+			Exporters:  stringList(p["exporters"]),
+		}
+		graph.Pipelines = append(graph.Pipelines, pipeline)
+
+		var from []string
+		for _, id := range pipeline.Receivers {
+			from = append(from, kindOf(id, "receiver"))
+		}
+		var stages [][]string
+		for _, id := range pipeline.Processors {
+			stages = append(stages, []string{"processor/" + id})
+		}
+		var exporters []string
+		for _, id := range pipeline.Exporters {
+			exporters = append(exporters, kindOf(id, "exporter"))
+		}
+		for _, to := range append(stages, exporters) {
+			for _, f := range from {
+				for _, t := range to {
+					graph.Edges = append(graph.Edges, dryRunEdge{Pipeline: name, From: f, To: t})
+				}
+			}
+			from = to
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(graph)
+}
+
+// resolvedConfig returns the configuration of the component of the given
+// kind declared under key, with userCfg applied to its default configuration.
+func resolvedConfig(factories otelcol.Factories, kind, key string, userCfg any) (map[string]any, error) {
+	var id component.ID
+	if err := id.UnmarshalText([]byte(key)); err != nil {
+		return nil, fmt.Errorf("%s %q: %w", kind, key, err)
+	}
+	var factory component.Factory
+	var ok bool
+	switch kind {
+	case "receiver":
+		factory, ok = factories.Receivers[id.Type()]
+	case "processor":
+		factory, ok = factories.Processors[id.Type()]
+	case "exporter":
+		factory, ok = factories.Exporters[id.Type()]
+	case "connector":
+		factory, ok = factories.Connectors[id.Type()]
+	case "extension":
+		factory, ok = factories.Extensions[id.Type()]
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s %q is not compiled into this layer", kind, key)
+	}
+	cfg := factory.CreateDefaultConfig()
+	userMap, _ := userCfg.(map[string]any)
+	if err := confmap.NewFromStringMap(userMap).Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("%s %q: %w", kind, key, err)
+	}
+	resolved := confmap.New()
+	if err := resolved.Marshal(cfg); err != nil {
+		return nil, fmt.Errorf("%s %q: %w", kind, key, err)
+	}
+	return resolved.ToStringMap(), nil
+}
+
+func stringList(v any) []string {
+	list, _ := v.([]any)
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
@@ -0,0 +1,68 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"context"
+	"sync"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/invocation"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/shutdown"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/otelcol"
+)
+
+// coordinator drains the components created from the factories assembled
+// last, which are those the collector service runs.
+var (
+	coordinatorMu sync.Mutex
+	coordinator   = shutdown.New()
+)
+
+// track has the components created from factories drained by a new
+// coordinator when the environment shuts down, under the policy selected in
+// OCELOT_ON_FREEZE.
+func track(factories otelcol.Factories) (otelcol.Factories, error) {
+	policy, err := shutdown.PolicyFromEnv()
+	if err != nil {
+		return otelcol.Factories{}, err
+	}
+	c := shutdown.New()
+	c.SetPolicy(policy)
+	coordinatorMu.Lock()
+	defer coordinatorMu.Unlock()
+	coordinator = c
+	return shutdown.Track(factories, c), nil
+}
+
+func currentCoordinator() *shutdown.Coordinator {
+	coordinatorMu.Lock()
+	defer coordinatorMu.Unlock()
+	return coordinator
+}
+
+// pipelinesConverter hands the pipelines of the resolved configuration to the
+// coordinator, so Shutdown drains the components in the order data flows
+// through them. It leaves the configuration unchanged.
+type pipelinesConverter struct{}
+
+func (pipelinesConverter) Convert(_ context.Context, conf *confmap.Conf) error {
+	currentCoordinator().SetPipelines(conf)
+	return nil
+}
+
+// Invoke records the start of the invocation with the given request ID, so
+// components can tell which invocation the telemetry they handle belongs to.
+// The collector's lifecycle manager calls it for every INVOKE event, before
+// the function's runtime is notified.
+func Invoke(requestID string) {
+	invocation.Begin(requestID)
+}
+
+// Shutdown drains the collector's components by the deadline of the SHUTDOWN
+// event, deadlineMs in milliseconds since the Unix epoch. The lifecycle
+// manager calls it when it receives the event, before it stops the collector
+// service, which then finds the components already shut down.
+func Shutdown(ctx context.Context, deadlineMs int64) error {
+	return currentCoordinator().Shutdown(ctx, shutdown.Deadline(deadlineMs))
+}
@@ -0,0 +1,175 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/invocation"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdacontextprocessor"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/exporter"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	otelexporter "go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+func telemetrySettings() component.TelemetrySettings {
+	return component.TelemetrySettings{
+		Logger:         zap.NewNop(),
+		MeterProvider:  metricnoop.NewMeterProvider(),
+		TracerProvider: tracenoop.NewTracerProvider(),
+	}
+}
+
+func TestInvokeStampsSpans(t *testing.T) {
+	factory := lambdacontextprocessor.NewFactory()
+	sink := new(consumertest.TracesSink)
+	set := processor.Settings{ID: component.NewID(factory.Type()), TelemetrySettings: telemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()}
+	p, err := factory.CreateTraces(context.Background(), set, factory.CreateDefaultConfig(), sink)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		requestID string
+	}{
+		{name: "first invocation", requestID: "request-1"},
+		{name: "next invocation", requestID: "request-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Invoke(tt.requestID)
+			if first, ok := invocation.First(); !ok || first.RequestID == "" {
+				t.Fatalf("First() = %+v, %v after Invoke", first, ok)
+			}
+
+			sink.Reset()
+			td := ptrace.NewTraces()
+			td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			if err := p.ConsumeTraces(context.Background(), td); err != nil {
+				t.Fatalf("ConsumeTraces() = %v", err)
+			}
+			span := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			if got, ok := span.Attributes().Get("faas.invocation_id"); !ok || got.Str() != tt.requestID {
+				t.Errorf("faas.invocation_id = %q, want %q", got.Str(), tt.requestID)
+			}
+		})
+	}
+}
+
+type fakeTraces struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
+
+// registerExporter registers a traces exporter of the given type for the
+// duration of the test, whose exporters shut down with shutdown.
+func registerExporter(t *testing.T, typ string, shutdown component.ShutdownFunc) {
+	t.Helper()
+	saved := slices.Clone(exporter.Factories)
+	t.Cleanup(func() { exporter.Factories = saved })
+	exporter.Register("lambdacomponents.exporter."+typ, "example.com/"+typ, typ, func(string) otelexporter.Factory {
+		return otelexporter.NewFactory(component.MustNewType(typ),
+			func() component.Config { return &fakeConfig{} },
+			otelexporter.WithTraces(func(context.Context, otelexporter.Settings, component.Config) (otelexporter.Traces, error) {
+				return fakeTraces{ShutdownFunc: shutdown, Traces: consumertest.NewNop()}, nil
+			}, component.StabilityLevelStable))
+	})
+}
+
+func TestShutdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		shutdown component.ShutdownFunc
+		wantErr  string
+	}{
+		{
+			name:     "drained",
+			shutdown: func(context.Context) error { return nil },
+		},
+		{
+			name: "blocking",
+			shutdown: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			wantErr: "shutdown deadline reached before [blocking] drained",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ := strings.ReplaceAll(tt.name, " ", "")
+			calls := 0
+			registerExporter(t, typ, func(ctx context.Context) error {
+				calls++
+				return tt.shutdown(ctx)
+			})
+			factories, err := BuildForConfig("extension", []byte("exporters:\n  "+typ+":\n"))
+			if err != nil {
+				t.Fatalf("BuildForConfig() = %v", err)
+			}
+			f := factories.Exporters[component.MustNewType(typ)]
+			set := otelexporter.Settings{ID: component.NewID(f.Type()), TelemetrySettings: telemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()}
+			exp, err := f.CreateTraces(context.Background(), set, f.CreateDefaultConfig())
+			if err != nil {
+				t.Fatalf("CreateTraces() = %v", err)
+			}
+
+			deadline := time.Now().Add(200 * time.Millisecond)
+			err = Shutdown(context.Background(), deadline.UnixMilli())
+			if returned := time.Now(); returned.After(deadline.Add(50 * time.Millisecond)) {
+				t.Errorf("Shutdown() returned %v after the event deadline", returned.Sub(deadline))
+			}
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Shutdown() = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Shutdown() = %v, want an error containing %q", err, tt.wantErr)
+			}
+
+			// The collector service shuts the exporter down again when it
+			// stops.
+			_ = exp.Shutdown(context.Background())
+			if calls != 1 {
+				t.Errorf("the exporter was shut down %d times, want once", calls)
+			}
+		})
+	}
+}
+
+func TestShutdownPolicyFromEnv(t *testing.T) {
+	registerExporter(t, "fake", func(context.Context) error { return nil })
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr string
+	}{
+		{name: "unset"},
+		{name: "drop", policy: "drop"},
+		{name: "unknown", policy: "wait", wantErr: `OCELOT_ON_FREEZE must be drop, persist or block, got "wait"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OCELOT_ON_FREEZE", tt.policy)
+			_, err := BuildForConfig("extension", []byte("exporters:\n  fake:\n"))
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("BuildForConfig() = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("BuildForConfig() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// The variables the upstream collector reads its config location from, in
+// order of precedence, and the location it falls back to.
+const (
+	configURIEnvVar   = "OPENTELEMETRY_COLLECTOR_CONFIG_URI"
+	configFileEnvVar  = "OPENTELEMETRY_COLLECTOR_CONFIG_FILE"
+	defaultConfigPath = "/opt/collector-config/config.yaml"
+)
+
+// uriScheme matches the scheme of a config URI, as confmap does.
+var uriScheme = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:`)
+
+// ConfigLocation returns the location the upstream collector loads its
+// configuration from.
+func ConfigLocation() string {
+	if uri, ok := os.LookupEnv(configURIEnvVar); ok {
+		return uri
+	}
+	if file, ok := os.LookupEnv(configFileEnvVar); ok {
+		return file
+	}
+	return defaultConfigPath
+}
+
+// LocalConfig returns the collector configuration when ConfigLocation is a
+// local file that declares its components inline. It returns false for
+// configurations read from another source, and for those whose component
+// sections are assembled from ${...} references: their components are only
+// known once the collector resolves them.
+func LocalConfig() ([]byte, bool) {
+	location := ConfigLocation()
+	if path, ok := strings.CutPrefix(location, "file:"); ok {
+		location = path
+	} else if uriScheme.MatchString(location) {
+		return nil, false
+	}
+	cfg, err := os.ReadFile(location)
+	if err != nil {
+		return nil, false
+	}
+	conf, err := parseConfig(cfg)
+	if err != nil {
+		return nil, false
+	}
+	for _, s := range configSections {
+		section := conf.Get(s.section)
+		if section == nil {
+			continue
+		}
+		components, ok := section.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		for key := range components {
+			if strings.Contains(key, "${") {
+				return nil, false
+			}
+		}
+	}
+	return cfg, true
+}
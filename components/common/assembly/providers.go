@@ -0,0 +1,51 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/s3provider"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/secretsmanagerprovider"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/ssmprovider"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+)
+
+// ConfigProviderFactories returns the configuration providers the collector
+// resolves its configuration with: the upstream ones, followed by those this
+// layer adds. Each is selected by the scheme of a config URI, either passed as
+// the config location or referenced from the configuration as
+// ${<scheme>:<value>}:
+//
+//   - ssm: a parameter from SSM Parameter Store
+//   - secretsmanager: a secret, or a key of a JSON secret, from Secrets Manager
+//   - s3: an object from S3
+//
+// A provider of this layer replaces the upstream provider of the same scheme,
+// since the collector refuses to start with two.
+func ConfigProviderFactories(upstream ...confmap.ProviderFactory) []confmap.ProviderFactory {
+	ours := []confmap.ProviderFactory{
+		ssmprovider.NewFactory(),
+		secretsmanagerprovider.NewFactory(),
+		s3provider.NewFactory(),
+	}
+	replaced := make(map[string]bool, len(ours))
+	for _, f := range ours {
+		replaced[scheme(f)] = true
+	}
+	factories := make([]confmap.ProviderFactory, 0, len(upstream)+len(ours))
+	for _, f := range upstream {
+		if !replaced[scheme(f)] {
+			factories = append(factories, f)
+		}
+	}
+	return append(factories, ours...)
+}
+
+// scheme returns the scheme of the providers f creates.
+func scheme(f confmap.ProviderFactory) string {
+	p := f.Create(confmap.ProviderSettings{Logger: zap.NewNop()})
+	defer func() { _ = p.Shutdown(context.Background()) }()
+	return p.Scheme()
+}
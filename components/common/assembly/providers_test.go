@@ -0,0 +1,79 @@
+//go:build lambdacomponents.custom
+
+package assembly
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+)
+
+// fakeProvider stands for an upstream provider of the given scheme.
+type fakeProvider struct {
+	scheme string
+}
+
+func (fakeProvider) Retrieve(context.Context, string, confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	return confmap.NewRetrieved("upstream")
+}
+
+func (p fakeProvider) Scheme() string {
+	return p.scheme
+}
+
+func (fakeProvider) Shutdown(context.Context) error {
+	return nil
+}
+
+func upstreamProvider(scheme string) confmap.ProviderFactory {
+	return confmap.NewProviderFactory(func(confmap.ProviderSettings) confmap.Provider {
+		return fakeProvider{scheme: scheme}
+	})
+}
+
+func TestConfigProviderFactories(t *testing.T) {
+	tests := []struct {
+		name         string
+		upstream     []confmap.ProviderFactory
+		want         []string
+		wantUpstream []string
+	}{
+		{
+			name: "no upstream providers",
+			want: []string{"ssm", "secretsmanager", "s3"},
+		},
+		{
+			name:         "added",
+			upstream:     []confmap.ProviderFactory{upstreamProvider("file"), upstreamProvider("env")},
+			want:         []string{"file", "env", "ssm", "secretsmanager", "s3"},
+			wantUpstream: []string{"file", "env"},
+		},
+		{
+			name:         "replaced",
+			upstream:     []confmap.ProviderFactory{upstreamProvider("file"), upstreamProvider("s3"), upstreamProvider("secretsmanager")},
+			want:         []string{"file", "ssm", "secretsmanager", "s3"},
+			wantUpstream: []string{"file"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var schemes, upstream []string
+			for _, f := range ConfigProviderFactories(tt.upstream...) {
+				p := f.Create(confmap.ProviderSettings{Logger: zap.NewNop()})
+				schemes = append(schemes, p.Scheme())
+				if _, ok := p.(fakeProvider); ok {
+					upstream = append(upstream, p.Scheme())
+				}
+			}
+			if !slices.Equal(schemes, tt.want) {
+				t.Errorf("schemes = %v, want %v", schemes, tt.want)
+			}
+			if !slices.Equal(upstream, tt.wantUpstream) {
+				t.Errorf("upstream schemes = %v, want %v", upstream, tt.wantUpstream)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Package buildhash stamps the data leaving the collector with the build that
+// produced it: every exporter adds an ocelot.build.hash resource attribute,
+// derived from the components compiled into the layer, to the signals it
+// exports.
+package buildhash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/registry"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol"
+)
+
+// AttributeKey is the resource attribute the hash is stored in.
+const AttributeKey = "ocelot.build.hash"
+
+// Hash returns the hash of the build that compiled in components: the first 16
+// hex digits of a SHA-256 over their kinds, names, build tags and modules. It
+// doesn't depend on the order of components, so two layers built with the
+// same components share a hash.
+func Hash(components []registry.ComponentInfo) string {
+	lines := make([]string, 0, len(components))
+	for _, info := range components {
+		lines = append(lines, strings.Join([]string{info.Kind, info.Name, info.BuildTag, info.Module}, "\t"))
+	}
+	slices.Sort(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// Wrap returns factories with every exporter factory wrapped by Exporter,
+// stamping the hash of components.
+func Wrap(factories otelcol.Factories, components []registry.ComponentInfo) otelcol.Factories {
+	hash := Hash(components)
+	exporters := make(map[component.Type]exporter.Factory, len(factories.Exporters))
+	for typ, f := range factories.Exporters {
+		exporters[typ] = Exporter(f, hash)
+	}
+	factories.Exporters = exporters
+	return factories
+}
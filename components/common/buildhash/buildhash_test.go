@@ -0,0 +1,167 @@
+package buildhash
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/registry"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+var (
+	otlp     = registry.ComponentInfo{Name: "otlp", Kind: "receiver", BuildTag: "lambdacomponents.receiver.otlp", Module: "go.opentelemetry.io/collector/receiver/otlpreceiver"}
+	otlphttp = registry.ComponentInfo{Name: "otlphttp", Kind: "exporter", BuildTag: "lambdacomponents.exporter.otlphttp", Module: "go.opentelemetry.io/collector/exporter/otlphttpexporter"}
+	batch    = registry.ComponentInfo{Name: "batch", Kind: "processor", BuildTag: "lambdacomponents.processor.batch", Module: "go.opentelemetry.io/collector/processor/batchprocessor"}
+)
+
+func TestHash(t *testing.T) {
+	base := Hash([]registry.ComponentInfo{otlp, otlphttp})
+	if !regexp.MustCompile(`^[0-9a-f]{16}$`).MatchString(base) {
+		t.Fatalf("Hash() = %q, want 16 hex digits", base)
+	}
+	otherModule := otlphttp
+	otherModule.Module = "example.com/otlphttpexporter"
+	tests := []struct {
+		name       string
+		components []registry.ComponentInfo
+		wantSame   bool
+	}{
+		{name: "same components", components: []registry.ComponentInfo{otlp, otlphttp}, wantSame: true},
+		{name: "other order", components: []registry.ComponentInfo{otlphttp, otlp}, wantSame: true},
+		{name: "another component", components: []registry.ComponentInfo{otlp, otlphttp, batch}},
+		{name: "fewer components", components: []registry.ComponentInfo{otlp}},
+		{name: "another module", components: []registry.ComponentInfo{otlp, otherModule}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Hash(tt.components); (got == base) != tt.wantSame {
+				t.Errorf("Hash() = %q, base build %q, want the same hash: %t", got, base, tt.wantSame)
+			}
+		})
+	}
+}
+
+type fakeExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+	consumer.Metrics
+	consumer.Logs
+}
+
+func (fakeExporter) Capabilities() consumer.Capabilities { return consumer.Capabilities{} }
+
+func TestExporter(t *testing.T) {
+	traces := new(consumertest.TracesSink)
+	metrics := new(consumertest.MetricsSink)
+	logs := new(consumertest.LogsSink)
+	exp := fakeExporter{Traces: traces, Metrics: metrics, Logs: logs}
+	typ := component.MustNewType("fake")
+	upstream := exporter.NewFactory(typ, func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			return exp, nil
+		}, component.StabilityLevelDevelopment),
+		exporter.WithMetrics(func(context.Context, exporter.Settings, component.Config) (exporter.Metrics, error) {
+			return exp, nil
+		}, component.StabilityLevelDevelopment),
+		exporter.WithLogs(func(context.Context, exporter.Settings, component.Config) (exporter.Logs, error) {
+			return exp, nil
+		}, component.StabilityLevelDevelopment))
+	f := Wrap(otelcol.Factories{Exporters: map[component.Type]exporter.Factory{typ: upstream}}, []registry.ComponentInfo{otlp}).Exporters[typ]
+	hash := Hash([]registry.ComponentInfo{otlp})
+	ctx := context.Background()
+	set := exporter.Settings{
+		ID: component.NewID(typ),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+
+	tests := []struct {
+		name string
+		// consume hands the exporter of one signal two resources, the second
+		// already stamped by another build, and returns the attributes of the
+		// resources it exported and its capabilities.
+		consume func(t *testing.T) ([]map[string]any, consumer.Capabilities)
+	}{
+		{
+			name: "traces",
+			consume: func(t *testing.T) ([]map[string]any, consumer.Capabilities) {
+				e, err := f.CreateTraces(ctx, set, f.CreateDefaultConfig())
+				if err != nil {
+					t.Fatalf("CreateTraces() = %v", err)
+				}
+				td := ptrace.NewTraces()
+				td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.name", "checkout")
+				td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr(AttributeKey, "0000000000000000")
+				if err := e.ConsumeTraces(ctx, td); err != nil {
+					t.Fatalf("ConsumeTraces() = %v", err)
+				}
+				out := traces.AllTraces()[len(traces.AllTraces())-1].ResourceSpans()
+				return []map[string]any{out.At(0).Resource().Attributes().AsRaw(), out.At(1).Resource().Attributes().AsRaw()}, e.Capabilities()
+			},
+		},
+		{
+			name: "metrics",
+			consume: func(t *testing.T) ([]map[string]any, consumer.Capabilities) {
+				e, err := f.CreateMetrics(ctx, set, f.CreateDefaultConfig())
+				if err != nil {
+					t.Fatalf("CreateMetrics() = %v", err)
+				}
+				md := pmetric.NewMetrics()
+				md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("service.name", "checkout")
+				md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr(AttributeKey, "0000000000000000")
+				if err := e.ConsumeMetrics(ctx, md); err != nil {
+					t.Fatalf("ConsumeMetrics() = %v", err)
+				}
+				out := metrics.AllMetrics()[len(metrics.AllMetrics())-1].ResourceMetrics()
+				return []map[string]any{out.At(0).Resource().Attributes().AsRaw(), out.At(1).Resource().Attributes().AsRaw()}, e.Capabilities()
+			},
+		},
+		{
+			name: "logs",
+			consume: func(t *testing.T) ([]map[string]any, consumer.Capabilities) {
+				e, err := f.CreateLogs(ctx, set, f.CreateDefaultConfig())
+				if err != nil {
+					t.Fatalf("CreateLogs() = %v", err)
+				}
+				ld := plog.NewLogs()
+				ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("service.name", "checkout")
+				ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr(AttributeKey, "0000000000000000")
+				if err := e.ConsumeLogs(ctx, ld); err != nil {
+					t.Fatalf("ConsumeLogs() = %v", err)
+				}
+				out := logs.AllLogs()[len(logs.AllLogs())-1].ResourceLogs()
+				return []map[string]any{out.At(0).Resource().Attributes().AsRaw(), out.At(1).Resource().Attributes().AsRaw()}, e.Capabilities()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources, capabilities := tt.consume(t)
+			if !capabilities.MutatesData {
+				t.Error("Capabilities().MutatesData = false, want true")
+			}
+			if got := resources[0]; got[AttributeKey] != hash || got["service.name"] != "checkout" {
+				t.Errorf("first resource = %v, want service.name kept and %s = %s", got, AttributeKey, hash)
+			}
+			if got := resources[1][AttributeKey]; got != hash {
+				t.Errorf("stamped resource %s = %v, want it replaced with %s", AttributeKey, got, hash)
+			}
+		})
+	}
+}
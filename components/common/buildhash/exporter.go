@@ -0,0 +1,108 @@
+package buildhash
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Exporter returns f with every exporter it creates setting the
+// AttributeKey resource attribute to hash on the data it exports. A value
+// already set upstream, by a collector forwarding to this one, is replaced.
+func Exporter(f exporter.Factory, hash string) exporter.Factory {
+	return exporterFactory{Factory: f, hash: hash}
+}
+
+type exporterFactory struct {
+	exporter.Factory
+	hash string
+}
+
+func (f exporterFactory) CreateTraces(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+	exp, err := f.Factory.CreateTraces(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return tracesExporter{Traces: exp, hash: f.hash}, nil
+}
+
+func (f exporterFactory) CreateMetrics(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+	exp, err := f.Factory.CreateMetrics(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return metricsExporter{Metrics: exp, hash: f.hash}, nil
+}
+
+func (f exporterFactory) CreateLogs(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+	exp, err := f.Factory.CreateLogs(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return logsExporter{Logs: exp, hash: f.hash}, nil
+}
+
+// capabilities reports that the wrapped exporter mutates the data, so the
+// pipeline hands it a copy when the data is shared with other consumers.
+func capabilities(c consumer.Capabilities) consumer.Capabilities {
+	c.MutatesData = true
+	return c
+}
+
+func stamp(resource pcommon.Resource, hash string) {
+	resource.Attributes().PutStr(AttributeKey, hash)
+}
+
+type tracesExporter struct {
+	exporter.Traces
+	hash string
+}
+
+func (e tracesExporter) Capabilities() consumer.Capabilities {
+	return capabilities(e.Traces.Capabilities())
+}
+
+func (e tracesExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		stamp(td.ResourceSpans().At(i).Resource(), e.hash)
+	}
+	return e.Traces.ConsumeTraces(ctx, td)
+}
+
+type metricsExporter struct {
+	exporter.Metrics
+	hash string
+}
+
+func (e metricsExporter) Capabilities() consumer.Capabilities {
+	return capabilities(e.Metrics.Capabilities())
+}
+
+func (e metricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		stamp(md.ResourceMetrics().At(i).Resource(), e.hash)
+	}
+	return e.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type logsExporter struct {
+	exporter.Logs
+	hash string
+}
+
+func (e logsExporter) Capabilities() consumer.Capabilities {
+	return capabilities(e.Logs.Capabilities())
+}
+
+func (e logsExporter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		stamp(ld.ResourceLogs().At(i).Resource(), e.hash)
+	}
+	return e.Logs.ConsumeLogs(ctx, ld)
+}
@@ -0,0 +1,56 @@
+package cardinalitylimitprocessor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultOverflowValue replaces the values over the limit of an attribute.
+const DefaultOverflowValue = "__other__"
+
+// Config defines the configuration for the cardinality limit processor.
+type Config struct {
+	// Limits lists the attributes whose values are capped.
+	Limits []LimitConfig `mapstructure:"limits"`
+	// OverflowValue replaces the values over the limit.
+	OverflowValue string `mapstructure:"overflow_value"`
+}
+
+// LimitConfig caps the number of distinct values of one attribute.
+type LimitConfig struct {
+	// Key is the attribute key, looked up in the resource attributes and in
+	// the attributes of spans, metric data points and log records.
+	Key string `mapstructure:"key"`
+	// MaxValues is the number of distinct values let through. The first
+	// MaxValues values seen are kept; later ones are replaced with
+	// OverflowValue.
+	MaxValues int `mapstructure:"max_values"`
+}
+
+// Validate checks the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Limits) == 0 {
+		return errors.New("limits must list at least one attribute")
+	}
+	if cfg.OverflowValue == "" {
+		return errors.New("overflow_value must not be empty")
+	}
+	var errs []error
+	seen := make(map[string]bool)
+	for i, l := range cfg.Limits {
+		if l.Key == "" {
+			errs = append(errs, fmt.Errorf("limits[%d]: key must be set", i))
+		} else if seen[l.Key] {
+			errs = append(errs, fmt.Errorf("limits[%d]: key %q is limited more than once", i, l.Key))
+		}
+		seen[l.Key] = true
+		if l.MaxValues <= 0 {
+			errs = append(errs, fmt.Errorf("limits[%d]: max_values must be greater than zero", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func createDefaultConfig() *Config {
+	return &Config{OverflowValue: DefaultOverflowValue}
+}
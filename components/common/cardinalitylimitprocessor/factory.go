@@ -0,0 +1,47 @@
+// Package cardinalitylimitprocessor caps the number of distinct values of
+// selected attributes, such as a URL that embeds IDs, so a single attribute
+// can't blow up the cardinality, and the cost, of the data downstream. Values
+// over the cap are replaced with a sentinel.
+package cardinalitylimitprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+var componentType = component.MustNewType("cardinalitylimit")
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+// NewFactory creates a factory for the cardinality limit processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		componentType,
+		func() component.Config { return createDefaultConfig() },
+		processor.WithTraces(createTraces, component.StabilityLevelDevelopment),
+		processor.WithMetrics(createMetrics, component.StabilityLevelDevelopment),
+		processor.WithLogs(createLogs, component.StabilityLevelDevelopment),
+	)
+}
+
+func createTraces(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+	p := newCardinalityLimiter(set.ID, cfg.(*Config))
+	return processorhelper.NewTraces(ctx, set, cfg, next, p.processTraces,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createMetrics(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Metrics) (processor.Metrics, error) {
+	p := newCardinalityLimiter(set.ID, cfg.(*Config))
+	return processorhelper.NewMetrics(ctx, set, cfg, next, p.processMetrics,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createLogs(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+	p := newCardinalityLimiter(set.ID, cfg.(*Config))
+	return processorhelper.NewLogs(ctx, set, cfg, next, p.processLogs,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
@@ -0,0 +1,170 @@
+package cardinalitylimitprocessor
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// seenValues holds the values seen of each limited attribute, by processor
+// ID. It outlives the processors, so the values admitted in earlier
+// invocations stay admitted when the collector is restarted within the
+// environment, and the traces, metrics and logs processors of one component
+// share their budget.
+var (
+	seenMu     sync.Mutex
+	seenValues = make(map[component.ID]map[string]*valueSet)
+)
+
+// valueSet is the set of admitted values of one attribute. It never holds more
+// than max values, which bounds the memory the processor uses.
+type valueSet struct {
+	mu     sync.Mutex
+	max    int
+	values map[string]struct{}
+}
+
+// admit reports whether value is within the limit, recording it if there is
+// room left.
+func (s *valueSet) admit(value string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.values[value]; ok {
+		return true
+	}
+	if len(s.values) >= s.max {
+		return false
+	}
+	s.values[value] = struct{}{}
+	return true
+}
+
+// cardinalityLimiter replaces the values of the limited attributes that don't
+// fit in their valueSet. Replacing a metric data point's attribute can leave
+// two data points with the same attributes in a metric; they describe what
+// the overflow collapsed into and are for the backend to aggregate.
+type cardinalityLimiter struct {
+	sets     map[string]*valueSet
+	overflow string
+}
+
+func newCardinalityLimiter(id component.ID, cfg *Config) *cardinalityLimiter {
+	seenMu.Lock()
+	defer seenMu.Unlock()
+	sets := seenValues[id]
+	if sets == nil {
+		sets = make(map[string]*valueSet)
+		seenValues[id] = sets
+	}
+	limited := make(map[string]*valueSet, len(cfg.Limits))
+	for _, l := range cfg.Limits {
+		s, ok := sets[l.Key]
+		if !ok {
+			s = &valueSet{values: make(map[string]struct{})}
+			sets[l.Key] = s
+		}
+		// A restarted collector may have changed the limit. Values admitted
+		// under a higher one stay admitted.
+		s.mu.Lock()
+		s.max = l.MaxValues
+		s.mu.Unlock()
+		limited[l.Key] = s
+	}
+	return &cardinalityLimiter{sets: limited, overflow: cfg.OverflowValue}
+}
+
+// limit replaces the limited attributes of attrs that are over their limit.
+func (p *cardinalityLimiter) limit(attrs pcommon.Map) {
+	for key, set := range p.sets {
+		v, ok := attrs.Get(key)
+		if !ok {
+			continue
+		}
+		if !set.admit(v.AsString()) {
+			attrs.PutStr(key, p.overflow)
+		}
+	}
+}
+
+func (p *cardinalityLimiter) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		p.limit(rs.Resource().Attributes())
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				p.limit(spans.At(k).Attributes())
+			}
+		}
+	}
+	return td, nil
+}
+
+func (p *cardinalityLimiter) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		p.limit(rl.Resource().Attributes())
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			records := sls.At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				p.limit(records.At(k).Attributes())
+			}
+		}
+	}
+	return ld, nil
+}
+
+func (p *cardinalityLimiter) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		p.limit(rm.Resource().Attributes())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				p.limitDataPoints(metrics.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+func (p *cardinalityLimiter) limitDataPoints(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.limit(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.limit(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.limit(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.limit(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.limit(dps.At(i).Attributes())
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package cardinalitylimitprocessor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// resetSeen forgets the values seen by every processor, before and after the
+// test.
+func resetSeen(t *testing.T) {
+	t.Helper()
+	reset := func() {
+		seenMu.Lock()
+		defer seenMu.Unlock()
+		clear(seenValues)
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+// values returns the value of key in every set of attributes, in order, and
+// "-" for those that don't have it.
+func values(attrs []pcommon.Map, key string) []string {
+	var got []string
+	for _, a := range attrs {
+		v, ok := a.Get(key)
+		if !ok {
+			got = append(got, "-")
+			continue
+		}
+		got = append(got, v.AsString())
+	}
+	return got
+}
+
+// process hands one item per value to p as the given signal, each with its
+// attribute key set to that value, and returns the attributes it let through.
+func process(t *testing.T, p *cardinalityLimiter, signal, key string, vals []string) []pcommon.Map {
+	t.Helper()
+	var attrs []pcommon.Map
+	switch signal {
+	case "traces":
+		td := ptrace.NewTraces()
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		for _, v := range vals {
+			spans.AppendEmpty().Attributes().PutStr(key, v)
+		}
+		td, err := p.processTraces(context.Background(), td)
+		if err != nil {
+			t.Fatalf("processTraces() = %v", err)
+		}
+		spans = td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for i := 0; i < spans.Len(); i++ {
+			attrs = append(attrs, spans.At(i).Attributes())
+		}
+	case "metrics":
+		md := pmetric.NewMetrics()
+		metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+		points := metrics.AppendEmpty().SetEmptySum().DataPoints()
+		for _, v := range vals {
+			points.AppendEmpty().Attributes().PutStr(key, v)
+		}
+		md, err := p.processMetrics(context.Background(), md)
+		if err != nil {
+			t.Fatalf("processMetrics() = %v", err)
+		}
+		points = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+		for i := 0; i < points.Len(); i++ {
+			attrs = append(attrs, points.At(i).Attributes())
+		}
+	case "logs":
+		ld := plog.NewLogs()
+		records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+		for _, v := range vals {
+			records.AppendEmpty().Attributes().PutStr(key, v)
+		}
+		ld, err := p.processLogs(context.Background(), ld)
+		if err != nil {
+			t.Fatalf("processLogs() = %v", err)
+		}
+		records = ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < records.Len(); i++ {
+			attrs = append(attrs, records.At(i).Attributes())
+		}
+	}
+	return attrs
+}
+
+func TestCardinalityLimit(t *testing.T) {
+	many := make([]string, 100)
+	for i := range many {
+		many[i] = fmt.Sprintf("/orders/%d", i)
+	}
+	wantMany := slices.Repeat([]string{DefaultOverflowValue}, len(many))
+	copy(wantMany, many[:3])
+
+	tests := []struct {
+		name     string
+		overflow string
+		// batches are the values of http.route handed to the processor, one
+		// batch at a time.
+		batches [][]string
+		want    [][]string
+	}{
+		{
+			name:    "within the limit",
+			batches: [][]string{{"/a", "/b", "/a", "/c"}},
+			want:    [][]string{{"/a", "/b", "/a", "/c"}},
+		},
+		{
+			name:    "many distinct values",
+			batches: [][]string{many},
+			want:    [][]string{wantMany},
+		},
+		{
+			name:    "admitted values pass after the limit is reached",
+			batches: [][]string{{"/a", "/b", "/c", "/d"}, {"/e", "/b", "/a"}},
+			want:    [][]string{{"/a", "/b", "/c", DefaultOverflowValue}, {DefaultOverflowValue, "/b", "/a"}},
+		},
+		{
+			name:     "overflow value",
+			overflow: "other",
+			batches:  [][]string{{"/a", "/b", "/c", "/d"}},
+			want:     [][]string{{"/a", "/b", "/c", "other"}},
+		},
+	}
+	for _, signal := range []string{"traces", "metrics", "logs"} {
+		for _, tt := range tests {
+			t.Run(signal+"/"+tt.name, func(t *testing.T) {
+				resetSeen(t)
+				cfg := createDefaultConfig()
+				cfg.Limits = []LimitConfig{{Key: "http.route", MaxValues: 3}}
+				if tt.overflow != "" {
+					cfg.OverflowValue = tt.overflow
+				}
+				p := newCardinalityLimiter(component.MustNewID("cardinalitylimit"), cfg)
+				for i, batch := range tt.batches {
+					got := values(process(t, p, signal, "http.route", batch), "http.route")
+					if !slices.Equal(got, tt.want[i]) {
+						t.Errorf("batch %d = %v, want %v", i, got, tt.want[i])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestCardinalityLimitAttributes(t *testing.T) {
+	resetSeen(t)
+	cfg := createDefaultConfig()
+	cfg.Limits = []LimitConfig{{Key: "user.id", MaxValues: 1}}
+	p := newCardinalityLimiter(component.MustNewID("cardinalitylimit"), cfg)
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("user.id", "alice")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for _, user := range []string{"alice", "bob"} {
+		s := spans.AppendEmpty()
+		s.Attributes().PutStr("user.id", user)
+		s.Attributes().PutStr("http.route", "/"+user)
+	}
+	if _, err := p.processTraces(context.Background(), td); err != nil {
+		t.Fatalf("processTraces() = %v", err)
+	}
+
+	resource := []pcommon.Map{rs.Resource().Attributes()}
+	if got := values(resource, "user.id"); !slices.Equal(got, []string{"alice"}) {
+		t.Errorf("resource user.id = %v, want [alice]", got)
+	}
+	attrs := []pcommon.Map{spans.At(0).Attributes(), spans.At(1).Attributes()}
+	if got, want := values(attrs, "user.id"), []string{"alice", DefaultOverflowValue}; !slices.Equal(got, want) {
+		t.Errorf("span user.id = %v, want %v", got, want)
+	}
+	if got, want := values(attrs, "http.route"), []string{"/alice", "/bob"}; !slices.Equal(got, want) {
+		t.Errorf("span http.route = %v, want %v", got, want)
+	}
+}
+
+func TestCardinalityLimitSharedAcrossRestarts(t *testing.T) {
+	tests := []struct {
+		name string
+		// second is the ID of the processor created after a first one, with
+		// a limit of 2, admitted /a and /b.
+		second component.ID
+		// max is the limit of the second processor.
+		max  int
+		want []string
+	}{
+		{
+			name:   "same processor",
+			second: component.MustNewID("cardinalitylimit"),
+			max:    2,
+			want:   []string{DefaultOverflowValue, "/b", "/a"},
+		},
+		{
+			name:   "lower limit keeps the admitted values",
+			second: component.MustNewID("cardinalitylimit"),
+			max:    1,
+			want:   []string{DefaultOverflowValue, "/b", "/a"},
+		},
+		{
+			name:   "higher limit",
+			second: component.MustNewID("cardinalitylimit"),
+			max:    3,
+			want:   []string{"/c", "/b", "/a"},
+		},
+		{
+			name:   "other processor",
+			second: component.MustNewIDWithName("cardinalitylimit", "other"),
+			max:    2,
+			want:   []string{"/c", "/b", DefaultOverflowValue},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetSeen(t)
+			first := createDefaultConfig()
+			first.Limits = []LimitConfig{{Key: "http.route", MaxValues: 2}}
+			p := newCardinalityLimiter(component.MustNewID("cardinalitylimit"), first)
+			process(t, p, "traces", "http.route", []string{"/a", "/b"})
+
+			second := createDefaultConfig()
+			second.Limits = []LimitConfig{{Key: "http.route", MaxValues: tt.max}}
+			p = newCardinalityLimiter(tt.second, second)
+			got := values(process(t, p, "logs", "http.route", []string{"/c", "/b", "/a"}), "http.route")
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("values = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cfg:  Config{Limits: []LimitConfig{{Key: "http.route", MaxValues: 10}}, OverflowValue: DefaultOverflowValue},
+		},
+		{
+			name:    "no limits",
+			cfg:     *createDefaultConfig(),
+			wantErr: "limits must list at least one attribute",
+		},
+		{
+			name:    "no overflow value",
+			cfg:     Config{Limits: []LimitConfig{{Key: "http.route", MaxValues: 10}}},
+			wantErr: "overflow_value must not be empty",
+		},
+		{
+			name:    "no key",
+			cfg:     Config{Limits: []LimitConfig{{MaxValues: 10}}, OverflowValue: DefaultOverflowValue},
+			wantErr: "limits[0]: key must be set",
+		},
+		{
+			name: "duplicate key",
+			cfg: Config{
+				Limits:        []LimitConfig{{Key: "http.route", MaxValues: 10}, {Key: "http.route", MaxValues: 5}},
+				OverflowValue: DefaultOverflowValue,
+			},
+			wantErr: `limits[1]: key "http.route" is limited more than once`,
+		},
+		{
+			name:    "no max values",
+			cfg:     Config{Limits: []LimitConfig{{Key: "http.route"}}, OverflowValue: DefaultOverflowValue},
+			wantErr: "limits[0]: max_values must be greater than zero",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
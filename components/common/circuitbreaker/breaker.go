@@ -0,0 +1,150 @@
+// Package circuitbreaker stops exporters from sending to a backend that keeps
+// failing, so retries against it don't use up the invocation. After Threshold
+// consecutive failed exports, exports fail immediately until the cooldown has
+// passed; the next export then probes the backend and closes the breaker again
+// if it succeeds.
+package circuitbreaker
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol"
+)
+
+const (
+	// EnvVar names the variable listing the exporters to wrap, as comma
+	// separated component types (e.g. "otlphttp,kafka"), or "*" for all.
+	EnvVar = "OCELOT_CIRCUIT_BREAKER"
+	// ThresholdEnvVar overrides DefaultThreshold.
+	ThresholdEnvVar = "OCELOT_CIRCUIT_BREAKER_THRESHOLD"
+	// CooldownEnvVar overrides DefaultCooldown, as a Go duration (e.g. "10s").
+	CooldownEnvVar = "OCELOT_CIRCUIT_BREAKER_COOLDOWN"
+)
+
+const (
+	// DefaultThreshold is the number of consecutive failures that opens the
+	// breaker.
+	DefaultThreshold = 5
+	// DefaultCooldown is how long an open breaker fails exports before
+	// probing the backend again.
+	DefaultCooldown = 30 * time.Second
+)
+
+// Settings configures the breakers of the wrapped exporters.
+type Settings struct {
+	Threshold int
+	Cooldown  time.Duration
+}
+
+// Wrap returns factories with the exporter factories listed in EnvVar wrapped
+// by Exporter, using the settings from ThresholdEnvVar and CooldownEnvVar.
+// factories is returned unchanged when EnvVar is unset.
+func Wrap(factories otelcol.Factories) (otelcol.Factories, error) {
+	list := strings.TrimSpace(os.Getenv(EnvVar))
+	if list == "" {
+		return factories, nil
+	}
+	settings, err := settingsFromEnv()
+	if err != nil {
+		return factories, err
+	}
+	wanted := make(map[string]bool)
+	for _, typ := range strings.Split(list, ",") {
+		wanted[strings.TrimSpace(typ)] = true
+	}
+	exporters := make(map[component.Type]exporter.Factory, len(factories.Exporters))
+	for typ, f := range factories.Exporters {
+		if wanted["*"] || wanted[typ.String()] {
+			f = Exporter(f, settings)
+		}
+		exporters[typ] = f
+	}
+	factories.Exporters = exporters
+	return factories, nil
+}
+
+func settingsFromEnv() (Settings, error) {
+	settings := Settings{Threshold: DefaultThreshold, Cooldown: DefaultCooldown}
+	if v := os.Getenv(ThresholdEnvVar); v != "" {
+		threshold, err := strconv.Atoi(v)
+		if err != nil || threshold < 1 {
+			return settings, fmt.Errorf("%s must be a positive integer, got %q", ThresholdEnvVar, v)
+		}
+		settings.Threshold = threshold
+	}
+	if v := os.Getenv(CooldownEnvVar); v != "" {
+		cooldown, err := time.ParseDuration(v)
+		if err != nil || cooldown <= 0 {
+			return settings, fmt.Errorf("%s must be a positive duration, got %q", CooldownEnvVar, v)
+		}
+		settings.Cooldown = cooldown
+	}
+	return settings, nil
+}
+
+type state int
+
+const (
+	closed state = iota
+	open
+	halfOpen
+)
+
+// breaker is the state shared by the exporters created for one component.
+type breaker struct {
+	settings Settings
+	id       component.ID
+
+	mu       sync.Mutex
+	state    state
+	failures int
+	openedAt time.Time
+}
+
+// allow reports whether an export may be attempted. An open breaker lets a
+// single probe through once the cooldown has passed.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case open:
+		if time.Since(b.openedAt) < b.settings.Cooldown {
+			return false
+		}
+		b.state = halfOpen
+		return true
+	case halfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// record updates the breaker with the outcome of an attempted export, and
+// reports whether the breaker opened.
+func (b *breaker) record(err error) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.state, b.failures = closed, 0
+		return false
+	}
+	b.failures++
+	if b.state == halfOpen || b.failures >= b.settings.Threshold {
+		b.state, b.openedAt = open, time.Now()
+		return true
+	}
+	return false
+}
+
+func (b *breaker) rejected() error {
+	return consumererror.NewPermanent(fmt.Errorf("circuit breaker for exporter %s is open after %d consecutive failures", b.id, b.settings.Threshold))
+}
@@ -0,0 +1,186 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.uber.org/zap"
+)
+
+var errBackend = errors.New("backend unavailable")
+
+// export is an export attempted through the breaker.
+type export struct {
+	// wait elapses before the export.
+	wait time.Duration
+	fail bool
+	// wantSent is whether the export reaches the backend, and wantOpen
+	// whether the breaker is open after it.
+	wantSent, wantOpen bool
+}
+
+func TestBreaker(t *testing.T) {
+	const cooldown = 20 * time.Millisecond
+	tests := []struct {
+		name    string
+		exports []export
+	}{
+		{
+			name: "failures under the threshold",
+			exports: []export{
+				{fail: true, wantSent: true},
+				{fail: true, wantSent: true},
+				{wantSent: true},
+				{fail: true, wantSent: true},
+				{fail: true, wantSent: true},
+			},
+		},
+		{
+			name: "consecutive failures open the breaker",
+			exports: []export{
+				{fail: true, wantSent: true},
+				{fail: true, wantSent: true},
+				{fail: true, wantSent: true, wantOpen: true},
+				{wantOpen: true},
+			},
+		},
+		{
+			name: "successful probe closes the breaker",
+			exports: []export{
+				{fail: true, wantSent: true},
+				{fail: true, wantSent: true},
+				{fail: true, wantSent: true, wantOpen: true},
+				{wait: cooldown, wantSent: true},
+				{fail: true, wantSent: true},
+				{wantSent: true},
+			},
+		},
+		{
+			name: "failed probe opens the breaker again",
+			exports: []export{
+				{fail: true, wantSent: true},
+				{fail: true, wantSent: true},
+				{fail: true, wantSent: true, wantOpen: true},
+				{wait: cooldown, fail: true, wantSent: true, wantOpen: true},
+				{wantOpen: true},
+				{wait: cooldown, wantSent: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := guard{
+				breaker: &breaker{settings: Settings{Threshold: 3, Cooldown: cooldown}, id: component.MustNewID("otlphttp")},
+				logger:  zap.NewNop(),
+			}
+			for i, e := range tt.exports {
+				time.Sleep(e.wait)
+				sent := false
+				err := g.do(func() error {
+					sent = true
+					if e.fail {
+						return errBackend
+					}
+					return nil
+				})
+				if sent != e.wantSent {
+					t.Fatalf("export %d sent: %t, want %t", i, sent, e.wantSent)
+				}
+				if !sent {
+					if !consumererror.IsPermanent(err) {
+						t.Errorf("export %d = %v, want a permanent error", i, err)
+					}
+					if want := "circuit breaker for exporter otlphttp is open after 3 consecutive failures"; err == nil || err.Error() != want {
+						t.Errorf("export %d = %v, want %q", i, err, want)
+					}
+				} else if e.fail != errors.Is(err, errBackend) {
+					t.Errorf("export %d = %v, want the backend's error: %t", i, err, e.fail)
+				}
+				if isOpen := g.breaker.state == open; isOpen != e.wantOpen {
+					t.Errorf("export %d left the breaker open: %t, want %t", i, isOpen, e.wantOpen)
+				}
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	otlphttp := component.MustNewType("otlphttp")
+	kafka := component.MustNewType("kafka")
+	factories := otelcol.Factories{Exporters: map[component.Type]exporter.Factory{
+		otlphttp: exporter.NewFactory(otlphttp, func() component.Config { return &struct{}{} }),
+		kafka:    exporter.NewFactory(kafka, func() component.Config { return &struct{}{} }),
+	}}
+	tests := []struct {
+		name         string
+		env          map[string]string
+		wantWrapped  []component.Type
+		wantSettings Settings
+		wantErr      string
+	}{
+		{name: "unset"},
+		{
+			name:         "listed",
+			env:          map[string]string{EnvVar: " otlphttp ,other"},
+			wantWrapped:  []component.Type{otlphttp},
+			wantSettings: Settings{Threshold: DefaultThreshold, Cooldown: DefaultCooldown},
+		},
+		{
+			name:         "all",
+			env:          map[string]string{EnvVar: "*", ThresholdEnvVar: "2", CooldownEnvVar: "10s"},
+			wantWrapped:  []component.Type{otlphttp, kafka},
+			wantSettings: Settings{Threshold: 2, Cooldown: 10 * time.Second},
+		},
+		{
+			name:    "invalid threshold",
+			env:     map[string]string{EnvVar: "*", ThresholdEnvVar: "0"},
+			wantErr: `OCELOT_CIRCUIT_BREAKER_THRESHOLD must be a positive integer, got "0"`,
+		},
+		{
+			name:    "invalid cooldown",
+			env:     map[string]string{EnvVar: "*", CooldownEnvVar: "30"},
+			wantErr: `OCELOT_CIRCUIT_BREAKER_COOLDOWN must be a positive duration, got "30"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{EnvVar, ThresholdEnvVar, CooldownEnvVar} {
+				t.Setenv(key, tt.env[key])
+			}
+			got, err := Wrap(factories)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Wrap() = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Wrap() = %v", err)
+			}
+			wrapped := 0
+			for typ, f := range got.Exporters {
+				ef, ok := f.(exporterFactory)
+				if !ok {
+					continue
+				}
+				wrapped++
+				if ef.settings != tt.wantSettings {
+					t.Errorf("%s settings = %+v, want %+v", typ, ef.settings, tt.wantSettings)
+				}
+			}
+			if wrapped != len(tt.wantWrapped) {
+				t.Errorf("%d exporters wrapped, want %v", wrapped, tt.wantWrapped)
+			}
+			for _, typ := range tt.wantWrapped {
+				if _, ok := got.Exporters[typ].(exporterFactory); !ok {
+					t.Errorf("%s isn't wrapped", typ)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package circuitbreaker
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// Exporter returns f with every exporter it creates guarded by a circuit
+// breaker. Each exporter has its own breaker.
+func Exporter(f exporter.Factory, settings Settings) exporter.Factory {
+	return exporterFactory{Factory: f, settings: settings}
+}
+
+type exporterFactory struct {
+	exporter.Factory
+	settings Settings
+}
+
+func (f exporterFactory) newGuard(set exporter.Settings) guard {
+	return guard{breaker: &breaker{settings: f.settings, id: set.ID}, logger: set.Logger}
+}
+
+func (f exporterFactory) CreateTraces(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+	exp, err := f.Factory.CreateTraces(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return tracesExporter{Traces: exp, guard: f.newGuard(set)}, nil
+}
+
+func (f exporterFactory) CreateMetrics(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+	exp, err := f.Factory.CreateMetrics(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return metricsExporter{Metrics: exp, guard: f.newGuard(set)}, nil
+}
+
+func (f exporterFactory) CreateLogs(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+	exp, err := f.Factory.CreateLogs(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return logsExporter{Logs: exp, guard: f.newGuard(set)}, nil
+}
+
+type guard struct {
+	breaker *breaker
+	logger  *zap.Logger
+}
+
+func (g guard) do(send func() error) error {
+	if !g.breaker.allow() {
+		return g.breaker.rejected()
+	}
+	err := send()
+	if g.breaker.record(err) {
+		g.logger.Warn("Circuit breaker opened, exports fail until the cooldown has passed",
+			zap.Duration("cooldown", g.breaker.settings.Cooldown), zap.Error(err))
+	}
+	return err
+}
+
+type tracesExporter struct {
+	exporter.Traces
+	guard guard
+}
+
+func (e tracesExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return e.guard.do(func() error { return e.Traces.ConsumeTraces(ctx, td) })
+}
+
+type metricsExporter struct {
+	exporter.Metrics
+	guard guard
+}
+
+func (e metricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return e.guard.do(func() error { return e.Metrics.ConsumeMetrics(ctx, md) })
+}
+
+type logsExporter struct {
+	exporter.Logs
+	guard guard
+}
+
+func (e logsExporter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return e.guard.do(func() error { return e.Logs.ConsumeLogs(ctx, ld) })
+}
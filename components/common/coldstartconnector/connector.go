@@ -0,0 +1,74 @@
+package coldstartconnector
+
+import (
+	"context"
+	"sync"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/invocation"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// emitted holds the IDs of the connectors that have emitted the metric. It
+// outlives the connectors, so a collector restarted within the environment
+// doesn't report the cold start again, and the traces and logs connectors of
+// one component report it once between them.
+var emitted sync.Map
+
+type coldStart struct {
+	component.StartFunc
+	component.ShutdownFunc
+	id   component.ID
+	next consumer.Metrics
+}
+
+func newColdStart(id component.ID, next consumer.Metrics) *coldStart {
+	return &coldStart{id: id, next: next}
+}
+
+func (c *coldStart) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *coldStart) ConsumeTraces(ctx context.Context, _ ptrace.Traces) error {
+	return c.emit(ctx)
+}
+
+func (c *coldStart) ConsumeLogs(ctx context.Context, _ plog.Logs) error {
+	return c.emit(ctx)
+}
+
+// emit sends the metric if the first invocation has begun and it hasn't been
+// sent yet.
+func (c *coldStart) emit(ctx context.Context) error {
+	first, ok := invocation.First()
+	if !ok {
+		return nil
+	}
+	if _, done := emitted.LoadOrStore(c.id.String(), true); done {
+		return nil
+	}
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	for _, attr := range lambdaenv.ResourceAttributes() {
+		rm.Resource().Attributes().PutStr(attr.Key, attr.Value)
+	}
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(componentType.String())
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("faas.coldstart.duration")
+	m.SetDescription("Time from the start of the execution environment to its first invocation")
+	m.SetUnit("s")
+	dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(invocation.ProcessStart()))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(first.Start))
+	dp.SetDoubleValue(first.Start.Sub(invocation.ProcessStart()).Seconds())
+	dp.Attributes().PutStr("faas.invocation_id", first.RequestID)
+	return c.next.ConsumeMetrics(ctx, md)
+}
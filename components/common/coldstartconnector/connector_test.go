@@ -0,0 +1,93 @@
+package coldstartconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/invocation"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+func settings(name string) connector.Settings {
+	return connector.Settings{
+		ID: component.NewIDWithName(componentType, name),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+}
+
+func TestColdStart(t *testing.T) {
+	f := NewFactory()
+	ctx := context.Background()
+	sink := new(consumertest.MetricsSink)
+	traces, err := f.CreateTracesToMetrics(ctx, settings("shared"), f.CreateDefaultConfig(), sink)
+	if err != nil {
+		t.Fatalf("CreateTracesToMetrics() = %v", err)
+	}
+	logs, err := f.CreateLogsToMetrics(ctx, settings("shared"), f.CreateDefaultConfig(), sink)
+	if err != nil {
+		t.Fatalf("CreateLogsToMetrics() = %v", err)
+	}
+	other := new(consumertest.MetricsSink)
+	otherTraces, err := f.CreateTracesToMetrics(ctx, settings("other"), f.CreateDefaultConfig(), other)
+	if err != nil {
+		t.Fatalf("CreateTracesToMetrics() = %v", err)
+	}
+
+	// The steps share the process-wide invocation state, so they run in order.
+	steps := []struct {
+		name      string
+		invoke    string
+		consume   func() error
+		wantTotal int // metrics emitted by the shared connector so far
+	}{
+		{name: "before the first invocation", consume: func() error { return traces.ConsumeTraces(ctx, ptrace.NewTraces()) }},
+		{name: "first invocation", invoke: "request-1", consume: func() error { return traces.ConsumeTraces(ctx, ptrace.NewTraces()) }, wantTotal: 1},
+		{name: "same invocation", consume: func() error { return traces.ConsumeTraces(ctx, ptrace.NewTraces()) }, wantTotal: 1},
+		{name: "logs of the same component", consume: func() error { return logs.ConsumeLogs(ctx, plog.NewLogs()) }, wantTotal: 1},
+		{name: "warm invocation", invoke: "request-2", consume: func() error { return traces.ConsumeTraces(ctx, ptrace.NewTraces()) }, wantTotal: 1},
+	}
+	for _, step := range steps {
+		if step.invoke != "" {
+			invocation.Begin(step.invoke)
+		}
+		if err := step.consume(); err != nil {
+			t.Fatalf("%s: consume = %v", step.name, err)
+		}
+		if got := sink.DataPointCount(); got != step.wantTotal {
+			t.Fatalf("%s: %d cold start data points emitted, want %d", step.name, got, step.wantTotal)
+		}
+	}
+
+	dp := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	if got, _ := dp.Attributes().Get("faas.invocation_id"); got.Str() != "request-1" {
+		t.Errorf("faas.invocation_id = %q, want the first invocation, request-1", got.Str())
+	}
+	if dp.DoubleValue() < 0 {
+		t.Errorf("faas.coldstart.duration = %v, want a non-negative duration", dp.DoubleValue())
+	}
+
+	// Another connector created after a warm invocation still reports the
+	// environment's first invocation.
+	if err := otherTraces.ConsumeTraces(ctx, ptrace.NewTraces()); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+	if got := other.DataPointCount(); got != 1 {
+		t.Fatalf("other connector emitted %d data points, want 1", got)
+	}
+	dp = other.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	if got, _ := dp.Attributes().Get("faas.invocation_id"); got.Str() != "request-1" {
+		t.Errorf("other connector faas.invocation_id = %q, want request-1", got.Str())
+	}
+}
@@ -0,0 +1,38 @@
+// Package coldstartconnector reports how long the execution environment took
+// to start, as the faas.coldstart.duration metric: the time from the start of
+// the extension process to the first invocation. The metric is emitted once
+// per environment, with the first data the connector receives after the
+// first invocation has begun; warm invocations emit nothing.
+package coldstartconnector
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+var componentType = component.MustNewType("coldstart")
+
+// Config defines the configuration for the cold start connector. It has no
+// settings.
+type Config struct{}
+
+// NewFactory creates a factory for the cold start connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		componentType,
+		func() component.Config { return &Config{} },
+		connector.WithTracesToMetrics(createTracesToMetrics, component.StabilityLevelDevelopment),
+		connector.WithLogsToMetrics(createLogsToMetrics, component.StabilityLevelDevelopment),
+	)
+}
+
+func createTracesToMetrics(_ context.Context, set connector.Settings, _ component.Config, next consumer.Metrics) (connector.Traces, error) {
+	return newColdStart(set.ID, next), nil
+}
+
+func createLogsToMetrics(_ context.Context, set connector.Settings, _ component.Config, next consumer.Metrics) (connector.Logs, error) {
+	return newColdStart(set.ID, next), nil
+}
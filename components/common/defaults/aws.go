@@ -0,0 +1,15 @@
+package defaults
+
+import "os"
+
+// AssumeRoleARNEnvVar names the variable holding the role AWS exporters assume
+// by default, e.g. to export to another account.
+const AssumeRoleARNEnvVar = "OCELOT_ASSUME_ROLE_ARN"
+
+// AssumeRoleARN returns the role AWS exporters default to assuming, or "" to
+// use the credentials of the execution role. The exporters assume it through
+// STS with the SDK's credentials cache, so the session is reused across warm
+// invocations and only refreshed before it expires.
+func AssumeRoleARN() string {
+	return os.Getenv(AssumeRoleARNEnvVar)
+}
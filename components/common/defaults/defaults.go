@@ -0,0 +1,96 @@
+// Package defaults wraps upstream component factories so their default
+// configuration can be adjusted for the Lambda execution model while keeping
+// every other part of the factory (type, stability, create functions) intact.
+package defaults
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// Exporter returns f with apply run against every default config it creates.
+func Exporter[C any](f exporter.Factory, apply func(cfg *C)) exporter.Factory {
+	return exporterFactory{Factory: f, createDefaultConfig: override(f.CreateDefaultConfig, apply)}
+}
+
+type exporterFactory struct {
+	exporter.Factory
+	createDefaultConfig component.CreateDefaultConfigFunc
+}
+
+func (f exporterFactory) CreateDefaultConfig() component.Config {
+	return f.createDefaultConfig()
+}
+
+// Processor returns f with apply run against every default config it creates.
+func Processor[C any](f processor.Factory, apply func(cfg *C)) processor.Factory {
+	return processorFactory{Factory: f, createDefaultConfig: override(f.CreateDefaultConfig, apply)}
+}
+
+type processorFactory struct {
+	processor.Factory
+	createDefaultConfig component.CreateDefaultConfigFunc
+}
+
+func (f processorFactory) CreateDefaultConfig() component.Config {
+	return f.createDefaultConfig()
+}
+
+// Connector returns f with apply run against every default config it creates.
+func Connector[C any](f connector.Factory, apply func(cfg *C)) connector.Factory {
+	return connectorFactory{Factory: f, createDefaultConfig: override(f.CreateDefaultConfig, apply)}
+}
+
+type connectorFactory struct {
+	connector.Factory
+	createDefaultConfig component.CreateDefaultConfigFunc
+}
+
+func (f connectorFactory) CreateDefaultConfig() component.Config {
+	return f.createDefaultConfig()
+}
+
+// Extension returns f with apply run against every default config it creates.
+func Extension[C any](f extension.Factory, apply func(cfg *C)) extension.Factory {
+	return extensionFactory{Factory: f, createDefaultConfig: override(f.CreateDefaultConfig, apply)}
+}
+
+type extensionFactory struct {
+	extension.Factory
+	createDefaultConfig component.CreateDefaultConfigFunc
+}
+
+func (f extensionFactory) CreateDefaultConfig() component.Config {
+	return f.createDefaultConfig()
+}
+
+// Receiver returns f with apply run against every default config it creates.
+func Receiver[C any](f receiver.Factory, apply func(cfg *C)) receiver.Factory {
+	return receiverFactory{Factory: f, createDefaultConfig: override(f.CreateDefaultConfig, apply)}
+}
+
+type receiverFactory struct {
+	receiver.Factory
+	createDefaultConfig component.CreateDefaultConfigFunc
+}
+
+func (f receiverFactory) CreateDefaultConfig() component.Config {
+	return f.createDefaultConfig()
+}
+
+// override applies the Lambda defaults on top of the upstream defaults. Configs
+// of an unexpected type are returned untouched so an upstream refactor degrades
+// to stock behavior instead of a panic.
+func override[C any](create component.CreateDefaultConfigFunc, apply func(cfg *C)) component.CreateDefaultConfigFunc {
+	return func() component.Config {
+		cfg := create()
+		if c, ok := cfg.(*C); ok {
+			apply(c)
+		}
+		return cfg
+	}
+}
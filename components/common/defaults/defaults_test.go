@@ -0,0 +1,64 @@
+package defaults
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+type fakeConfig struct {
+	Timeout int
+}
+
+type otherConfig struct{}
+
+func TestOverride(t *testing.T) {
+	typ := component.MustNewType("fake")
+	newConfig := func() component.Config { return &fakeConfig{Timeout: 30} }
+	apply := func(cfg *fakeConfig) { cfg.Timeout = 5 }
+	tests := []struct {
+		name    string
+		factory component.Factory
+	}{
+		{name: "exporter", factory: Exporter(exporter.NewFactory(typ, newConfig), apply)},
+		{name: "processor", factory: Processor(processor.NewFactory(typ, newConfig), apply)},
+		{name: "connector", factory: Connector(connector.NewFactory(typ, newConfig), apply)},
+		{name: "extension", factory: Extension(extension.NewFactory(typ, newConfig, func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return nil, nil
+		}, component.StabilityLevelDevelopment), apply)},
+		{name: "receiver", factory: Receiver(receiver.NewFactory(typ, newConfig), apply)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.factory.Type() != typ {
+				t.Errorf("Type() = %v, want %v", tt.factory.Type(), typ)
+			}
+			cfg, ok := tt.factory.CreateDefaultConfig().(*fakeConfig)
+			if !ok {
+				t.Fatalf("CreateDefaultConfig() = %T, want *fakeConfig", tt.factory.CreateDefaultConfig())
+			}
+			if cfg.Timeout != 5 {
+				t.Errorf("Timeout = %d, want the override, 5", cfg.Timeout)
+			}
+			// Every call creates a new config, with the override applied.
+			cfg.Timeout = 1
+			if again := tt.factory.CreateDefaultConfig().(*fakeConfig); again.Timeout != 5 {
+				t.Errorf("Timeout = %d after changing an earlier config, want 5", again.Timeout)
+			}
+		})
+	}
+}
+
+func TestOverrideUnexpectedType(t *testing.T) {
+	f := Processor(processor.NewFactory(component.MustNewType("fake"), func() component.Config { return &otherConfig{} }),
+		func(*fakeConfig) { t.Error("override applied to a config of another type") })
+	if _, ok := f.CreateDefaultConfig().(*otherConfig); !ok {
+		t.Errorf("CreateDefaultConfig() = %T, want the upstream *otherConfig", f.CreateDefaultConfig())
+	}
+}
@@ -0,0 +1,67 @@
+package defaults
+
+import (
+	"hash/fnv"
+	"strings"
+)
+
+// Namespace derives names from the ID of the Lambda extension running the
+// collector. When several extensions run collectors in the same function,
+// defaults built with it keep their tables, files, directories and object
+// keys apart.
+type Namespace struct {
+	extensionId string
+}
+
+// WithExtensionID returns the namespace of the given extension. An empty ID,
+// as passed when describing factories, yields the stock names.
+func WithExtensionID(extensionId string) Namespace {
+	return Namespace{extensionId: extensionId}
+}
+
+// ID returns the extension ID as given.
+func (n Namespace) ID() string {
+	return n.extensionId
+}
+
+// Identifier returns the extension ID with every character other than ASCII
+// letters, digits and '_' replaced by '_', so it is valid in identifiers such
+// as table and metric names as well as in file names.
+func (n Namespace) Identifier() string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, n.extensionId)
+}
+
+// Join returns base followed by sep and the Identifier, or base unchanged
+// without an extension ID.
+func (n Namespace) Join(base, sep string) string {
+	if n.extensionId == "" {
+		return base
+	}
+	return base + sep + n.Identifier()
+}
+
+// Pick returns one of candidates, chosen by the extension ID. The same ID
+// always picks the same candidate, and IDs spread evenly across them. Picks
+// are made by rendezvous hashing, so removing a candidate only moves the
+// extensions that had picked it. It returns "" without candidates.
+func (n Namespace) Pick(candidates []string) string {
+	var picked string
+	var best uint64
+	for i, candidate := range candidates {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(n.extensionId))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(candidate))
+		if score := h.Sum64(); i == 0 || score > best {
+			picked, best = candidate, score
+		}
+	}
+	return picked
+}
@@ -0,0 +1,72 @@
+package defaults
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestNamespace(t *testing.T) {
+	tests := []struct {
+		name           string
+		extensionId    string
+		wantIdentifier string
+		wantJoin       string
+	}{
+		{name: "no extension ID", wantJoin: "otel"},
+		{name: "identifier", extensionId: "Ext_01", wantIdentifier: "Ext_01", wantJoin: "otel-Ext_01"},
+		{name: "other characters", extensionId: "a1b2-c3.d4/é", wantIdentifier: "a1b2_c3_d4__", wantJoin: "otel-a1b2_c3_d4__"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := WithExtensionID(tt.extensionId)
+			if ns.ID() != tt.extensionId {
+				t.Errorf("ID() = %q, want %q", ns.ID(), tt.extensionId)
+			}
+			if got := ns.Identifier(); got != tt.wantIdentifier {
+				t.Errorf("Identifier() = %q, want %q", got, tt.wantIdentifier)
+			}
+			if got := ns.Join("otel", "-"); got != tt.wantJoin {
+				t.Errorf("Join() = %q, want %q", got, tt.wantJoin)
+			}
+		})
+	}
+}
+
+func TestPick(t *testing.T) {
+	candidates := []string{"a", "b", "c", "d"}
+	if got := WithExtensionID("ext").Pick(nil); got != "" {
+		t.Errorf("Pick(nil) = %q, want \"\"", got)
+	}
+
+	picks := make(map[string]int)
+	moved := 0
+	for i := range 1000 {
+		ns := WithExtensionID(fmt.Sprintf("extension-%d", i))
+		picked := ns.Pick(candidates)
+		if !slices.Contains(candidates, picked) {
+			t.Fatalf("Pick() = %q, want one of %v", picked, candidates)
+		}
+		if again := ns.Pick(candidates); again != picked {
+			t.Errorf("Pick() = %q, then %q for the same extension ID", picked, again)
+		}
+		picks[picked]++
+
+		// Removing a candidate only moves the extensions that had picked it.
+		remaining := slices.DeleteFunc(slices.Clone(candidates), func(c string) bool { return c == "b" })
+		if after := ns.Pick(remaining); after != picked {
+			if picked != "b" {
+				t.Errorf("extension-%d moved from %q to %q when b was removed", i, picked, after)
+			}
+			moved++
+		}
+	}
+	if moved != picks["b"] {
+		t.Errorf("%d extensions moved when b was removed, want the %d that had picked it", moved, picks["b"])
+	}
+	for _, c := range candidates {
+		if picks[c] < 150 || picks[c] > 350 {
+			t.Errorf("%q picked %d times out of 1000, want about 250", c, picks[c])
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package defaults
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configretry"
+)
+
+// Compression returns the compression network exporters default to. Lambda
+// bills egress, so payloads are compressed with zstd, which compresses
+// telemetry better than gzip at a lower CPU cost, where the protocol supports
+// it, and with gzip otherwise.
+func Compression(zstdSupported bool) configcompression.Type {
+	if zstdSupported {
+		return configcompression.TypeZstd
+	}
+	return configcompression.TypeGzip
+}
+
+// RetryBackOff returns the retry settings network exporters default to. The
+// upstream default keeps retrying for up to 5 minutes, far longer than most
+// function timeouts: these give up after a few seconds, retrying quickly.
+func RetryBackOff() configretry.BackOffConfig {
+	cfg := configretry.NewDefaultBackOffConfig()
+	cfg.InitialInterval = 100 * time.Millisecond
+	cfg.MaxInterval = time.Second
+	cfg.MaxElapsedTime = 5 * time.Second
+	return cfg
+}
+
+// Endpoint returns the endpoint an exporter defaults to from the
+// comma-separated pool in the environment variable envVar, picked by the
+// extension ID so that collectors spread across the pool while each keeps
+// exporting to the same endpoint. It returns "" when the variable is unset.
+func Endpoint(envVar string, ns Namespace) string {
+	var endpoints []string
+	for _, endpoint := range strings.Split(os.Getenv(envVar), ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return ns.Pick(endpoints)
+}
@@ -0,0 +1,59 @@
+package defaults
+
+import (
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+func TestCompression(t *testing.T) {
+	if got := Compression(true); got != configcompression.TypeZstd {
+		t.Errorf("Compression(true) = %q, want zstd", got)
+	}
+	if got := Compression(false); got != configcompression.TypeGzip {
+		t.Errorf("Compression(false) = %q, want gzip", got)
+	}
+}
+
+func TestRetryBackOff(t *testing.T) {
+	cfg := RetryBackOff()
+	if !cfg.Enabled {
+		t.Error("RetryBackOff() disables retries")
+	}
+	if cfg.MaxElapsedTime != 5*time.Second || cfg.MaxInterval != time.Second || cfg.InitialInterval != 100*time.Millisecond {
+		t.Errorf("RetryBackOff() = %+v, want retries for up to 5s, every 100ms to 1s", cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("RetryBackOff().Validate() = %v", err)
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	const envVar = "OCELOT_TEST_ENDPOINTS"
+	tests := []struct {
+		name  string
+		pool  string
+		wants []string
+	}{
+		{name: "unset", wants: []string{""}},
+		{name: "one", pool: "https://a:4318", wants: []string{"https://a:4318"}},
+		{name: "pool", pool: " https://a:4318, ,https://b:4318 ", wants: []string{"https://a:4318", "https://b:4318"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envVar, tt.pool)
+			got := Endpoint(envVar, WithExtensionID("ext"))
+			found := false
+			for _, want := range tt.wants {
+				found = found || got == want
+			}
+			if !found {
+				t.Errorf("Endpoint() = %q, want one of %q", got, tt.wants)
+			}
+			if again := Endpoint(envVar, WithExtensionID("ext")); again != got {
+				t.Errorf("Endpoint() = %q, then %q for the same extension ID", got, again)
+			}
+		})
+	}
+}
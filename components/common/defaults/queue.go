@@ -0,0 +1,24 @@
+package defaults
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// DisableSendingQueue turns off the sending queue of the exporter configured
+// by cfg, in place. The exporter then sends the data it is handed before
+// returning, and returns the final error of the export, once retries are
+// exhausted, to its caller instead of logging it from the queue. Configs
+// without a sending queue are left unchanged.
+func DisableSendingQueue(cfg component.Config) error {
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err != nil {
+		return err
+	}
+	if !conf.IsSet("sending_queue") {
+		return nil
+	}
+	return confmap.NewFromStringMap(map[string]any{
+		"sending_queue": map[string]any{"enabled": false},
+	}).Unmarshal(cfg)
+}
@@ -0,0 +1,40 @@
+package defaults
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+type queuedConfig struct {
+	Endpoint    string                     `mapstructure:"endpoint"`
+	QueueConfig exporterhelper.QueueConfig `mapstructure:"sending_queue"`
+}
+
+type unqueuedConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+func TestDisableSendingQueue(t *testing.T) {
+	queued := &queuedConfig{Endpoint: "localhost:4317", QueueConfig: exporterhelper.NewDefaultQueueConfig()}
+	if err := DisableSendingQueue(queued); err != nil {
+		t.Fatalf("DisableSendingQueue() = %v", err)
+	}
+	if queued.QueueConfig.Enabled {
+		t.Error("the sending queue is still enabled")
+	}
+	if want := exporterhelper.NewDefaultQueueConfig().QueueSize; queued.QueueConfig.QueueSize != want {
+		t.Errorf("queue_size = %d, want %d", queued.QueueConfig.QueueSize, want)
+	}
+	if queued.Endpoint != "localhost:4317" {
+		t.Errorf("endpoint = %q, want it unchanged", queued.Endpoint)
+	}
+
+	unqueued := &unqueuedConfig{Endpoint: "localhost:4317"}
+	if err := DisableSendingQueue(unqueued); err != nil {
+		t.Fatalf("DisableSendingQueue() = %v for a config without a queue", err)
+	}
+	if unqueued.Endpoint != "localhost:4317" {
+		t.Errorf("endpoint = %q, want it unchanged", unqueued.Endpoint)
+	}
+}
@@ -0,0 +1,28 @@
+package defaults
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SamplingOverrideEnvVar names the variable forcing samplers to keep a given
+// percentage of the data, e.g. 100 to keep everything during an incident
+// without redeploying.
+const SamplingOverrideEnvVar = "OCELOT_SAMPLING_OVERRIDE"
+
+// SamplingOverride returns the percentage samplers are forced to keep, and
+// whether SamplingOverrideEnvVar is set. A value that isn't a percentage
+// between 0 and 100 is an error.
+func SamplingOverride() (float64, bool, error) {
+	v := strings.TrimSpace(os.Getenv(SamplingOverrideEnvVar))
+	if v == "" {
+		return 0, false, nil
+	}
+	percent, err := strconv.ParseFloat(v, 64)
+	if err != nil || percent < 0 || percent > 100 {
+		return 0, false, fmt.Errorf("%s must be a percentage between 0 and 100, got %q", SamplingOverrideEnvVar, v)
+	}
+	return percent, true, nil
+}
@@ -0,0 +1,40 @@
+package defaults
+
+import "testing"
+
+func TestSamplingOverride(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    float64
+		wantSet bool
+		wantErr string
+	}{
+		{name: "unset"},
+		{name: "blank", value: "  "},
+		{name: "percentage", value: " 12.5 ", want: 12.5, wantSet: true},
+		{name: "zero", value: "0", want: 0, wantSet: true},
+		{name: "everything", value: "100", want: 100, wantSet: true},
+		{name: "over a hundred", value: "101", wantErr: `OCELOT_SAMPLING_OVERRIDE must be a percentage between 0 and 100, got "101"`},
+		{name: "negative", value: "-1", wantErr: `OCELOT_SAMPLING_OVERRIDE must be a percentage between 0 and 100, got "-1"`},
+		{name: "not a number", value: "all", wantErr: `OCELOT_SAMPLING_OVERRIDE must be a percentage between 0 and 100, got "all"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(SamplingOverrideEnvVar, tt.value)
+			got, set, err := SamplingOverride()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("SamplingOverride() = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SamplingOverride() = %v", err)
+			}
+			if got != tt.want || set != tt.wantSet {
+				t.Errorf("SamplingOverride() = %v, %t, want %v, %t", got, set, tt.want, tt.wantSet)
+			}
+		})
+	}
+}
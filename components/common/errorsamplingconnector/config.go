@@ -0,0 +1,22 @@
+package errorsamplingconnector
+
+import "errors"
+
+// Config defines the configuration for the error sampling connector.
+type Config struct {
+	// SamplingPercentage is the percentage of traces without errors that are
+	// forwarded. Traces with an error span are always forwarded.
+	SamplingPercentage float64 `mapstructure:"sampling_percentage"`
+}
+
+// Validate checks the connector configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.SamplingPercentage < 0 || cfg.SamplingPercentage > 100 {
+		return errors.New("sampling_percentage must be between 0 and 100")
+	}
+	return nil
+}
+
+func createDefaultConfig() *Config {
+	return &Config{SamplingPercentage: 10}
+}
@@ -0,0 +1,82 @@
+package errorsamplingconnector
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// errorSampling decides per trace ID, so the spans of a trace that arrive in
+// different batches get the same decision. The exception is a trace whose
+// error span arrives in a later batch than its other spans: only the spans
+// batched with an error are certain to be kept.
+type errorSampling struct {
+	component.StartFunc
+	component.ShutdownFunc
+	next consumer.Traces
+	// threshold is compared with the hash of a trace ID. Traces without errors
+	// are kept when their hash is below it.
+	threshold uint64
+	keepAll   bool
+}
+
+func newConnector(cfg *Config, next consumer.Traces) *errorSampling {
+	return &errorSampling{
+		next:      next,
+		threshold: uint64(cfg.SamplingPercentage / 100 * math.MaxUint64),
+		keepAll:   cfg.SamplingPercentage >= 100,
+	}
+}
+
+func (c *errorSampling) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (c *errorSampling) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if !c.keepAll {
+		c.sample(td)
+		if td.SpanCount() == 0 {
+			return nil
+		}
+	}
+	return c.next.ConsumeTraces(ctx, td)
+}
+
+// sample removes the spans of the traces that have no error in td and aren't
+// sampled, along with the resources and scopes left empty.
+func (c *errorSampling) sample(td ptrace.Traces) {
+	withErrors := make(map[pcommon.TraceID]bool)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if span := spans.At(k); span.Status().Code() == ptrace.StatusCodeError {
+					withErrors[span.TraceID()] = true
+				}
+			}
+		}
+	}
+
+	rss.RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				return !withErrors[span.TraceID()] && !c.sampled(span.TraceID())
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+}
+
+func (c *errorSampling) sampled(id pcommon.TraceID) bool {
+	h := fnv.New64a()
+	_, _ = h.Write(id[:])
+	return h.Sum64() < c.threshold
+}
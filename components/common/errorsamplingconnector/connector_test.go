@@ -0,0 +1,173 @@
+package errorsamplingconnector
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+func settings() connector.Settings {
+	return connector.Settings{
+		ID: component.NewID(componentType),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+}
+
+// traceID returns the i-th trace ID, spread over the ID space as random IDs
+// are.
+func traceID(i int) pcommon.TraceID {
+	var id pcommon.TraceID
+	binary.BigEndian.PutUint64(id[:8], uint64(i+1)*0x9e3779b97f4a7c15)
+	binary.BigEndian.PutUint64(id[8:], uint64(i+1))
+	return id
+}
+
+// newTraces returns a batch with two resources, each holding one span of
+// every trace from 0 to count. The spans of the traces in errors have an error
+// status in the second resource.
+func newTraces(count int, errors ...int) ptrace.Traces {
+	failed := make(map[int]bool)
+	for _, i := range errors {
+		failed[i] = true
+	}
+	td := ptrace.NewTraces()
+	for r := range 2 {
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		for i := range count {
+			span := spans.AppendEmpty()
+			span.SetTraceID(traceID(i))
+			if r == 1 && failed[i] {
+				span.Status().SetCode(ptrace.StatusCodeError)
+			}
+		}
+	}
+	return td
+}
+
+// keptTraces returns the traces of the spans in the sink, with how many spans
+// of each were kept.
+func keptTraces(sink *consumertest.TracesSink) map[pcommon.TraceID]int {
+	kept := make(map[pcommon.TraceID]int)
+	for _, td := range sink.AllTraces() {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			sss := rss.At(i).ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				spans := sss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					kept[spans.At(k).TraceID()]++
+				}
+			}
+		}
+	}
+	return kept
+}
+
+func TestErrorSampling(t *testing.T) {
+	const count = 1000
+	tests := []struct {
+		name       string
+		percentage float64
+		errors     []int
+		// wantMin and wantMax bound the number of traces kept.
+		wantMin, wantMax int
+	}{
+		{name: "errors only", percentage: 0, errors: []int{3, 500}, wantMin: 2, wantMax: 2},
+		{name: "everything", percentage: 100, errors: []int{3}, wantMin: count, wantMax: count},
+		{name: "sample", percentage: 50, errors: []int{3}, wantMin: 400, wantMax: 600},
+		{name: "default", percentage: createDefaultConfig().SamplingPercentage, wantMin: 50, wantMax: 150},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFactory()
+			sink := new(consumertest.TracesSink)
+			c, err := f.CreateTracesToTraces(context.Background(), settings(), &Config{SamplingPercentage: tt.percentage}, sink)
+			if err != nil {
+				t.Fatalf("CreateTracesToTraces() = %v", err)
+			}
+			if err := c.ConsumeTraces(context.Background(), newTraces(count, tt.errors...)); err != nil {
+				t.Fatalf("ConsumeTraces() = %v", err)
+			}
+			kept := keptTraces(sink)
+			if len(kept) < tt.wantMin || len(kept) > tt.wantMax {
+				t.Errorf("%d traces kept, want between %d and %d", len(kept), tt.wantMin, tt.wantMax)
+			}
+			for _, i := range tt.errors {
+				if kept[traceID(i)] != 2 {
+					t.Errorf("%d spans of trace %d, which has an error, kept, want 2", kept[traceID(i)], i)
+				}
+			}
+			for id, spans := range kept {
+				if spans != 2 {
+					t.Errorf("%d spans of trace %v kept, want its 2 spans", spans, id)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorSamplingConsistentAcrossBatches(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	c := newConnector(&Config{SamplingPercentage: 50}, sink)
+	for range 2 {
+		if err := c.ConsumeTraces(context.Background(), newTraces(100)); err != nil {
+			t.Fatalf("ConsumeTraces() = %v", err)
+		}
+	}
+	for id, spans := range keptTraces(sink) {
+		if spans != 4 {
+			t.Errorf("%d spans of trace %v kept over two batches, want 4", spans, id)
+		}
+	}
+}
+
+func TestErrorSamplingDropsEmptyBatches(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	c := newConnector(&Config{SamplingPercentage: 0}, sink)
+	if err := c.ConsumeTraces(context.Background(), newTraces(10)); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+	if got := len(sink.AllTraces()); got != 0 {
+		t.Errorf("%d batches forwarded, want none", got)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{name: "default", cfg: *createDefaultConfig()},
+		{name: "zero", cfg: Config{SamplingPercentage: 0}},
+		{name: "hundred", cfg: Config{SamplingPercentage: 100}},
+		{name: "negative", cfg: Config{SamplingPercentage: -1}, wantErr: "sampling_percentage must be between 0 and 100"},
+		{name: "over a hundred", cfg: Config{SamplingPercentage: 101}, wantErr: "sampling_percentage must be between 0 and 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
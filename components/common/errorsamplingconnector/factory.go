@@ -0,0 +1,27 @@
+// Package errorsamplingconnector forwards every trace that contains an error
+// and a sample of the others. Running it as a connector lets the sampled
+// traces be routed to different pipelines than the unsampled ones.
+package errorsamplingconnector
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+var componentType = component.MustNewType("errorsampling")
+
+// NewFactory creates a factory for the error sampling connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		componentType,
+		func() component.Config { return createDefaultConfig() },
+		connector.WithTracesToTraces(createTracesToTraces, component.StabilityLevelDevelopment),
+	)
+}
+
+func createTracesToTraces(_ context.Context, _ connector.Settings, cfg component.Config, next consumer.Traces) (connector.Traces, error) {
+	return newConnector(cfg.(*Config), next), nil
+}
@@ -0,0 +1,144 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type exporterFactory struct {
+	exporter.Factory
+	secondary    exporter.Factory
+	secondaryCfg component.Config
+}
+
+func (f exporterFactory) CreateTraces(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+	if err := disableQueue(set, cfg); err != nil {
+		return nil, err
+	}
+	exp, err := f.Factory.CreateTraces(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.CreateTraces(ctx, secondarySettings(set, f.secondary.Type()), f.secondaryCfg)
+	if err != nil {
+		return nil, err
+	}
+	return tracesExporter{Traces: exp, secondary: secondary}, nil
+}
+
+func (f exporterFactory) CreateMetrics(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+	if err := disableQueue(set, cfg); err != nil {
+		return nil, err
+	}
+	exp, err := f.Factory.CreateMetrics(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.CreateMetrics(ctx, secondarySettings(set, f.secondary.Type()), f.secondaryCfg)
+	if err != nil {
+		return nil, err
+	}
+	return metricsExporter{Metrics: exp, secondary: secondary}, nil
+}
+
+func (f exporterFactory) CreateLogs(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+	if err := disableQueue(set, cfg); err != nil {
+		return nil, err
+	}
+	exp, err := f.Factory.CreateLogs(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.CreateLogs(ctx, secondarySettings(set, f.secondary.Type()), f.secondaryCfg)
+	if err != nil {
+		return nil, err
+	}
+	return logsExporter{Logs: exp, secondary: secondary}, nil
+}
+
+// disableQueue turns off the sending queue of the primary exporter: a queued
+// export returns before it is sent, so its failure would never reach the
+// fallback.
+func disableQueue(set exporter.Settings, cfg component.Config) error {
+	if err := defaults.DisableSendingQueue(cfg); err != nil {
+		return fmt.Errorf("failed to disable the sending queue of %s for the %s fallback: %w", set.ID, s3Type, err)
+	}
+	return nil
+}
+
+type tracesExporter struct {
+	exporter.Traces
+	secondary exporter.Traces
+}
+
+func (e tracesExporter) Start(ctx context.Context, host component.Host) error {
+	if err := e.secondary.Start(ctx, host); err != nil {
+		return err
+	}
+	return e.Traces.Start(ctx, host)
+}
+
+func (e tracesExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.Traces.Shutdown(ctx), e.secondary.Shutdown(ctx))
+}
+
+func (e tracesExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return consume(
+		func() error { return e.Traces.ConsumeTraces(ctx, td) },
+		func() error { return e.secondary.ConsumeTraces(ctx, td) },
+	)
+}
+
+type metricsExporter struct {
+	exporter.Metrics
+	secondary exporter.Metrics
+}
+
+func (e metricsExporter) Start(ctx context.Context, host component.Host) error {
+	if err := e.secondary.Start(ctx, host); err != nil {
+		return err
+	}
+	return e.Metrics.Start(ctx, host)
+}
+
+func (e metricsExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.Metrics.Shutdown(ctx), e.secondary.Shutdown(ctx))
+}
+
+func (e metricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return consume(
+		func() error { return e.Metrics.ConsumeMetrics(ctx, md) },
+		func() error { return e.secondary.ConsumeMetrics(ctx, md) },
+	)
+}
+
+type logsExporter struct {
+	exporter.Logs
+	secondary exporter.Logs
+}
+
+func (e logsExporter) Start(ctx context.Context, host component.Host) error {
+	if err := e.secondary.Start(ctx, host); err != nil {
+		return err
+	}
+	return e.Logs.Start(ctx, host)
+}
+
+func (e logsExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.Logs.Shutdown(ctx), e.secondary.Shutdown(ctx))
+}
+
+func (e logsExporter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return consume(
+		func() error { return e.Logs.ConsumeLogs(ctx, ld) },
+		func() error { return e.secondary.ConsumeLogs(ctx, ld) },
+	)
+}
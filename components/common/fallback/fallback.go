@@ -0,0 +1,95 @@
+// Package fallback spills data to S3 when the exporter it was meant for fails
+// to send it, instead of dropping it. The S3 copy is written by the awss3
+// exporter, so objects land under the extension's partition like every other
+// upload of the layer.
+package fallback
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol"
+)
+
+const (
+	// EnvVar names the variable listing the exporters to back with S3, as comma
+	// separated component types (e.g. "otlphttp"), or "*" for all but awss3.
+	EnvVar = "OCELOT_S3_FALLBACK"
+	// BucketEnvVar names the variable holding the bucket to spill to.
+	BucketEnvVar = "OCELOT_S3_FALLBACK_BUCKET"
+)
+
+var s3Type = component.MustNewType("awss3")
+
+// Wrap returns factories with the exporter factories listed in EnvVar wrapped
+// by Exporter, spilling to the bucket in BucketEnvVar through the awss3
+// exporter. factories is returned unchanged when EnvVar is unset, and an error
+// when the awss3 exporter isn't compiled in or the bucket isn't set.
+func Wrap(factories otelcol.Factories) (otelcol.Factories, error) {
+	list := strings.TrimSpace(os.Getenv(EnvVar))
+	if list == "" {
+		return factories, nil
+	}
+	s3, ok := factories.Exporters[s3Type]
+	if !ok {
+		return factories, fmt.Errorf("%s requires the awss3 exporter, build the layer with the lambdacomponents.exporter.awss3 tag", EnvVar)
+	}
+	bucket := os.Getenv(BucketEnvVar)
+	if bucket == "" {
+		return factories, fmt.Errorf("%s requires %s to name the bucket to spill to", EnvVar, BucketEnvVar)
+	}
+	s3Cfg := s3.CreateDefaultConfig()
+	if err := confmap.NewFromStringMap(map[string]any{
+		"s3uploader": map[string]any{"s3_bucket": bucket},
+	}).Unmarshal(s3Cfg); err != nil {
+		return factories, fmt.Errorf("failed to configure the %s fallback: %w", s3Type, err)
+	}
+
+	wanted := make(map[string]bool)
+	for _, typ := range strings.Split(list, ",") {
+		wanted[strings.TrimSpace(typ)] = true
+	}
+	exporters := make(map[component.Type]exporter.Factory, len(factories.Exporters))
+	for typ, f := range factories.Exporters {
+		if typ != s3Type && (wanted["*"] || wanted[typ.String()]) {
+			f = Exporter(f, s3, s3Cfg)
+		}
+		exporters[typ] = f
+	}
+	factories.Exporters = exporters
+	return factories, nil
+}
+
+// Exporter returns primary with every exporter it creates backed by one created
+// by secondary from secondaryCfg. Data is written to the secondary exporter
+// only when the primary fails to export it. The sending queue of the primary
+// is turned off, so its exports fail, after their retries, in the call that
+// hands them the data rather than later in the queue.
+func Exporter(primary, secondary exporter.Factory, secondaryCfg component.Config) exporter.Factory {
+	return exporterFactory{Factory: primary, secondary: secondary, secondaryCfg: secondaryCfg}
+}
+
+// secondarySettings derives the settings of the secondary exporter from those
+// of the primary, so its logs and telemetry name the exporter it backs.
+func secondarySettings(set exporter.Settings, typ component.Type) exporter.Settings {
+	set.ID = component.NewIDWithName(typ, "fallback_"+strings.ReplaceAll(set.ID.String(), "/", "_"))
+	return set
+}
+
+// consume sends data with the primary and, if that fails, with the secondary.
+// The export only fails if both do.
+func consume(primary, secondary func() error) error {
+	err := primary()
+	if err == nil {
+		return nil
+	}
+	if serr := secondary(); serr != nil {
+		return errors.Join(err, fmt.Errorf("fallback export failed: %w", serr))
+	}
+	return nil
+}
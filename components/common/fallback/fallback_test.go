@@ -0,0 +1,243 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// s3Config mirrors the part of the awss3 exporter configuration Wrap sets.
+type s3Config struct {
+	S3Uploader struct {
+		S3Bucket string `mapstructure:"s3_bucket"`
+	} `mapstructure:"s3uploader"`
+}
+
+type fakeTraces struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
+
+// newFactory returns a traces exporter factory whose exporters hand the data
+// to next, recording the settings and config of the last one created.
+func newFactory(typ component.Type, next consumer.Traces, created *exporter.Settings, createdCfg *component.Config) exporter.Factory {
+	return exporter.NewFactory(typ, func() component.Config { return &s3Config{} },
+		exporter.WithTraces(func(_ context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			if created != nil {
+				*created, *createdCfg = set, cfg
+			}
+			return fakeTraces{Traces: next}, nil
+		}, component.StabilityLevelDevelopment))
+}
+
+func settings() exporter.Settings {
+	return exporter.Settings{
+		ID: component.MustNewIDWithName("otlphttp", "backend"),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+}
+
+func TestExporter(t *testing.T) {
+	errPrimary := errors.New("primary failed")
+	errSecondary := errors.New("secondary failed")
+	tests := []struct {
+		name          string
+		primaryErr    error
+		secondaryErr  error
+		wantSecondary int
+		wantErr       string
+	}{
+		{name: "primary succeeds"},
+		{name: "primary fails", primaryErr: errPrimary, wantSecondary: 1},
+		{
+			name:          "both fail",
+			primaryErr:    errPrimary,
+			secondaryErr:  errSecondary,
+			wantSecondary: 1,
+			wantErr:       "primary failed\nfallback export failed: secondary failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := consumertest.NewNop()
+			if tt.primaryErr != nil {
+				primary = consumertest.NewErr(tt.primaryErr)
+			}
+			secondarySink := new(consumertest.TracesSink)
+			var secondary consumer.Traces = secondarySink
+			if tt.secondaryErr != nil {
+				secondary = consumertest.NewErr(tt.secondaryErr)
+			}
+			var created exporter.Settings
+			var createdCfg component.Config
+			secondaryCfg := &s3Config{}
+			f := Exporter(
+				newFactory(component.MustNewType("otlphttp"), primary, nil, nil),
+				newFactory(s3Type, secondary, &created, &createdCfg),
+				secondaryCfg)
+			exp, err := f.CreateTraces(context.Background(), settings(), f.CreateDefaultConfig())
+			if err != nil {
+				t.Fatalf("CreateTraces() = %v", err)
+			}
+			if want := "awss3/fallback_otlphttp_backend"; created.ID.String() != want {
+				t.Errorf("secondary exporter ID = %s, want %s", created.ID, want)
+			}
+			if createdCfg != component.Config(secondaryCfg) {
+				t.Errorf("secondary exporter config = %v, want the one given to Exporter", createdCfg)
+			}
+			if err := exp.Start(context.Background(), nil); err != nil {
+				t.Fatalf("Start() = %v", err)
+			}
+
+			err = exp.ConsumeTraces(context.Background(), ptrace.NewTraces())
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ConsumeTraces() = %v", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ConsumeTraces() = %v, want %q", err, tt.wantErr)
+			}
+			if tt.secondaryErr == nil && len(secondarySink.AllTraces()) != tt.wantSecondary {
+				t.Errorf("secondary exporter received %d batches, want %d", len(secondarySink.AllTraces()), tt.wantSecondary)
+			}
+			if err := exp.Shutdown(context.Background()); err != nil {
+				t.Errorf("Shutdown() = %v", err)
+			}
+		})
+	}
+}
+
+// queuedConfig is the configuration of an exporter with a sending queue, like
+// otlp and otlphttp.
+type queuedConfig struct {
+	QueueConfig exporterhelper.QueueConfig `mapstructure:"sending_queue"`
+}
+
+func TestExporterQueued(t *testing.T) {
+	// The primary queues the data it is handed, with the upstream default
+	// queue, and fails to send it.
+	primary := exporter.NewFactory(component.MustNewType("otlphttp"),
+		func() component.Config { return &queuedConfig{QueueConfig: exporterhelper.NewDefaultQueueConfig()} },
+		exporter.WithTraces(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			return exporterhelper.NewTraces(ctx, set, cfg, func(context.Context, ptrace.Traces) error {
+				return errors.New("backend unreachable")
+			}, exporterhelper.WithQueue(cfg.(*queuedConfig).QueueConfig))
+		}, component.StabilityLevelDevelopment))
+	sink := new(consumertest.TracesSink)
+	f := Exporter(primary, newFactory(s3Type, sink, nil, nil), &s3Config{})
+
+	cfg := f.CreateDefaultConfig().(*queuedConfig)
+	exp, err := f.CreateTraces(context.Background(), settings(), cfg)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if cfg.QueueConfig.Enabled {
+		t.Error("the sending queue of the primary exporter is enabled")
+	}
+	if err := exp.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("checkout")
+	if err := exp.ConsumeTraces(context.Background(), td); err != nil {
+		t.Errorf("ConsumeTraces() = %v", err)
+	}
+	if err := exp.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() = %v", err)
+	}
+	if got := sink.SpanCount(); got != 1 {
+		t.Errorf("the S3 exporter received %d spans, want 1", got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	otlphttp := component.MustNewType("otlphttp")
+	kafka := component.MustNewType("kafka")
+	tests := []struct {
+		name        string
+		list        string
+		bucket      string
+		withoutS3   bool
+		wantWrapped []component.Type
+		wantErr     string
+	}{
+		{name: "unset"},
+		{name: "listed", list: "otlphttp, other", bucket: "spill", wantWrapped: []component.Type{otlphttp}},
+		{name: "all but awss3", list: "*", bucket: "spill", wantWrapped: []component.Type{otlphttp, kafka}},
+		{
+			name:      "no awss3 exporter",
+			list:      "*",
+			bucket:    "spill",
+			withoutS3: true,
+			wantErr:   "OCELOT_S3_FALLBACK requires the awss3 exporter, build the layer with the lambdacomponents.exporter.awss3 tag",
+		},
+		{
+			name:    "no bucket",
+			list:    "*",
+			wantErr: "OCELOT_S3_FALLBACK requires OCELOT_S3_FALLBACK_BUCKET to name the bucket to spill to",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvVar, tt.list)
+			t.Setenv(BucketEnvVar, tt.bucket)
+			exporters := map[component.Type]exporter.Factory{
+				otlphttp: newFactory(otlphttp, consumertest.NewNop(), nil, nil),
+				kafka:    newFactory(kafka, consumertest.NewNop(), nil, nil),
+			}
+			if !tt.withoutS3 {
+				exporters[s3Type] = newFactory(s3Type, consumertest.NewNop(), nil, nil)
+			}
+			got, err := Wrap(otelcol.Factories{Exporters: exporters})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Wrap() = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Wrap() = %v", err)
+			}
+			wrapped := 0
+			for typ, f := range got.Exporters {
+				ef, ok := f.(exporterFactory)
+				if !ok {
+					continue
+				}
+				wrapped++
+				if typ == s3Type {
+					t.Error("the awss3 exporter backs itself")
+				}
+				if bucket := ef.secondaryCfg.(*s3Config).S3Uploader.S3Bucket; bucket != tt.bucket {
+					t.Errorf("%s spills to bucket %q, want %q", typ, bucket, tt.bucket)
+				}
+			}
+			if wrapped != len(tt.wantWrapped) {
+				t.Errorf("%d exporters wrapped, want %v", wrapped, tt.wantWrapped)
+			}
+			for _, typ := range tt.wantWrapped {
+				if _, ok := got.Exporters[typ].(exporterFactory); !ok {
+					t.Errorf("%s isn't wrapped", typ)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Package invocation tracks the Lambda invocations of the execution
+// environment, so components can tell which invocation the telemetry they
+// handle belongs to and whether it is the environment's first. The collector's
+// lifecycle manager calls Begin, through assembly.Invoke, for every INVOKE
+// event it receives.
+package invocation
+
+import (
+	"sync"
+	"time"
+)
+
+// processStart approximates when the execution environment started: the
+// extension process is started during its initialization.
+var processStart = time.Now()
+
+// Invocation describes an invocation of the function.
+type Invocation struct {
+	// RequestID is the AWS request ID of the invocation.
+	RequestID string
+	// ColdStart is true for the first invocation of the environment.
+	ColdStart bool
+	// Start is when the INVOKE event was received.
+	Start time.Time
+}
+
+var (
+	mu      sync.RWMutex
+	current Invocation
+	first   Invocation
+	started bool
+)
+
+// Begin records the start of the invocation with the given request ID.
+func Begin(requestID string) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = Invocation{RequestID: requestID, ColdStart: !started, Start: time.Now()}
+	if !started {
+		first = current
+	}
+	started = true
+}
+
+// Current returns the invocation in progress, or the last one between
+// invocations, and false before the first invocation.
+func Current() (Invocation, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current, started
+}
+
+// First returns the first invocation of the environment, and false before it.
+func First() (Invocation, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	return first, started
+}
+
+// ProcessStart returns when the extension process started.
+func ProcessStart() time.Time {
+	return processStart
+}
@@ -0,0 +1,52 @@
+package invocation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInvocations(t *testing.T) {
+	// The steps share the process-wide invocation state, so they run in order.
+	steps := []struct {
+		name          string
+		begin         string
+		wantCurrent   string
+		wantColdStart bool
+		wantFirst     string
+		wantStarted   bool
+	}{
+		{name: "before the first invocation"},
+		{name: "first invocation", begin: "request-1", wantCurrent: "request-1", wantColdStart: true, wantFirst: "request-1", wantStarted: true},
+		{name: "warm invocation", begin: "request-2", wantCurrent: "request-2", wantFirst: "request-1", wantStarted: true},
+		{name: "between invocations", wantCurrent: "request-2", wantFirst: "request-1", wantStarted: true},
+		{name: "same request ID", begin: "request-2", wantCurrent: "request-2", wantFirst: "request-1", wantStarted: true},
+	}
+	for _, step := range steps {
+		before := time.Now()
+		if step.begin != "" {
+			Begin(step.begin)
+		}
+		current, ok := Current()
+		if ok != step.wantStarted {
+			t.Fatalf("%s: Current() started = %t, want %t", step.name, ok, step.wantStarted)
+		}
+		if current.RequestID != step.wantCurrent || current.ColdStart != step.wantColdStart {
+			t.Errorf("%s: Current() = %s, cold start %t, want %s, cold start %t",
+				step.name, current.RequestID, current.ColdStart, step.wantCurrent, step.wantColdStart)
+		}
+		if step.begin != "" && current.Start.Before(before) {
+			t.Errorf("%s: Current().Start = %v, want the time Begin was called", step.name, current.Start)
+		}
+		first, ok := First()
+		if ok != step.wantStarted {
+			t.Fatalf("%s: First() started = %t, want %t", step.name, ok, step.wantStarted)
+		}
+		if first.RequestID != step.wantFirst || first.ColdStart != step.wantStarted {
+			t.Errorf("%s: First() = %s, cold start %t, want %s, cold start %t",
+				step.name, first.RequestID, first.ColdStart, step.wantFirst, step.wantStarted)
+		}
+	}
+	if first, _ := First(); ProcessStart().After(first.Start) {
+		t.Errorf("ProcessStart() = %v, want it before the first invocation, %v", ProcessStart(), first.Start)
+	}
+}
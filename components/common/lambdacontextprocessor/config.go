@@ -0,0 +1,11 @@
+package lambdacontextprocessor
+
+// Config defines the configuration for the Lambda context processor.
+type Config struct {
+	// Override replaces the attributes when a span already has them.
+	Override bool `mapstructure:"override"`
+}
+
+func createDefaultConfig() *Config {
+	return &Config{}
+}
@@ -0,0 +1,30 @@
+// Package lambdacontextprocessor stamps spans with the request ID of the
+// current Lambda invocation, as faas.invocation_id, and whether it is the
+// execution environment's first, as faas.coldstart.
+package lambdacontextprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+var componentType = component.MustNewType("lambdacontext")
+
+// NewFactory creates a factory for the Lambda context processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		componentType,
+		func() component.Config { return createDefaultConfig() },
+		processor.WithTraces(createTraces, component.StabilityLevelDevelopment),
+	)
+}
+
+func createTraces(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+	p := &lambdaContext{override: cfg.(*Config).Override}
+	return processorhelper.NewTraces(ctx, set, cfg, next, p.processTraces,
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
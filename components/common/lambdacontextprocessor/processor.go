@@ -0,0 +1,45 @@
+package lambdacontextprocessor
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/invocation"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	invocationIDAttribute = "faas.invocation_id"
+	coldStartAttribute    = "faas.coldstart"
+)
+
+// lambdaContext stamps spans with the invocation in progress when they are
+// processed. Spans held back by a batch until the next invocation get that
+// invocation instead, so the processor belongs before any batch processor in
+// the pipeline.
+type lambdaContext struct {
+	override bool
+}
+
+func (p *lambdaContext) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	inv, ok := invocation.Current()
+	if !ok {
+		return td, nil
+	}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				attrs := spans.At(k).Attributes()
+				if _, exists := attrs.Get(invocationIDAttribute); p.override || !exists {
+					attrs.PutStr(invocationIDAttribute, inv.RequestID)
+				}
+				if _, exists := attrs.Get(coldStartAttribute); p.override || !exists {
+					attrs.PutBool(coldStartAttribute, inv.ColdStart)
+				}
+			}
+		}
+	}
+	return td, nil
+}
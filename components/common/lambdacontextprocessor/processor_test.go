@@ -0,0 +1,116 @@
+package lambdacontextprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/invocation"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+func settings() processor.Settings {
+	return processor.Settings{
+		ID: component.NewID(componentType),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+}
+
+// newTraces returns one span, holding the given attributes.
+func newTraces(attrs map[string]any) ptrace.Traces {
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	if err := span.Attributes().FromRaw(attrs); err != nil {
+		panic(err)
+	}
+	return td
+}
+
+func TestLambdaContext(t *testing.T) {
+	ctx := context.Background()
+	f := NewFactory()
+	sink := new(consumertest.TracesSink)
+	stamp, err := f.CreateTraces(ctx, settings(), f.CreateDefaultConfig(), sink)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	overrideSink := new(consumertest.TracesSink)
+	override, err := f.CreateTraces(ctx, settings(), &Config{Override: true}, overrideSink)
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+
+	existing := map[string]any{invocationIDAttribute: "upstream", coldStartAttribute: false}
+	// The steps share the process-wide invocation state, so they run in order.
+	steps := []struct {
+		name     string
+		invoke   string
+		override bool
+		attrs    map[string]any
+		want     map[string]any
+	}{
+		{
+			name: "before the first invocation",
+			want: map[string]any{},
+		},
+		{
+			name:   "first invocation",
+			invoke: "request-1",
+			want:   map[string]any{invocationIDAttribute: "request-1", coldStartAttribute: true},
+		},
+		{
+			name:  "existing attributes",
+			attrs: existing,
+			want:  existing,
+		},
+		{
+			name:     "existing attributes overridden",
+			override: true,
+			attrs:    existing,
+			want:     map[string]any{invocationIDAttribute: "request-1", coldStartAttribute: true},
+		},
+		{
+			name:   "warm invocation",
+			invoke: "request-2",
+			attrs:  map[string]any{"http.route": "/orders"},
+			want:   map[string]any{"http.route": "/orders", invocationIDAttribute: "request-2", coldStartAttribute: false},
+		},
+	}
+	for _, step := range steps {
+		if step.invoke != "" {
+			invocation.Begin(step.invoke)
+		}
+		p, s := stamp, sink
+		if step.override {
+			p, s = override, overrideSink
+		}
+		s.Reset()
+		if err := p.ConsumeTraces(ctx, newTraces(step.attrs)); err != nil {
+			t.Fatalf("%s: ConsumeTraces() = %v", step.name, err)
+		}
+		if len(s.AllTraces()) != 1 {
+			t.Fatalf("%s: %d batches passed on, want 1", step.name, len(s.AllTraces()))
+		}
+		got := s.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw()
+		if len(got) != len(step.want) {
+			t.Errorf("%s: attributes = %v, want %v", step.name, got, step.want)
+			continue
+		}
+		for k, v := range step.want {
+			if got[k] != v {
+				t.Errorf("%s: attributes = %v, want %v", step.name, got, step.want)
+				break
+			}
+		}
+	}
+}
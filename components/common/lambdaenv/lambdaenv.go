@@ -0,0 +1,75 @@
+// Package lambdaenv reads the variables the Lambda runtime sets in the
+// execution environment. Components use it to derive their defaults.
+package lambdaenv
+
+import (
+	"os"
+	"strconv"
+)
+
+// MemorySizeMiB returns the memory configured for the function, as reported by
+// AWS_LAMBDA_FUNCTION_MEMORY_SIZE, and whether the variable held a valid value.
+func MemorySizeMiB() (uint32, bool) {
+	v, err := strconv.ParseUint(os.Getenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE"), 10, 32)
+	if err != nil || v == 0 {
+		return 0, false
+	}
+	return uint32(v), true
+}
+
+// FunctionName returns the name of the function, from AWS_LAMBDA_FUNCTION_NAME.
+func FunctionName() string {
+	return os.Getenv("AWS_LAMBDA_FUNCTION_NAME")
+}
+
+// FunctionVersion returns the version being executed, from
+// AWS_LAMBDA_FUNCTION_VERSION.
+func FunctionVersion() string {
+	return os.Getenv("AWS_LAMBDA_FUNCTION_VERSION")
+}
+
+// Region returns the region the function runs in, from AWS_REGION.
+func Region() string {
+	return os.Getenv("AWS_REGION")
+}
+
+// Enabled reports whether the named variable is set to a true value, as
+// understood by strconv.ParseBool.
+func Enabled(key string) bool {
+	enabled, err := strconv.ParseBool(os.Getenv(key))
+	return err == nil && enabled
+}
+
+// LogGroupName returns the CloudWatch Logs group of the function, from
+// AWS_LAMBDA_LOG_GROUP_NAME.
+func LogGroupName() string {
+	return os.Getenv("AWS_LAMBDA_LOG_GROUP_NAME")
+}
+
+// LogStreamName returns the CloudWatch Logs stream of the execution
+// environment, from AWS_LAMBDA_LOG_STREAM_NAME.
+func LogStreamName() string {
+	return os.Getenv("AWS_LAMBDA_LOG_STREAM_NAME")
+}
+
+// ResourceAttribute is a resource attribute derived from the environment.
+type ResourceAttribute struct {
+	Key   string
+	Value string
+}
+
+// ResourceAttributes returns the faas and cloud resource attributes that the
+// execution environment exposes, leaving out those whose variable is unset.
+func ResourceAttributes() []ResourceAttribute {
+	var attrs []ResourceAttribute
+	for _, attr := range []ResourceAttribute{
+		{"faas.name", FunctionName()},
+		{"faas.version", FunctionVersion()},
+		{"cloud.region", Region()},
+	} {
+		if attr.Value != "" {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,86 @@
+package lambdaenv
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMemorySizeMiB(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   uint32
+		wantOK bool
+	}{
+		{name: "unset"},
+		{name: "size", value: "1024", want: 1024, wantOK: true},
+		{name: "zero", value: "0"},
+		{name: "negative", value: "-128"},
+		{name: "not a number", value: "1GB"},
+		{name: "too large", value: "4294967296"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", tt.value)
+			got, ok := MemorySizeMiB()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("MemorySizeMiB() = %d, %t, want %d, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: ""},
+		{value: "true", want: true},
+		{value: "1", want: true},
+		{value: "TRUE", want: true},
+		{value: "false"},
+		{value: "yes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("OCELOT_TEST_ENABLED", tt.value)
+			if got := Enabled("OCELOT_TEST_ENABLED"); got != tt.want {
+				t.Errorf("Enabled() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceAttributes(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want []ResourceAttribute
+	}{
+		{
+			name: "unset",
+			env:  map[string]string{"AWS_LAMBDA_FUNCTION_NAME": "", "AWS_LAMBDA_FUNCTION_VERSION": "", "AWS_REGION": ""},
+		},
+		{
+			name: "all",
+			env:  map[string]string{"AWS_LAMBDA_FUNCTION_NAME": "checkout", "AWS_LAMBDA_FUNCTION_VERSION": "$LATEST", "AWS_REGION": "eu-west-1"},
+			want: []ResourceAttribute{{"faas.name", "checkout"}, {"faas.version", "$LATEST"}, {"cloud.region", "eu-west-1"}},
+		},
+		{
+			name: "some",
+			env:  map[string]string{"AWS_LAMBDA_FUNCTION_NAME": "checkout", "AWS_LAMBDA_FUNCTION_VERSION": "", "AWS_REGION": "eu-west-1"},
+			want: []ResourceAttribute{{"faas.name", "checkout"}, {"cloud.region", "eu-west-1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if got := ResourceAttributes(); !slices.Equal(got, tt.want) {
+				t.Errorf("ResourceAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package logtometricsconnector
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Metric types a log attribute can be extracted into.
+const (
+	TypeGauge = "gauge"
+	TypeSum   = "sum"
+)
+
+// Config defines the configuration for the log to metrics connector.
+type Config struct {
+	// Metrics lists the metrics extracted from the log records.
+	Metrics []MetricConfig `mapstructure:"metrics"`
+}
+
+// MetricConfig describes a metric taking its values from a log attribute.
+type MetricConfig struct {
+	Name        string `mapstructure:"name"`
+	Description string `mapstructure:"description"`
+	Unit        string `mapstructure:"unit"`
+	// Attribute is the log record attribute holding the value. Records
+	// without it, or with a value that isn't a number, are skipped.
+	Attribute string `mapstructure:"attribute"`
+	// Type is "gauge", for a data point per log record, or "sum", for a delta
+	// sum of the values of each batch per resource.
+	Type string `mapstructure:"type"`
+}
+
+// Validate checks the connector configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Metrics) == 0 {
+		return errors.New("metrics must list at least one metric")
+	}
+	var errs []error
+	for i, m := range cfg.Metrics {
+		if m.Name == "" {
+			errs = append(errs, fmt.Errorf("metrics[%d]: name must be set", i))
+		}
+		if m.Attribute == "" {
+			errs = append(errs, fmt.Errorf("metrics[%d]: attribute must be set", i))
+		}
+		if m.Type != TypeGauge && m.Type != TypeSum {
+			errs = append(errs, fmt.Errorf("metrics[%d]: type must be %s or %s, got %q", i, TypeGauge, TypeSum, m.Type))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func createDefaultConfig() *Config {
+	return &Config{}
+}
@@ -0,0 +1,138 @@
+package logtometricsconnector
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// logToMetrics keeps no state between batches, so it has nothing to flush
+// when the environment is frozen or shut down.
+type logToMetrics struct {
+	component.StartFunc
+	component.ShutdownFunc
+	metrics []MetricConfig
+	next    consumer.Metrics
+}
+
+func (c *logToMetrics) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *logToMetrics) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	md := pmetric.NewMetrics()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		var sms pmetric.ScopeMetrics
+		created := false
+		for _, m := range c.metrics {
+			metric, ok := c.extract(m, rl)
+			if !ok {
+				continue
+			}
+			if !created {
+				rm := md.ResourceMetrics().AppendEmpty()
+				rl.Resource().CopyTo(rm.Resource())
+				rm.SetSchemaUrl(rl.SchemaUrl())
+				sms = rm.ScopeMetrics().AppendEmpty()
+				sms.Scope().SetName(componentType.String())
+				created = true
+			}
+			metric.MoveTo(sms.Metrics().AppendEmpty())
+		}
+	}
+	if md.DataPointCount() == 0 {
+		return nil
+	}
+	return c.next.ConsumeMetrics(ctx, md)
+}
+
+// extract builds the metric m from the log records of rl, and reports whether
+// any record had a value for it.
+func (c *logToMetrics) extract(m MetricConfig, rl plog.ResourceLogs) (pmetric.Metric, bool) {
+	metric := pmetric.NewMetric()
+	metric.SetName(m.Name)
+	metric.SetDescription(m.Description)
+	metric.SetUnit(m.Unit)
+
+	var gauge pmetric.NumberDataPointSlice
+	var sum pmetric.NumberDataPoint
+	var start, end pcommon.Timestamp
+	found := false
+	if m.Type == TypeSum {
+		s := metric.SetEmptySum()
+		s.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		sum = s.DataPoints().AppendEmpty()
+	} else {
+		gauge = metric.SetEmptyGauge().DataPoints()
+	}
+
+	sls := rl.ScopeLogs()
+	for j := 0; j < sls.Len(); j++ {
+		records := sls.At(j).LogRecords()
+		for k := 0; k < records.Len(); k++ {
+			record := records.At(k)
+			v, ok := record.Attributes().Get(m.Attribute)
+			if !ok {
+				continue
+			}
+			value, ok := number(v)
+			if !ok {
+				continue
+			}
+			ts := timestamp(record)
+			if m.Type == TypeSum {
+				sum.SetDoubleValue(sum.DoubleValue() + value)
+				if !found || ts < start {
+					start = ts
+				}
+				end = max(end, ts)
+			} else {
+				dp := gauge.AppendEmpty()
+				dp.SetDoubleValue(value)
+				dp.SetTimestamp(ts)
+			}
+			found = true
+		}
+	}
+	if m.Type == TypeSum {
+		sum.SetStartTimestamp(start)
+		sum.SetTimestamp(end)
+	}
+	return metric, found
+}
+
+// number returns the value of a numeric attribute, or of a string attribute
+// holding a number.
+func number(v pcommon.Value) (float64, bool) {
+	switch v.Type() {
+	case pcommon.ValueTypeInt:
+		return float64(v.Int()), true
+	case pcommon.ValueTypeDouble:
+		return v.Double(), true
+	case pcommon.ValueTypeStr:
+		f, err := strconv.ParseFloat(v.Str(), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// timestamp returns when the event of record occurred, falling back to when it
+// was observed and to the current time.
+func timestamp(record plog.LogRecord) pcommon.Timestamp {
+	if ts := record.Timestamp(); ts != 0 {
+		return ts
+	}
+	if ts := record.ObservedTimestamp(); ts != 0 {
+		return ts
+	}
+	return pcommon.NewTimestampFromTime(time.Now())
+}
@@ -0,0 +1,226 @@
+package logtometricsconnector
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+func settings() connector.Settings {
+	return connector.Settings{
+		ID: component.NewID(componentType),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+}
+
+// newLogs returns one resource per service, holding a log record per value
+// with latency_ms set to it, timestamped 1, 2, 3... A nil value leaves the
+// attribute out.
+func newLogs(services map[string][]any) plog.Logs {
+	ld := plog.NewLogs()
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", name)
+		records := rl.ScopeLogs().AppendEmpty().LogRecords()
+		for i, v := range services[name] {
+			record := records.AppendEmpty()
+			record.SetTimestamp(pcommon.Timestamp(i + 1))
+			if v != nil {
+				_ = record.Attributes().PutEmpty("latency_ms").FromRaw(v)
+			}
+		}
+	}
+	return ld
+}
+
+// point is a data point of the extracted metric.
+type point struct {
+	service    string
+	value      float64
+	start, end pcommon.Timestamp
+}
+
+func points(t *testing.T, sink *consumertest.MetricsSink, typ string) []point {
+	t.Helper()
+	var got []point
+	for _, md := range sink.AllMetrics() {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			rm := rms.At(i)
+			service, _ := rm.Resource().Attributes().Get("service.name")
+			sm := rm.ScopeMetrics().At(0)
+			if sm.Scope().Name() != componentType.String() {
+				t.Errorf("scope = %q, want %q", sm.Scope().Name(), componentType)
+			}
+			m := sm.Metrics().At(0)
+			if m.Name() != "latency" || m.Unit() != "ms" {
+				t.Errorf("metric = %s (%s), want latency (ms)", m.Name(), m.Unit())
+			}
+			var dps pmetric.NumberDataPointSlice
+			switch typ {
+			case TypeGauge:
+				dps = m.Gauge().DataPoints()
+			case TypeSum:
+				if m.Sum().AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+					t.Errorf("temporality = %v, want delta", m.Sum().AggregationTemporality())
+				}
+				dps = m.Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dp := dps.At(j)
+				got = append(got, point{service: service.Str(), value: dp.DoubleValue(), start: dp.StartTimestamp(), end: dp.Timestamp()})
+			}
+		}
+	}
+	return got
+}
+
+func TestLogToMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      string
+		services map[string][]any
+		want     []point
+	}{
+		{
+			name:     "gauge",
+			typ:      TypeGauge,
+			services: map[string][]any{"checkout": {int64(10), 2.5, "7", "slow", nil}},
+			want: []point{
+				{service: "checkout", value: 10, end: 1},
+				{service: "checkout", value: 2.5, end: 2},
+				{service: "checkout", value: 7, end: 3},
+			},
+		},
+		{
+			name:     "sum",
+			typ:      TypeSum,
+			services: map[string][]any{"checkout": {nil, int64(10), 2.5, "7"}, "orders": {int64(1)}},
+			want: []point{
+				{service: "checkout", value: 19.5, start: 2, end: 4},
+				{service: "orders", value: 1, start: 1, end: 1},
+			},
+		},
+		{
+			name:     "resources without values",
+			typ:      TypeSum,
+			services: map[string][]any{"checkout": {"slow", nil}, "orders": {int64(1)}},
+			want:     []point{{service: "orders", value: 1, start: 1, end: 1}},
+		},
+		{
+			name:     "no values",
+			typ:      TypeGauge,
+			services: map[string][]any{"checkout": {true, nil}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFactory()
+			cfg := &Config{Metrics: []MetricConfig{{Name: "latency", Unit: "ms", Attribute: "latency_ms", Type: tt.typ}}}
+			sink := new(consumertest.MetricsSink)
+			c, err := f.CreateLogsToMetrics(context.Background(), settings(), cfg, sink)
+			if err != nil {
+				t.Fatalf("CreateLogsToMetrics() = %v", err)
+			}
+			if err := c.ConsumeLogs(context.Background(), newLogs(tt.services)); err != nil {
+				t.Fatalf("ConsumeLogs() = %v", err)
+			}
+			if tt.want == nil && len(sink.AllMetrics()) != 0 {
+				t.Errorf("%d batches of metrics emitted, want none", len(sink.AllMetrics()))
+			}
+			if got := points(t, sink, tt.typ); !slices.Equal(got, tt.want) {
+				t.Errorf("data points = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		name      string
+		timestamp pcommon.Timestamp
+		observed  pcommon.Timestamp
+		want      pcommon.Timestamp
+	}{
+		{name: "timestamp", timestamp: 2, observed: 3, want: 2},
+		{name: "observed", observed: 3, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := plog.NewLogRecord()
+			record.SetTimestamp(tt.timestamp)
+			record.SetObservedTimestamp(tt.observed)
+			if got := timestamp(record); got != tt.want {
+				t.Errorf("timestamp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := timestamp(plog.NewLogRecord()); got == 0 {
+		t.Error("timestamp() = 0 for a record without timestamps, want the current time")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cfg:  Config{Metrics: []MetricConfig{{Name: "latency", Attribute: "latency_ms", Type: TypeGauge}}},
+		},
+		{
+			name:    "no metrics",
+			cfg:     *createDefaultConfig(),
+			wantErr: "metrics must list at least one metric",
+		},
+		{
+			name:    "no name",
+			cfg:     Config{Metrics: []MetricConfig{{Attribute: "latency_ms", Type: TypeSum}}},
+			wantErr: "metrics[0]: name must be set",
+		},
+		{
+			name:    "no attribute",
+			cfg:     Config{Metrics: []MetricConfig{{Name: "latency", Type: TypeSum}}},
+			wantErr: "metrics[0]: attribute must be set",
+		},
+		{
+			name:    "unknown type",
+			cfg:     Config{Metrics: []MetricConfig{{Name: "latency", Attribute: "latency_ms", Type: "histogram"}}},
+			wantErr: `metrics[0]: type must be gauge or sum, got "histogram"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Package logtometricsconnector extracts metrics from numeric attributes of
+// structured log records, such as a latency_ms field, as gauges or sums.
+package logtometricsconnector
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+var componentType = component.MustNewType("logtometrics")
+
+// NewFactory creates a factory for the log to metrics connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		componentType,
+		func() component.Config { return createDefaultConfig() },
+		connector.WithLogsToMetrics(createLogsToMetrics, component.StabilityLevelDevelopment),
+	)
+}
+
+func createLogsToMetrics(_ context.Context, _ connector.Settings, cfg component.Config, next consumer.Metrics) (connector.Logs, error) {
+	return &logToMetrics{metrics: cfg.(*Config).Metrics, next: next}, nil
+}
@@ -0,0 +1,80 @@
+package ottlspanmetricsconnector
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Metric types a span value can be recorded into.
+const (
+	TypeHistogram = "histogram"
+	TypeSum       = "sum"
+)
+
+// defaultBuckets are the explicit histogram bucket boundaries used when a
+// histogram doesn't configure its own, the OpenTelemetry SDK defaults.
+var defaultBuckets = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// Config defines the configuration for the OTTL span metrics connector.
+type Config struct {
+	// Metrics lists the metrics recorded from the spans.
+	Metrics []MetricConfig `mapstructure:"metrics"`
+}
+
+// MetricConfig describes a metric taking its values from an OTTL value
+// expression evaluated against each span, such as
+// `span.attributes["http.response.body.size"]` or
+// `Milliseconds(span.end_time - span.start_time)`.
+type MetricConfig struct {
+	Name        string `mapstructure:"name"`
+	Description string `mapstructure:"description"`
+	Unit        string `mapstructure:"unit"`
+	// Value is the OTTL value expression. Spans it yields no number for are
+	// skipped.
+	Value string `mapstructure:"value"`
+	// Condition is an optional OTTL condition limiting the spans recorded.
+	Condition string `mapstructure:"condition"`
+	// Type is "histogram", recording the distribution of the values of each
+	// batch per resource, or "sum", adding them up.
+	Type string `mapstructure:"type"`
+	// Buckets are the boundaries of a histogram's buckets, in increasing
+	// order. It defaults to the OpenTelemetry SDK boundaries.
+	Buckets []float64 `mapstructure:"buckets"`
+}
+
+// Validate checks the connector configuration is valid. The expressions are
+// parsed when the connector is created.
+func (cfg *Config) Validate() error {
+	if len(cfg.Metrics) == 0 {
+		return errors.New("metrics must list at least one metric")
+	}
+	var errs []error
+	for i, m := range cfg.Metrics {
+		if m.Name == "" {
+			errs = append(errs, fmt.Errorf("metrics[%d]: name must be set", i))
+		}
+		if m.Value == "" {
+			errs = append(errs, fmt.Errorf("metrics[%d]: value must be set", i))
+		}
+		switch m.Type {
+		case TypeHistogram:
+			for j := 1; j < len(m.Buckets); j++ {
+				if m.Buckets[j] <= m.Buckets[j-1] {
+					errs = append(errs, fmt.Errorf("metrics[%d]: buckets must be in increasing order", i))
+					break
+				}
+			}
+		case TypeSum:
+			if len(m.Buckets) > 0 {
+				errs = append(errs, fmt.Errorf("metrics[%d]: buckets only apply to %s metrics", i, TypeHistogram))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("metrics[%d]: type must be %s or %s, got %q", i, TypeHistogram, TypeSum, m.Type))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func createDefaultConfig() *Config {
+	return &Config{}
+}
@@ -0,0 +1,191 @@
+package ottlspanmetricsconnector
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// metric is a configured metric with its expressions parsed.
+type metric struct {
+	MetricConfig
+	value     *ottl.ValueExpression[ottlspan.TransformContext]
+	condition *ottl.Condition[ottlspan.TransformContext]
+}
+
+// spanMetrics keeps no state between batches, so it has nothing to flush when
+// the environment is frozen or shut down.
+type spanMetrics struct {
+	component.StartFunc
+	component.ShutdownFunc
+	metrics []metric
+	next    consumer.Metrics
+}
+
+func newConnector(cfg *Config, set component.TelemetrySettings, next consumer.Metrics) (*spanMetrics, error) {
+	parser, err := ottlspan.NewParser(ottlfuncs.StandardConverters[ottlspan.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	c := &spanMetrics{next: next}
+	for i, m := range cfg.Metrics {
+		parsed := metric{MetricConfig: m}
+		if parsed.value, err = parser.ParseValueExpression(m.Value); err != nil {
+			return nil, fmt.Errorf("metrics[%d]: value: %w", i, err)
+		}
+		if m.Condition != "" {
+			if parsed.condition, err = parser.ParseCondition(m.Condition); err != nil {
+				return nil, fmt.Errorf("metrics[%d]: condition: %w", i, err)
+			}
+		}
+		if m.Type == TypeHistogram && len(m.Buckets) == 0 {
+			parsed.Buckets = defaultBuckets
+		}
+		c.metrics = append(c.metrics, parsed)
+	}
+	return c, nil
+}
+
+func (c *spanMetrics) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *spanMetrics) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	md := pmetric.NewMetrics()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		var sms pmetric.ScopeMetrics
+		created := false
+		for _, m := range c.metrics {
+			recorded, ok, err := c.record(ctx, m, rs)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if !created {
+				rm := md.ResourceMetrics().AppendEmpty()
+				rs.Resource().CopyTo(rm.Resource())
+				rm.SetSchemaUrl(rs.SchemaUrl())
+				sms = rm.ScopeMetrics().AppendEmpty()
+				sms.Scope().SetName(componentType.String())
+				created = true
+			}
+			recorded.MoveTo(sms.Metrics().AppendEmpty())
+		}
+	}
+	if md.DataPointCount() == 0 {
+		return nil
+	}
+	return c.next.ConsumeMetrics(ctx, md)
+}
+
+// record builds the metric m from the spans of rs, and reports whether any
+// span had a value for it.
+func (c *spanMetrics) record(ctx context.Context, m metric, rs ptrace.ResourceSpans) (pmetric.Metric, bool, error) {
+	var values []float64
+	var start, end pcommon.Timestamp
+	sss := rs.ScopeSpans()
+	for j := 0; j < sss.Len(); j++ {
+		ss := sss.At(j)
+		spans := ss.Spans()
+		for k := 0; k < spans.Len(); k++ {
+			span := spans.At(k)
+			tCtx := ottlspan.NewTransformContext(span, ss.Scope(), rs.Resource(), ss, rs)
+			if m.condition != nil {
+				matched, err := m.condition.Eval(ctx, tCtx)
+				if err != nil {
+					return pmetric.Metric{}, false, fmt.Errorf("metric %s: condition: %w", m.Name, err)
+				}
+				if !matched {
+					continue
+				}
+			}
+			v, err := m.value.Eval(ctx, tCtx)
+			if err != nil {
+				return pmetric.Metric{}, false, fmt.Errorf("metric %s: value: %w", m.Name, err)
+			}
+			value, ok := number(v)
+			if !ok {
+				continue
+			}
+			if len(values) == 0 || span.EndTimestamp() < start {
+				start = span.EndTimestamp()
+			}
+			end = max(end, span.EndTimestamp())
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return pmetric.Metric{}, false, nil
+	}
+
+	out := pmetric.NewMetric()
+	out.SetName(m.Name)
+	out.SetDescription(m.Description)
+	out.SetUnit(m.Unit)
+	if m.Type == TypeSum {
+		sum := out.SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(end)
+		var total float64
+		for _, v := range values {
+			total += v
+		}
+		dp.SetDoubleValue(total)
+		return out, true, nil
+	}
+
+	histogram := out.SetEmptyHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	dp := histogram.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(end)
+	dp.ExplicitBounds().FromRaw(m.Buckets)
+	counts := make([]uint64, len(m.Buckets)+1)
+	var total float64
+	for _, v := range values {
+		// A value equal to a boundary belongs to the bucket the boundary
+		// closes, as the bounds are inclusive upper bounds.
+		counts[sort.SearchFloat64s(m.Buckets, v)]++
+		total += v
+	}
+	dp.BucketCounts().FromRaw(counts)
+	dp.SetCount(uint64(len(values)))
+	dp.SetSum(total)
+	dp.SetMin(slices.Min(values))
+	dp.SetMax(slices.Max(values))
+	return out, true, nil
+}
+
+// number returns the value an expression yielded, if it is a number.
+func number(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case pcommon.Value:
+		switch v.Type() {
+		case pcommon.ValueTypeInt:
+			return float64(v.Int()), true
+		case pcommon.ValueTypeDouble:
+			return v.Double(), true
+		}
+	}
+	return 0, false
+}
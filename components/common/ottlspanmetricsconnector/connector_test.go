@@ -0,0 +1,278 @@
+package ottlspanmetricsconnector
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+func settings() connector.Settings {
+	return connector.Settings{
+		ID: component.NewID(componentType),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+}
+
+// span is a span of the batch handed to the connector, ending at the given
+// time.
+type span struct {
+	route string
+	size  any
+	end   pcommon.Timestamp
+}
+
+func newTraces(spans []span) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	out := rs.ScopeSpans().AppendEmpty().Spans()
+	for _, s := range spans {
+		span := out.AppendEmpty()
+		span.SetName(s.route)
+		span.Attributes().PutStr("http.route", s.route)
+		if s.size != nil {
+			_ = span.Attributes().PutEmpty("http.response.body.size").FromRaw(s.size)
+		}
+		span.SetStartTimestamp(s.end - 1)
+		span.SetEndTimestamp(s.end)
+	}
+	return td
+}
+
+func create(t *testing.T, m MetricConfig, sink *consumertest.MetricsSink) connector.Traces {
+	t.Helper()
+	f := NewFactory()
+	c, err := f.CreateTracesToMetrics(context.Background(), settings(), &Config{Metrics: []MetricConfig{m}}, sink)
+	if err != nil {
+		t.Fatalf("CreateTracesToMetrics() = %v", err)
+	}
+	return c
+}
+
+const sizeValue = `span.attributes["http.response.body.size"]`
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		name       string
+		buckets    []float64
+		spans      []span
+		wantBounds []float64
+		wantCounts []uint64
+		wantSum    float64
+	}{
+		{
+			name:    "buckets",
+			buckets: []float64{10, 100},
+			spans: []span{
+				{route: "/a", size: int64(5), end: 3},
+				{route: "/a", size: int64(10), end: 2},
+				{route: "/a", size: 50.5, end: 4},
+				{route: "/a", size: int64(500), end: 5},
+				{route: "/a", size: "large", end: 1},
+				{route: "/a", end: 1},
+			},
+			wantBounds: []float64{10, 100},
+			wantCounts: []uint64{2, 1, 1},
+			wantSum:    565.5,
+		},
+		{
+			name:       "default buckets",
+			spans:      []span{{route: "/a", size: int64(7), end: 2}, {route: "/a", size: int64(7), end: 5}},
+			wantBounds: defaultBuckets,
+			wantCounts: []uint64{0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			wantSum:    14,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			c := create(t, MetricConfig{Name: "response.size", Unit: "By", Value: sizeValue, Type: TypeHistogram, Buckets: tt.buckets}, sink)
+			if err := c.ConsumeTraces(context.Background(), newTraces(tt.spans)); err != nil {
+				t.Fatalf("ConsumeTraces() = %v", err)
+			}
+			if len(sink.AllMetrics()) != 1 {
+				t.Fatalf("%d batches of metrics emitted, want 1", len(sink.AllMetrics()))
+			}
+			rm := sink.AllMetrics()[0].ResourceMetrics().At(0)
+			if service, _ := rm.Resource().Attributes().Get("service.name"); service.Str() != "checkout" {
+				t.Errorf("service.name = %q, want checkout", service.Str())
+			}
+			m := rm.ScopeMetrics().At(0).Metrics().At(0)
+			if m.Type() != pmetric.MetricTypeHistogram || m.Name() != "response.size" || m.Unit() != "By" {
+				t.Fatalf("metric = %s %s (%s), want a histogram response.size (By)", m.Type(), m.Name(), m.Unit())
+			}
+			dp := m.Histogram().DataPoints().At(0)
+			if got := dp.ExplicitBounds().AsRaw(); !slices.Equal(got, tt.wantBounds) {
+				t.Errorf("bounds = %v, want %v", got, tt.wantBounds)
+			}
+			if got := dp.BucketCounts().AsRaw(); !slices.Equal(got, tt.wantCounts) {
+				t.Errorf("bucket counts = %v, want %v", got, tt.wantCounts)
+			}
+			var count uint64
+			for _, n := range tt.wantCounts {
+				count += n
+			}
+			if dp.Count() != count || dp.Sum() != tt.wantSum {
+				t.Errorf("count, sum = %d, %v, want %d, %v", dp.Count(), dp.Sum(), count, tt.wantSum)
+			}
+			if dp.StartTimestamp() != 2 || dp.Timestamp() != 5 {
+				t.Errorf("data point spans %v to %v, want 2 to 5", dp.StartTimestamp(), dp.Timestamp())
+			}
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		spans     []span
+		// want is the value of the sum, or -1 when no metric is emitted.
+		want float64
+	}{
+		{
+			name:  "all spans",
+			spans: []span{{route: "/orders", size: int64(3), end: 1}, {route: "/health", size: 1.5, end: 2}},
+			want:  4.5,
+		},
+		{
+			name:      "condition",
+			condition: `span.attributes["http.route"] == "/orders"`,
+			spans:     []span{{route: "/orders", size: int64(3), end: 1}, {route: "/health", size: 1.5, end: 2}},
+			want:      3,
+		},
+		{
+			name:      "no span matches",
+			condition: `span.attributes["http.route"] == "/missing"`,
+			spans:     []span{{route: "/orders", size: int64(3), end: 1}},
+			want:      -1,
+		},
+		{
+			name:  "no value",
+			spans: []span{{route: "/orders", size: "large", end: 1}},
+			want:  -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			c := create(t, MetricConfig{Name: "response.size", Value: sizeValue, Condition: tt.condition, Type: TypeSum}, sink)
+			if err := c.ConsumeTraces(context.Background(), newTraces(tt.spans)); err != nil {
+				t.Fatalf("ConsumeTraces() = %v", err)
+			}
+			if tt.want < 0 {
+				if got := len(sink.AllMetrics()); got != 0 {
+					t.Errorf("%d batches of metrics emitted, want none", got)
+				}
+				return
+			}
+			if len(sink.AllMetrics()) != 1 {
+				t.Fatalf("%d batches of metrics emitted, want 1", len(sink.AllMetrics()))
+			}
+			m := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+			if m.Type() != pmetric.MetricTypeSum || m.Sum().AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+				t.Fatalf("metric = %s, want a delta sum", m.Type())
+			}
+			if got := m.Sum().DataPoints().At(0).DoubleValue(); got != tt.want {
+				t.Errorf("sum = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateInvalidExpressions(t *testing.T) {
+	tests := []struct {
+		name    string
+		metric  MetricConfig
+		wantErr string
+	}{
+		{
+			name:    "value",
+			metric:  MetricConfig{Name: "m", Value: `span.attributes[`, Type: TypeSum},
+			wantErr: "metrics[0]: value: ",
+		},
+		{
+			name:    "condition",
+			metric:  MetricConfig{Name: "m", Value: sizeValue, Condition: `span.attributes["a"] ==`, Type: TypeSum},
+			wantErr: "metrics[0]: condition: ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFactory()
+			_, err := f.CreateTracesToMetrics(context.Background(), settings(), &Config{Metrics: []MetricConfig{tt.metric}}, new(consumertest.MetricsSink))
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("CreateTracesToMetrics() = %v, want an error starting with %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cfg: Config{Metrics: []MetricConfig{
+				{Name: "size", Value: sizeValue, Type: TypeHistogram, Buckets: []float64{1, 10}},
+				{Name: "total", Value: sizeValue, Type: TypeSum},
+			}},
+		},
+		{
+			name:    "no metrics",
+			cfg:     *createDefaultConfig(),
+			wantErr: "metrics must list at least one metric",
+		},
+		{
+			name:    "no name or value",
+			cfg:     Config{Metrics: []MetricConfig{{Type: TypeSum}}},
+			wantErr: "metrics[0]: name must be set\nmetrics[0]: value must be set",
+		},
+		{
+			name:    "unordered buckets",
+			cfg:     Config{Metrics: []MetricConfig{{Name: "size", Value: sizeValue, Type: TypeHistogram, Buckets: []float64{10, 10}}}},
+			wantErr: "metrics[0]: buckets must be in increasing order",
+		},
+		{
+			name:    "buckets of a sum",
+			cfg:     Config{Metrics: []MetricConfig{{Name: "size", Value: sizeValue, Type: TypeSum, Buckets: []float64{1}}}},
+			wantErr: "metrics[0]: buckets only apply to histogram metrics",
+		},
+		{
+			name:    "unknown type",
+			cfg:     Config{Metrics: []MetricConfig{{Name: "size", Value: sizeValue, Type: "gauge"}}},
+			wantErr: `metrics[0]: type must be histogram or sum, got "gauge"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+// Package ottlspanmetricsconnector records metrics from spans, taking each
+// value from an OTTL expression. It covers the simple cases of the
+// signaltometrics connector, a histogram or sum of one value per span, with a
+// shorter configuration.
+package ottlspanmetricsconnector
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+var componentType = component.MustNewType("ottlspanmetrics")
+
+// NewFactory creates a factory for the OTTL span metrics connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		componentType,
+		func() component.Config { return createDefaultConfig() },
+		connector.WithTracesToMetrics(createTracesToMetrics, component.StabilityLevelDevelopment),
+	)
+}
+
+func createTracesToMetrics(_ context.Context, set connector.Settings, cfg component.Config, next consumer.Metrics) (connector.Traces, error) {
+	return newConnector(cfg.(*Config), set.TelemetrySettings, next)
+}
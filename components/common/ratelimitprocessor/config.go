@@ -0,0 +1,32 @@
+package ratelimitprocessor
+
+import (
+	"errors"
+	"time"
+)
+
+// Config defines the configuration for the rate limit processor.
+type Config struct {
+	// Limit is the number of spans, metric data points or log records let
+	// through in each invocation, and in each Interval of an invocation.
+	// Items over the limit are dropped.
+	Limit int `mapstructure:"limit"`
+	// Interval is the longest window Limit applies to: an invocation that
+	// lasts longer gets Limit items again every Interval.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// Validate checks the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Limit <= 0 {
+		return errors.New("limit must be greater than zero")
+	}
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be greater than zero")
+	}
+	return nil
+}
+
+func createDefaultConfig() *Config {
+	return &Config{Limit: 10000, Interval: time.Second}
+}
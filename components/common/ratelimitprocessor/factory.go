@@ -0,0 +1,48 @@
+// Package ratelimitprocessor caps the number of items that flow through a
+// pipeline in each invocation, and each interval of those that last longer, so
+// a burst of telemetry can't use up the time budget of an invocation exporting
+// it. Items over the cap are dropped and
+// counted in the ocelot.processor.ratelimit.dropped metric.
+package ratelimitprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+var componentType = component.MustNewType("ratelimit")
+
+var processorCapabilities = consumer.Capabilities{MutatesData: true}
+
+// NewFactory creates a factory for the rate limit processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		componentType,
+		func() component.Config { return createDefaultConfig() },
+		processor.WithTraces(createTraces, component.StabilityLevelDevelopment),
+		processor.WithMetrics(createMetrics, component.StabilityLevelDevelopment),
+		processor.WithLogs(createLogs, component.StabilityLevelDevelopment),
+	)
+}
+
+func createTraces(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+	p := newRateLimiter(set, cfg.(*Config), "traces")
+	return processorhelper.NewTraces(ctx, set, cfg, next, p.processTraces,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createMetrics(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Metrics) (processor.Metrics, error) {
+	p := newRateLimiter(set, cfg.(*Config), "metrics")
+	return processorhelper.NewMetrics(ctx, set, cfg, next, p.processMetrics,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createLogs(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+	p := newRateLimiter(set, cfg.(*Config), "logs")
+	return processorhelper.NewLogs(ctx, set, cfg, next, p.processLogs,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
@@ -0,0 +1,151 @@
+package ratelimitprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/invocation"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+const scopeName = "github.com/open-telemetry/opentelemetry-lambda/collector/common/ratelimitprocessor"
+
+// rateLimiter admits up to limit items in each window. A window starts with
+// every invocation, so the limit applies per invocation, and every interval
+// within one that lasts longer. The windows are measured in wall clock time,
+// so the time the environment spends frozen counts towards them.
+type rateLimiter struct {
+	limit    int
+	interval time.Duration
+	now      func() time.Time
+
+	mu          sync.Mutex
+	windowStart time.Time
+	requestID   string
+	used        int
+
+	dropped metric.Int64Counter
+	attrs   metric.MeasurementOption
+}
+
+func newRateLimiter(set processor.Settings, cfg *Config, signal string) *rateLimiter {
+	r := &rateLimiter{limit: cfg.Limit, interval: cfg.Interval, now: time.Now}
+	dropped, err := set.MeterProvider.Meter(scopeName).Int64Counter("ocelot.processor.ratelimit.dropped",
+		metric.WithDescription("Items dropped for exceeding the rate limit"), metric.WithUnit("{item}"))
+	if err != nil {
+		set.Logger.Warn("Failed to create the rate limit metric", zap.Error(err))
+	}
+	r.dropped = dropped
+	r.attrs = metric.WithAttributes(
+		attribute.String("processor", set.ID.String()),
+		attribute.String("signal", signal))
+	return r
+}
+
+// admit calls filter with a function that admits n more items and reports
+// true if they fit in the current window. The window doesn't change during the
+// call, so no item is admitted against a window it wasn't counted in.
+func (r *rateLimiter) admit(filter func(fits func(n int) bool)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	inv, _ := invocation.Current()
+	if inv.RequestID != r.requestID || now.Sub(r.windowStart) >= r.interval {
+		r.windowStart, r.requestID, r.used = now, inv.RequestID, 0
+	}
+	filter(func(n int) bool {
+		if r.used+n > r.limit {
+			return false
+		}
+		r.used += n
+		return true
+	})
+}
+
+func (r *rateLimiter) recordDropped(ctx context.Context, n int) {
+	if n > 0 && r.dropped != nil {
+		r.dropped.Add(ctx, int64(n), r.attrs)
+	}
+}
+
+func (r *rateLimiter) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	n := td.SpanCount()
+	r.admit(func(fits func(int) bool) {
+		td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+			rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+				ss.Spans().RemoveIf(func(ptrace.Span) bool { return !fits(1) })
+				return ss.Spans().Len() == 0
+			})
+			return rs.ScopeSpans().Len() == 0
+		})
+	})
+	r.recordDropped(ctx, n-td.SpanCount())
+	if td.SpanCount() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+// processMetrics admits metrics whole, so a metric whose data points don't all
+// fit in what is left of the window is dropped with all of them, and the
+// points left are kept for the metrics that follow.
+func (r *rateLimiter) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	n := md.DataPointCount()
+	r.admit(func(fits func(int) bool) {
+		md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+			rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+				sm.Metrics().RemoveIf(func(m pmetric.Metric) bool { return !fits(dataPointCount(m)) })
+				return sm.Metrics().Len() == 0
+			})
+			return rm.ScopeMetrics().Len() == 0
+		})
+	})
+	r.recordDropped(ctx, n-md.DataPointCount())
+	if md.DataPointCount() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+func (r *rateLimiter) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	n := ld.LogRecordCount()
+	r.admit(func(fits func(int) bool) {
+		ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+			rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+				sl.LogRecords().RemoveIf(func(plog.LogRecord) bool { return !fits(1) })
+				return sl.LogRecords().Len() == 0
+			})
+			return rl.ScopeLogs().Len() == 0
+		})
+	})
+	r.recordDropped(ctx, n-ld.LogRecordCount())
+	if ld.LogRecordCount() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+func dataPointCount(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,230 @@
+package ratelimitprocessor
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/invocation"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// batch is the data handed to the processor at once: one resource per size,
+// holding that many spans or log records, or, for metrics, one gauge per size
+// with that many data points.
+type batch struct {
+	sizes []int
+	// invoke, if set, begins an invocation with this request ID before the
+	// batch is processed.
+	invoke string
+	// advance moves the clock forward before the batch is processed.
+	advance time.Duration
+}
+
+func newTraces(sizes []int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, size := range sizes {
+		spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+		for range size {
+			spans.AppendEmpty()
+		}
+	}
+	return td
+}
+
+func newMetrics(sizes []int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	for _, size := range sizes {
+		points := metrics.AppendEmpty().SetEmptyGauge().DataPoints()
+		for range size {
+			points.AppendEmpty()
+		}
+	}
+	return md
+}
+
+func newLogs(sizes []int) plog.Logs {
+	ld := plog.NewLogs()
+	for _, size := range sizes {
+		records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+		for range size {
+			records.AppendEmpty()
+		}
+	}
+	return ld
+}
+
+// process hands b to r as the given signal and returns how many items it let
+// through.
+func process(t *testing.T, r *rateLimiter, signal string, b batch) int {
+	t.Helper()
+	var (
+		kept int
+		err  error
+	)
+	switch signal {
+	case "traces":
+		var td ptrace.Traces
+		td, err = r.processTraces(context.Background(), newTraces(b.sizes))
+		kept = td.SpanCount()
+	case "metrics":
+		var md pmetric.Metrics
+		md, err = r.processMetrics(context.Background(), newMetrics(b.sizes))
+		kept = md.DataPointCount()
+	case "logs":
+		var ld plog.Logs
+		ld, err = r.processLogs(context.Background(), newLogs(b.sizes))
+		kept = ld.LogRecordCount()
+	}
+	if errors.Is(err, processorhelper.ErrSkipProcessingData) {
+		return 0
+	}
+	if err != nil {
+		t.Fatalf("process %s = %v", signal, err)
+	}
+	return kept
+}
+
+// droppedCount returns the value of the dropped items counter.
+func droppedCount(t *testing.T, reader *sdkmetric.ManualReader) int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() = %v", err)
+	}
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "ocelot.processor.ratelimit.dropped" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				total += dp.Value
+			}
+		}
+	}
+	return total
+}
+
+func TestRateLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		signal      string
+		batches     []batch
+		wantKept    []int
+		wantDropped int64
+	}{
+		{
+			name:     "under the limit",
+			signal:   "traces",
+			batches:  []batch{{sizes: []int{2}}, {sizes: []int{1, 2}}},
+			wantKept: []int{2, 3},
+		},
+		{
+			name:        "burst of spans",
+			signal:      "traces",
+			batches:     []batch{{sizes: []int{3}}, {sizes: []int{2, 2}}, {sizes: []int{4}}},
+			wantKept:    []int{3, 2, 0},
+			wantDropped: 6,
+		},
+		{
+			name:        "burst of log records",
+			signal:      "logs",
+			batches:     []batch{{sizes: []int{4, 4}}, {sizes: []int{1}}},
+			wantKept:    []int{5, 0},
+			wantDropped: 4,
+		},
+		{
+			// The points of a dropped metric are left for the metrics
+			// that follow.
+			name:        "burst of metrics",
+			signal:      "metrics",
+			batches:     []batch{{sizes: []int{3, 3}}, {sizes: []int{2}}, {sizes: []int{1}}},
+			wantKept:    []int{3, 2, 0},
+			wantDropped: 4,
+		},
+		{
+			name:        "next invocation",
+			signal:      "traces",
+			batches:     []batch{{sizes: []int{6}}, {sizes: []int{6}, invoke: "request-2"}},
+			wantKept:    []int{5, 5},
+			wantDropped: 2,
+		},
+		{
+			name:        "next interval of an invocation",
+			signal:      "logs",
+			batches:     []batch{{sizes: []int{5}}, {sizes: []int{1}, advance: 500 * time.Millisecond}, {sizes: []int{3}, advance: 500 * time.Millisecond}},
+			wantKept:    []int{5, 0, 3},
+			wantDropped: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invocation.Begin(tt.name + "/request-1")
+			reader := sdkmetric.NewManualReader()
+			set := processor.Settings{
+				ID: component.NewID(componentType),
+				TelemetrySettings: component.TelemetrySettings{
+					Logger:         zap.NewNop(),
+					MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+					TracerProvider: tracenoop.NewTracerProvider(),
+				},
+				BuildInfo: component.NewDefaultBuildInfo(),
+			}
+			r := newRateLimiter(set, &Config{Limit: 5, Interval: time.Second}, tt.signal)
+			now := time.Now()
+			r.now = func() time.Time { return now }
+
+			var kept []int
+			for _, b := range tt.batches {
+				if b.invoke != "" {
+					invocation.Begin(tt.name + "/" + b.invoke)
+				}
+				now = now.Add(b.advance)
+				kept = append(kept, process(t, r, tt.signal, b))
+			}
+			if !slices.Equal(kept, tt.wantKept) {
+				t.Errorf("kept %v items per batch, want %v", kept, tt.wantKept)
+			}
+			if got := droppedCount(t, reader); got != tt.wantDropped {
+				t.Errorf("dropped count = %d, want %d", got, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{name: "default", cfg: *createDefaultConfig()},
+		{name: "no limit", cfg: Config{Interval: time.Second}, wantErr: "limit must be greater than zero"},
+		{name: "no interval", cfg: Config{Limit: 1}, wantErr: "interval must be greater than zero"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// CheckDefaults creates every factory in factories and its default
+// configuration, and checks that the configuration survives a round trip
+// through the collector's configuration format. This catches default overrides
+// that panic or set fields the component can't read back, before the
+// collector service is started. It doesn't validate the defaults: many
+// components require settings, such as an endpoint, that have no default.
+func (r *Registry[F]) CheckDefaults(factories []func(extensionId string) F, extensionId string) error {
+	var errs []error
+	for _, newFactory := range r.ordered(factories) {
+		src := r.source(newFactory)
+		if err := checkDefaultConfig(newFactory, extensionId); err != nil {
+			errs = append(errs, fmt.Errorf("%s registered by build tag %s: %w", r.kind, src.buildTag, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func checkDefaultConfig[F component.Factory](newFactory func(extensionId string) F, extensionId string) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panicked creating the default config: %v", p)
+		}
+	}()
+	factory := newFactory(extensionId)
+	cfg := factory.CreateDefaultConfig()
+	if cfg == nil {
+		return fmt.Errorf("%q has no default config", factory.Type())
+	}
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err != nil {
+		return fmt.Errorf("%q default config can't be marshaled: %w", factory.Type(), err)
+	}
+	if err := conf.Unmarshal(factory.CreateDefaultConfig()); err != nil {
+		return fmt.Errorf("%q default config can't be read back: %w", factory.Type(), err)
+	}
+	return nil
+}
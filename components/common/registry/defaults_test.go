@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+)
+
+// unreadableConfig is a default configuration that can be marshaled but not
+// read back.
+type unreadableConfig struct{}
+
+func (*unreadableConfig) Unmarshal(*confmap.Conf) error {
+	return errors.New("unknown field")
+}
+
+func TestValidateDefaults(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultConfig func() component.Config
+		wantErr       string
+	}{
+		{
+			name:          "valid",
+			defaultConfig: func() component.Config { return &fakeConfig{Endpoint: "localhost:4317"} },
+		},
+		{
+			name:          "no default config",
+			defaultConfig: func() component.Config { return nil },
+			wantErr:       `processor registered by build tag lambdacomponents.processor.broken: "broken" has no default config`,
+		},
+		{
+			name:          "default config can't be read back",
+			defaultConfig: func() component.Config { return &unreadableConfig{} },
+			wantErr:       `processor registered by build tag lambdacomponents.processor.broken: "broken" default config can't be read back: `,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var factories []func(string) processor.Factory
+			k := NewKind("processor", &factories)
+			k.Register("lambdacomponents.processor.a", "", "a", newFactory("a", nil))
+			k.Register("lambdacomponents.processor.broken", "", "broken", func(string) processor.Factory {
+				return processor.NewFactory(component.MustNewType("broken"), tt.defaultConfig)
+			})
+			err := k.Validate("extension")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+			} else if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
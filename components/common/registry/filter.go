@@ -0,0 +1,89 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// DisableEnvVar names the variable listing compiled-in components that Build
+// leaves out, so a component can be turned off without rebuilding the layer.
+// Entries are separated by commas and are either a component type, which
+// matches every kind, or a type qualified by its kind, such as "exporter:otlp".
+const DisableEnvVar = "OCELOT_DISABLE_COMPONENTS"
+
+// EnableEnvVar names the variable that, when set, restricts Build to exactly
+// the components it lists, using the same format as DisableEnvVar. It takes
+// precedence over DisableEnvVar, and an empty value enables no component.
+const EnableEnvVar = "OCELOT_ENABLED_COMPONENTS"
+
+// included reports whether Build should keep a component of the given kind
+// and type, according to EnableEnvVar or, when it is unset, DisableEnvVar.
+func included(kind string, typ component.Type) bool {
+	if _, restricted := os.LookupEnv(EnableEnvVar); restricted {
+		return componentSetFromEnv(EnableEnvVar).contains(kind, typ)
+	}
+	return !componentSetFromEnv(DisableEnvVar).contains(kind, typ)
+}
+
+// CheckEnabled returns an error naming every EnableEnvVar entry that matches
+// none of the built components. built maps each kind to its component types.
+func CheckEnabled(built map[string][]component.Type) error {
+	if _, restricted := os.LookupEnv(EnableEnvVar); !restricted {
+		return nil
+	}
+	var errs []error
+	for _, entry := range strings.Split(os.Getenv(EnableEnvVar), ",") {
+		if entry = strings.TrimSpace(entry); entry != "" && !matchesAny(entry, built) {
+			errs = append(errs, fmt.Errorf("%s lists %q, which is not compiled into this layer", EnableEnvVar, entry))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func matchesAny(entry string, built map[string][]component.Type) bool {
+	set := componentSet{entry: struct{}{}}
+	for kind, types := range built {
+		for _, typ := range types {
+			if set.contains(kind, typ) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Types returns the component types of a built factory map, sorted.
+func Types[F any](built map[component.Type]F) []component.Type {
+	types := make([]component.Type, 0, len(built))
+	for typ := range built {
+		types = append(types, typ)
+	}
+	slices.SortFunc(types, func(a, b component.Type) int { return strings.Compare(a.String(), b.String()) })
+	return types
+}
+
+// componentSet is a parsed list of component entries.
+type componentSet map[string]struct{}
+
+func componentSetFromEnv(key string) componentSet {
+	set := make(componentSet)
+	for _, entry := range strings.Split(os.Getenv(key), ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			set[entry] = struct{}{}
+		}
+	}
+	return set
+}
+
+func (s componentSet) contains(kind string, typ component.Type) bool {
+	if _, ok := s[typ.String()]; ok {
+		return true
+	}
+	_, ok := s[kind+":"+typ.String()]
+	return ok
+}
@@ -0,0 +1,89 @@
+package registry
+
+import (
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/processor"
+)
+
+func TestBuildFiltersComponents(t *testing.T) {
+	tests := []struct {
+		name     string
+		disabled string
+		// enabled, if not nil, is the value of EnableEnvVar.
+		enabled *string
+		want    []string
+	}{
+		{name: "unset", want: []string{"a", "b", "c"}},
+		{name: "disabled type", disabled: "b", want: []string{"a", "c"}},
+		{name: "disabled kind and type", disabled: " processor:a , exporter:c", want: []string{"b", "c"}},
+		{name: "enabled", enabled: ptr("a,processor:c"), want: []string{"a", "c"}},
+		{name: "enabled takes precedence", disabled: "a", enabled: ptr("a"), want: []string{"a"}},
+		{name: "enabled empty", enabled: ptr("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(DisableEnvVar, tt.disabled)
+			if tt.enabled != nil {
+				t.Setenv(EnableEnvVar, *tt.enabled)
+			}
+			var factories []func(string) processor.Factory
+			k := NewKind("processor", &factories)
+			for _, typ := range []string{"a", "b", "c"} {
+				k.Register("lambdacomponents.processor."+typ, "", typ, newFactory(typ, nil))
+			}
+			built, err := k.Build("extension")
+			if err != nil {
+				t.Fatalf("Build() = %v", err)
+			}
+			var got []string
+			for _, typ := range Types(built) {
+				got = append(got, typ.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Build() types = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckEnabled(t *testing.T) {
+	built := map[string][]component.Type{
+		"receiver": {component.MustNewType("otlp")},
+		"exporter": {component.MustNewType("otlphttp")},
+	}
+	tests := []struct {
+		name    string
+		enabled *string
+		wantErr string
+	}{
+		{name: "unset"},
+		{name: "built", enabled: ptr("otlp, exporter:otlphttp")},
+		{
+			name:    "not built",
+			enabled: ptr("otlp,exporter:otlp"),
+			wantErr: `OCELOT_ENABLED_COMPONENTS lists "exporter:otlp", which is not compiled into this layer`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.enabled != nil {
+				t.Setenv(EnableEnvVar, *tt.enabled)
+			}
+			err := CheckEnabled(built)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckEnabled() = %v", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("CheckEnabled() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,54 @@
+package registry
+
+import "go.opentelemetry.io/collector/component"
+
+// Kind is the registry of one lambdacomponents package: it appends
+// registrations to the package's Factories slice, records where they come
+// from and builds them. Factories appended to the slice directly, as upstream
+// files do, are built along with the registered ones.
+type Kind[F component.Factory] struct {
+	registry  *Registry[F]
+	factories *[]func(extensionId string) F
+}
+
+// NewKind creates the registry of the given component kind over factories,
+// the package's Factories slice.
+func NewKind[F component.Factory](kind string, factories *[]func(extensionId string) F) *Kind[F] {
+	return &Kind[F]{registry: New[F](kind), factories: factories}
+}
+
+// Register adds newFactory to the package's factories, recording the build tag
+// that selected it and the Go module it comes from for the manifest. typ is
+// the component type newFactory creates, so the factory is only constructed
+// when it is used.
+func (k *Kind[F]) Register(tag, module, typ string, newFactory func(extensionId string) F) {
+	*k.factories = append(*k.factories, newFactory)
+	k.registry.Record(newFactory, tag, module, typ)
+}
+
+// Build returns the package's components keyed by type. See Registry.Build.
+func (k *Kind[F]) Build(extensionId string) (map[component.Type]F, error) {
+	return k.registry.Build(*k.factories, extensionId)
+}
+
+// BuildTypes is Build restricted to the given component types. Only their
+// factories are constructed.
+func (k *Kind[F]) BuildTypes(extensionId string, types []component.Type) (map[component.Type]F, error) {
+	return k.registry.BuildTypes(*k.factories, extensionId, types)
+}
+
+// Validate reports components registered more than once under the same
+// component type, which the collector would otherwise reject with a bare
+// duplicate error, and components whose default configuration can't be
+// created.
+func (k *Kind[F]) Validate(extensionId string) error {
+	if _, err := k.Build(extensionId); err != nil {
+		return err
+	}
+	return k.registry.CheckDefaults(*k.factories, extensionId)
+}
+
+// Manifest describes the package's components compiled into this build.
+func (k *Kind[F]) Manifest() []ComponentInfo {
+	return k.registry.Manifest(*k.factories)
+}
@@ -0,0 +1,248 @@
+// Package registry keeps track of the component factories registered by the
+// lambdacomponents packages and checks them before the collector service is
+// built from them.
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"unsafe"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// ComponentInfo describes a component compiled into the collector.
+type ComponentInfo struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	BuildTag string `json:"build_tag"`
+	Module   string `json:"module,omitempty"`
+}
+
+// BuildTag returns the build tag that selects the component of the given kind
+// and type, whether or not it is compiled into this build. It returns false
+// for components this repository doesn't provide, such as the ones built into
+// the upstream collector.
+func BuildTag(kind string, typ component.Type) (string, bool) {
+	tag, ok := catalog[kind+"/"+typ.String()]
+	return tag, ok
+}
+
+// source is what brought a factory into the build.
+type source struct {
+	typ      string
+	buildTag string
+	module   string
+}
+
+// Registry records the build tag and module of the factories of one component
+// kind. Factories appended to a package's slice without going through the
+// registry are still built; their build tag is inferred from the file name.
+type Registry[F component.Factory] struct {
+	kind    string
+	sources map[unsafe.Pointer]source
+}
+
+// New creates an empty registry for the given component kind.
+func New[F component.Factory](kind string) *Registry[F] {
+	return &Registry[F]{kind: kind, sources: make(map[unsafe.Pointer]source)}
+}
+
+// Record notes that newFactory was selected by tag, comes from module and
+// creates a factory of component type typ. The type lets BuildTypes and
+// Manifest skip constructing factories that aren't needed.
+func (r *Registry[F]) Record(newFactory func(extensionId string) F, tag, module, typ string) {
+	r.sources[funcID(newFactory)] = source{typ: typ, buildTag: tag, module: module}
+}
+
+// Build creates every factory in factories and returns them keyed by component
+// type. The map is a snapshot: later changes to factories don't affect it. An
+// error is returned for each component type registered more than once, naming
+// the build tags that selected the conflicting registrations in sorted order, for each
+// factory whose type differs from the one it was recorded with, and for each
+// factory that panics when it or its default configuration is created.
+// Components are left out according to EnableEnvVar and DisableEnvVar.
+func (r *Registry[F]) Build(factories []func(extensionId string) F, extensionId string) (map[component.Type]F, error) {
+	return r.build(factories, extensionId, func(string) bool { return true })
+}
+
+// BuildTypes is Build restricted to the given component types. Factories
+// recorded with a type outside of types are not constructed at all, which
+// keeps the cold start cost of a layer proportional to the components its
+// configuration uses. Factories appended without a recorded type are always
+// constructed, and kept if their type is in types.
+func (r *Registry[F]) BuildTypes(factories []func(extensionId string) F, extensionId string, types []component.Type) (map[component.Type]F, error) {
+	wanted := make(map[string]bool, len(types))
+	for _, typ := range types {
+		wanted[typ.String()] = true
+	}
+	return r.build(factories, extensionId, func(typ string) bool { return wanted[typ] })
+}
+
+func (r *Registry[F]) build(factories []func(extensionId string) F, extensionId string, wanted func(typ string) bool) (map[component.Type]F, error) {
+	built := make(map[component.Type]F, len(factories))
+	tags := make(map[component.Type][]string)
+	var types []component.Type
+	var errs []error
+	for _, newFactory := range r.ordered(factories) {
+		src := r.source(newFactory)
+		if src.typ != "" && !wanted(src.typ) {
+			continue
+		}
+		factory, err := construct(newFactory, extensionId)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", r.kind, r.describe(src), err))
+			continue
+		}
+		typ := factory.Type()
+		if src.typ != "" && typ.String() != src.typ {
+			errs = append(errs, fmt.Errorf("%s registered as %q by build tag %s creates %q",
+				r.kind, src.typ, src.buildTag, typ))
+			continue
+		}
+		if !wanted(typ.String()) || !included(r.kind, typ) {
+			continue
+		}
+		if _, ok := built[typ]; !ok {
+			built[typ] = factory
+			types = append(types, typ)
+		}
+		tags[typ] = append(tags[typ], src.buildTag)
+	}
+
+	slices.SortFunc(types, func(a, b component.Type) int { return strings.Compare(a.String(), b.String()) })
+	for _, typ := range types {
+		if len(tags[typ]) > 1 {
+			errs = append(errs, fmt.Errorf("%s %q is registered %d times, by build tags %s",
+				r.kind, typ, len(tags[typ]), strings.Join(tags[typ], ", ")))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return built, nil
+}
+
+// Manifest describes every factory in factories, sorted by component type and
+// then build tag. Only factories appended without a recorded type are
+// constructed.
+func (r *Registry[F]) Manifest(factories []func(extensionId string) F) []ComponentInfo {
+	infos := make([]ComponentInfo, 0, len(factories))
+	for _, newFactory := range r.ordered(factories) {
+		src := r.source(newFactory)
+		name := src.typ
+		if name == "" {
+			if factory, err := construct(newFactory, ""); err == nil {
+				name = factory.Type().String()
+			} else {
+				name = src.buildTag[strings.LastIndex(src.buildTag, ".")+1:]
+			}
+		}
+		infos = append(infos, ComponentInfo{
+			Name:     name,
+			Kind:     r.kind,
+			BuildTag: src.buildTag,
+			Module:   src.module,
+		})
+	}
+	slices.SortStableFunc(infos, func(a, b ComponentInfo) int { return strings.Compare(a.Name, b.Name) })
+	return infos
+}
+
+// construct creates the factory of newFactory and its default configuration,
+// which the collector creates for every configured component. A panic in
+// either, typically from an upstream NewFactory or a default override, is
+// returned as an error instead of taking the collector down.
+func construct[F component.Factory](newFactory func(extensionId string) F, extensionId string) (factory F, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			var zero F
+			factory, err = zero, fmt.Errorf("panicked creating the factory: %v", p)
+		}
+	}()
+	factory = newFactory(extensionId)
+	if any(factory) == nil {
+		return factory, errors.New("created no factory")
+	}
+	factory.CreateDefaultConfig()
+	return factory, nil
+}
+
+// describe names a registration in errors: by the component ID it was
+// recorded with and its build tag, or by the build tag alone.
+func (r *Registry[F]) describe(src source) string {
+	if src.typ == "" {
+		return "registered by build tag " + src.buildTag
+	}
+	return fmt.Sprintf("%q registered by build tag %s", src.typ, src.buildTag)
+}
+
+// ordered returns factories sorted by the component type they create, and by
+// the build tag that selected them among factories of the same type. The
+// order of factories follows the order in which the init functions of the
+// component files ran, which the Go toolchain doesn't guarantee; sorting makes
+// which of two duplicate registrations is kept, and the order of the tags in
+// error messages, independent of it. Factories appended without a recorded
+// type are constructed to read theirs.
+func (r *Registry[F]) ordered(factories []func(extensionId string) F) []func(extensionId string) F {
+	type entry struct {
+		newFactory func(extensionId string) F
+		typ, tag   string
+	}
+	entries := make([]entry, 0, len(factories))
+	for _, newFactory := range factories {
+		src := r.source(newFactory)
+		typ := src.typ
+		if typ == "" {
+			if factory, err := construct(newFactory, ""); err == nil {
+				typ = factory.Type().String()
+			}
+		}
+		entries = append(entries, entry{newFactory: newFactory, typ: typ, tag: src.buildTag})
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		if c := strings.Compare(a.typ, b.typ); c != 0 {
+			return c
+		}
+		return strings.Compare(a.tag, b.tag)
+	})
+	sorted := make([]func(extensionId string) F, len(entries))
+	for i, e := range entries {
+		sorted[i] = e.newFactory
+	}
+	return sorted
+}
+
+func (r *Registry[F]) source(newFactory func(extensionId string) F) source {
+	if src, ok := r.sources[funcID(newFactory)]; ok {
+		return src
+	}
+	return source{buildTag: r.inferBuildTag(newFactory)}
+}
+
+// inferBuildTag derives the build tag that selected a registration from the
+// file declaring it, since component files are named after their build tag.
+func (r *Registry[F]) inferBuildTag(newFactory func(extensionId string) F) string {
+	fn := runtime.FuncForPC(funcPC(newFactory))
+	if fn == nil {
+		return "lambdacomponents." + r.kind + ".<unknown>"
+	}
+	file, _ := fn.FileLine(fn.Entry())
+	return "lambdacomponents." + r.kind + "." + strings.TrimSuffix(filepath.Base(file), ".go")
+}
+
+func funcPC(fn any) uintptr {
+	return reflect.ValueOf(fn).Pointer()
+}
+
+// funcID identifies a function value. Unlike its code pointer, it tells apart
+// the closures created by one function literal, such as registrations made in
+// a loop or by a helper.
+func funcID[F component.Factory](fn func(extensionId string) F) unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&fn))
+}
@@ -0,0 +1,240 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/processor"
+)
+
+type fakeConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+// newFactory returns a registration creating a processor factory of the given
+// type, and counts the factories it creates.
+func newFactory(typ string, created *int) func(string) processor.Factory {
+	return func(string) processor.Factory {
+		if created != nil {
+			*created++
+		}
+		return processor.NewFactory(component.MustNewType(typ), func() component.Config { return &fakeConfig{} })
+	}
+}
+
+// registration is a call to Kind.Register.
+type registration struct {
+	tag, typ   string
+	newFactory func(string) processor.Factory
+}
+
+func register(regs []registration) *Kind[processor.Factory] {
+	var factories []func(string) processor.Factory
+	k := NewKind("processor", &factories)
+	for _, r := range regs {
+		k.Register(r.tag, "example.com/"+r.typ, r.typ, r.newFactory)
+	}
+	return k
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name      string
+		regs      []registration
+		wantTypes []string
+		wantErr   string
+	}{
+		{
+			name: "distinct types",
+			regs: []registration{
+				{tag: "lambdacomponents.processor.b", typ: "b", newFactory: newFactory("b", nil)},
+				{tag: "lambdacomponents.processor.a", typ: "a", newFactory: newFactory("a", nil)},
+			},
+			wantTypes: []string{"a", "b"},
+		},
+		{
+			name: "duplicate type",
+			regs: []registration{
+				{tag: "lambdacomponents.processor.a2", typ: "a", newFactory: newFactory("a", nil)},
+				{tag: "lambdacomponents.processor.a", typ: "a", newFactory: newFactory("a", nil)},
+			},
+			wantErr: `processor "a" is registered 2 times, by build tags lambdacomponents.processor.a, lambdacomponents.processor.a2`,
+		},
+		{
+			name: "type differs from the registration",
+			regs: []registration{
+				{tag: "lambdacomponents.processor.a", typ: "a", newFactory: newFactory("b", nil)},
+			},
+			wantErr: `processor registered as "a" by build tag lambdacomponents.processor.a creates "b"`,
+		},
+		{
+			name: "factory panics",
+			regs: []registration{
+				{tag: "lambdacomponents.processor.a", typ: "a", newFactory: func(string) processor.Factory { panic("boom") }},
+			},
+			wantErr: `processor "a" registered by build tag lambdacomponents.processor.a: panicked creating the factory: boom`,
+		},
+		{
+			name: "default config panics",
+			regs: []registration{
+				{tag: "lambdacomponents.processor.a", typ: "a", newFactory: func(string) processor.Factory {
+					return processor.NewFactory(component.MustNewType("a"), func() component.Config { panic("no default") })
+				}},
+			},
+			wantErr: `processor "a" registered by build tag lambdacomponents.processor.a: panicked creating the factory: no default`,
+		},
+		{
+			name: "no factory",
+			regs: []registration{
+				{tag: "lambdacomponents.processor.a", typ: "a", newFactory: func(string) processor.Factory { return nil }},
+			},
+			wantErr: `processor "a" registered by build tag lambdacomponents.processor.a: created no factory`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			built, err := register(tt.regs).Build("extension")
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Build() = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build() = %v", err)
+			}
+			var got []string
+			for _, typ := range Types(built) {
+				got = append(got, typ.String())
+			}
+			if !slices.Equal(got, tt.wantTypes) {
+				t.Errorf("Build() types = %v, want %v", got, tt.wantTypes)
+			}
+		})
+	}
+}
+
+func TestBuildSnapshot(t *testing.T) {
+	var factories []func(string) processor.Factory
+	k := NewKind("processor", &factories)
+	k.Register("lambdacomponents.processor.a", "", "a", newFactory("a", nil))
+	built, err := k.Build("extension")
+	if err != nil {
+		t.Fatalf("Build() = %v", err)
+	}
+	k.Register("lambdacomponents.processor.b", "", "b", newFactory("b", nil))
+	if len(built) != 1 {
+		t.Errorf("Build() = %d factories after a later registration, want 1", len(built))
+	}
+}
+
+func TestBuildTypes(t *testing.T) {
+	var createdA, createdB int
+	var factories []func(string) processor.Factory
+	k := NewKind("processor", &factories)
+	k.Register("lambdacomponents.processor.a", "", "a", newFactory("a", &createdA))
+	k.Register("lambdacomponents.processor.b", "", "b", newFactory("b", &createdB))
+	// Appended directly, as upstream files do, so its type isn't known until
+	// it is constructed.
+	factories = append(factories, newFactory("c", nil))
+
+	built, err := k.BuildTypes("extension", []component.Type{component.MustNewType("a"), component.MustNewType("c")})
+	if err != nil {
+		t.Fatalf("BuildTypes() = %v", err)
+	}
+	var got []string
+	for _, typ := range Types(built) {
+		got = append(got, typ.String())
+	}
+	if want := []string{"a", "c"}; !slices.Equal(got, want) {
+		t.Errorf("BuildTypes() types = %v, want %v", got, want)
+	}
+	if createdA == 0 {
+		t.Error("the factory of a wasn't constructed")
+	}
+	if createdB != 0 {
+		t.Errorf("the factory of b was constructed %d times, want 0", createdB)
+	}
+}
+
+func TestManifest(t *testing.T) {
+	var factories []func(string) processor.Factory
+	k := NewKind("processor", &factories)
+	k.Register("lambdacomponents.processor.b", "example.com/b", "b", newFactory("b", nil))
+	k.Register("lambdacomponents.processor.a", "example.com/a", "a", newFactory("a", nil))
+	factories = append(factories, newFactory("c", nil))
+
+	want := []ComponentInfo{
+		{Name: "a", Kind: "processor", BuildTag: "lambdacomponents.processor.a", Module: "example.com/a"},
+		{Name: "b", Kind: "processor", BuildTag: "lambdacomponents.processor.b", Module: "example.com/b"},
+		// The build tag of a factory appended directly is inferred from the
+		// file that declares it.
+		{Name: "c", Kind: "processor", BuildTag: "lambdacomponents.processor.registry_test"},
+	}
+	if got := k.Manifest(); !slices.Equal(got, want) {
+		t.Errorf("Manifest() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildTag(t *testing.T) {
+	tests := []struct {
+		kind    string
+		typ     string
+		wantTag string
+		wantOK  bool
+	}{
+		{kind: "receiver", typ: "otlpjsonfile", wantTag: "lambdacomponents.receiver.otlpjson", wantOK: true},
+		{kind: "connector", typ: "forward", wantTag: "lambdacomponents.connector.forward", wantOK: true},
+		{kind: "exporter", typ: "forward"},
+		{kind: "receiver", typ: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind+"/"+tt.typ, func(t *testing.T) {
+			tag, ok := BuildTag(tt.kind, component.MustNewType(tt.typ))
+			if tag != tt.wantTag || ok != tt.wantOK {
+				t.Errorf("BuildTag() = %q, %t, want %q, %t", tag, ok, tt.wantTag, tt.wantOK)
+			}
+		})
+	}
+}
+
+// BenchmarkBuild compares building every registered factory with building the
+// few a configuration uses, out of 100 registrations whose factories cost
+// about as much to create as an upstream one that sets up its metadata.
+func BenchmarkBuild(b *testing.B) {
+	var factories []func(string) processor.Factory
+	k := NewKind("processor", &factories)
+	var types []component.Type
+	for i := range 100 {
+		typ := fmt.Sprintf("p%d", i)
+		k.Register("lambdacomponents.processor."+typ, "example.com/"+typ, typ, func(string) processor.Factory {
+			attrs := make(map[string]string, 64)
+			for j := range 64 {
+				attrs[fmt.Sprintf("attr%d", j)] = typ
+			}
+			return processor.NewFactory(component.MustNewType(typ), func() component.Config {
+				return &fakeConfig{Endpoint: attrs["attr0"]}
+			})
+		})
+		if i%50 == 0 {
+			types = append(types, component.MustNewType(typ))
+		}
+	}
+
+	b.Run("all", func(b *testing.B) {
+		for range b.N {
+			if _, err := k.Build("extension"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("configured", func(b *testing.B) {
+		for range b.N {
+			if _, err := k.BuildTypes("extension", types); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
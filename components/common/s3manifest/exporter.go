@@ -0,0 +1,161 @@
+package s3manifest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.uber.org/zap"
+)
+
+// Exporter returns f, the awss3 exporter factory, with the exporters it
+// creates writing a manifest when they shut down. The exporters created for
+// the signals of one component share a manifest, written when the last of
+// them shuts down.
+func Exporter(f exporter.Factory, extensionId string) exporter.Factory {
+	return &exporterFactory{Factory: f, extensionId: extensionId, components: make(map[component.ID]*tracker)}
+}
+
+type exporterFactory struct {
+	exporter.Factory
+	extensionId string
+
+	mu         sync.Mutex
+	components map[component.ID]*tracker
+}
+
+// tracker counts the running exporters of a component.
+type tracker struct {
+	settings settings
+	logger   *zap.Logger
+	manifest manifest
+	running  int
+}
+
+func (f *exporterFactory) track(set exporter.Settings, cfg component.Config) (*tracker, error) {
+	s, err := readSettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.components[set.ID]
+	if !ok {
+		t = &tracker{
+			settings: s,
+			logger:   set.Logger,
+			manifest: manifest{ExtensionID: f.extensionId, Exporter: set.ID.String(), Start: time.Now()},
+		}
+		f.components[set.ID] = t
+	}
+	t.running++
+	return t, nil
+}
+
+// doneFunc returns the function an exporter of the component calls when it
+// has shut down. Calls after the first are ignored.
+func (f *exporterFactory) doneFunc(id component.ID, t *tracker) func(ctx context.Context) {
+	var once sync.Once
+	return func(ctx context.Context) {
+		once.Do(func() { f.done(ctx, id, t) })
+	}
+}
+
+// done writes the manifest once the last exporter of the component has shut
+// down. Failing to write it is logged, not returned: the data objects are
+// written either way.
+func (f *exporterFactory) done(ctx context.Context, id component.ID, t *tracker) {
+	f.mu.Lock()
+	t.running--
+	last := t.running == 0
+	if last {
+		delete(f.components, id)
+	}
+	f.mu.Unlock()
+	if !last {
+		return
+	}
+	m := t.manifest
+	m.End = time.Now()
+	c, err := newClient(ctx, t.settings)
+	if err == nil {
+		err = write(ctx, c, t.settings, m)
+	}
+	if err != nil {
+		t.logger.Warn("Failed to write the S3 manifest", zap.Error(err))
+	}
+}
+
+func (f *exporterFactory) CreateTraces(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+	exp, err := f.Factory.CreateTraces(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	t, err := f.track(set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return tracesExporter{Traces: exp, done: f.doneFunc(set.ID, t)}, nil
+}
+
+func (f *exporterFactory) CreateMetrics(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+	exp, err := f.Factory.CreateMetrics(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	t, err := f.track(set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return metricsExporter{Metrics: exp, done: f.doneFunc(set.ID, t)}, nil
+}
+
+func (f *exporterFactory) CreateLogs(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+	exp, err := f.Factory.CreateLogs(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	t, err := f.track(set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return logsExporter{Logs: exp, done: f.doneFunc(set.ID, t)}, nil
+}
+
+// The manifest is written after the wrapped exporter has shut down, so it
+// lists the objects flushed on shutdown too.
+
+type tracesExporter struct {
+	exporter.Traces
+	done func(ctx context.Context)
+}
+
+func (e tracesExporter) Shutdown(ctx context.Context) error {
+	err := e.Traces.Shutdown(ctx)
+	e.done(ctx)
+	return err
+}
+
+type metricsExporter struct {
+	exporter.Metrics
+	done func(ctx context.Context)
+}
+
+func (e metricsExporter) Shutdown(ctx context.Context) error {
+	err := e.Metrics.Shutdown(ctx)
+	e.done(ctx)
+	return err
+}
+
+type logsExporter struct {
+	exporter.Logs
+	done func(ctx context.Context)
+}
+
+func (e logsExporter) Shutdown(ctx context.Context) error {
+	err := e.Logs.Shutdown(ctx)
+	e.done(ctx)
+	return err
+}
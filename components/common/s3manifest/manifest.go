@@ -0,0 +1,187 @@
+// Package s3manifest makes the objects the awss3 exporter writes
+// discoverable. When an exporter shuts down, a small JSON index object listing
+// the keys it wrote since it started is put next to them, keyed by extension
+// ID and start time.
+package s3manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// EnvVar names the variable that enables manifests when set to a true value.
+const EnvVar = "OCELOT_S3_MANIFEST"
+
+// client is the part of the S3 API manifests use.
+type client interface {
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// settings are the parts of the awss3 exporter configuration that locate its
+// objects, read by their configuration keys.
+type settings struct {
+	Region          string `mapstructure:"region"`
+	Bucket          string `mapstructure:"s3_bucket"`
+	Prefix          string `mapstructure:"s3_prefix"`
+	PartitionFormat string `mapstructure:"s3_partition_format"`
+	Endpoint        string `mapstructure:"endpoint"`
+	ForcePathStyle  bool   `mapstructure:"s3_force_path_style"`
+	RoleARN         string `mapstructure:"role_arn"`
+}
+
+func readSettings(cfg component.Config) (settings, error) {
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err != nil {
+		return settings{}, err
+	}
+	var s settings
+	uploader, err := conf.Sub("s3uploader")
+	if err != nil {
+		return settings{}, err
+	}
+	if err := uploader.Unmarshal(&s, confmap.WithIgnoreUnused()); err != nil {
+		return settings{}, err
+	}
+	if s.Bucket == "" {
+		return settings{}, fmt.Errorf("s3uploader.s3_bucket is not set")
+	}
+	return s, nil
+}
+
+func newClient(ctx context.Context, s settings) (client, error) {
+	awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(s.Region))
+	if err != nil {
+		return nil, err
+	}
+	if s.RoleARN != "" {
+		awsCfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(awsCfg), s.RoleARN))
+	}
+	return s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if s.Endpoint != "" {
+			o.BaseEndpoint = aws.String(s.Endpoint)
+		}
+		o.UsePathStyle = s.ForcePathStyle
+	}), nil
+}
+
+// manifest is the index object.
+type manifest struct {
+	ExtensionID string    `json:"extension_id"`
+	Exporter    string    `json:"exporter"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Keys        []string  `json:"keys"`
+}
+
+// key returns the key of the index object, under the exporter's prefix.
+func (m manifest) key(prefix string) string {
+	ext := m.ExtensionID
+	if ext == "" {
+		ext = "default"
+	}
+	return path.Join(prefix, "_manifests", "ext="+ext, m.Start.UTC().Format("20060102T150405.000Z")+".json")
+}
+
+// write lists the objects written under the partitions of the span of m and
+// puts the index object.
+func write(ctx context.Context, c client, s settings, m manifest) error {
+	prefixes, err := partitionPrefixes(s, m.Start, m.End)
+	if err != nil {
+		return err
+	}
+	m.Keys = []string{}
+	for _, prefix := range prefixes {
+		input := &s3.ListObjectsV2Input{Bucket: aws.String(s.Bucket), Prefix: aws.String(prefix)}
+		for {
+			out, err := c.ListObjectsV2(ctx, input)
+			if err != nil {
+				return fmt.Errorf("failed to list objects under %s: %w", prefix, err)
+			}
+			for _, obj := range out.Contents {
+				if obj.Key != nil && obj.LastModified != nil && !obj.LastModified.Before(m.Start.Truncate(time.Second)) {
+					m.Keys = append(m.Keys, *obj.Key)
+				}
+			}
+			if out.NextContinuationToken == nil {
+				break
+			}
+			input.ContinuationToken = out.NextContinuationToken
+		}
+	}
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = c.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.Bucket),
+		Key:         aws.String(m.key(s.Prefix)),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String("application/json"),
+	})
+	return err
+}
+
+// partitionPrefixes returns the key prefixes of the partitions objects written
+// between start and end are in. Partitions are at least a minute long.
+func partitionPrefixes(s settings, start, end time.Time) ([]string, error) {
+	seen := make(map[string]bool)
+	var prefixes []string
+	for t := start.UTC().Truncate(time.Minute); !t.After(end.UTC()); t = t.Add(time.Minute) {
+		partition, err := formatPartition(s.PartitionFormat, t)
+		if err != nil {
+			return nil, err
+		}
+		prefix := path.Join(s.Prefix, partition) + "/"
+		if !seen[prefix] {
+			seen[prefix] = true
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes, nil
+}
+
+// formatPartition expands the strftime directives of a partition format that
+// resolve to a minute or longer, and %%.
+func formatPartition(format string, t time.Time) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.WriteByte(format[i])
+			continue
+		}
+		if i++; i == len(format) {
+			return "", fmt.Errorf("partition format %q ends with %%", format)
+		}
+		switch format[i] {
+		case 'Y':
+			fmt.Fprintf(&b, "%04d", t.Year())
+		case 'm':
+			fmt.Fprintf(&b, "%02d", int(t.Month()))
+		case 'd':
+			fmt.Fprintf(&b, "%02d", t.Day())
+		case 'H':
+			fmt.Fprintf(&b, "%02d", t.Hour())
+		case 'M':
+			fmt.Fprintf(&b, "%02d", t.Minute())
+		case '%':
+			b.WriteByte('%')
+		default:
+			return "", fmt.Errorf("partition format %q uses %%%c, which manifests don't support", format, format[i])
+		}
+	}
+	return b.String(), nil
+}
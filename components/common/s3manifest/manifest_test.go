@@ -0,0 +1,259 @@
+package s3manifest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func TestFormatPartition(t *testing.T) {
+	ts := time.Date(2026, 3, 7, 9, 5, 59, 0, time.UTC)
+	tests := []struct {
+		format  string
+		want    string
+		wantErr string
+	}{
+		{format: "year=%Y/month=%m/day=%d/hour=%H/minute=%M", want: "year=2026/month=03/day=07/hour=09/minute=05"},
+		{format: "%Y%m%d%%", want: "20260307%"},
+		{format: "static", want: "static"},
+		{format: "%Y/%S", wantErr: `partition format "%Y/%S" uses %S, which manifests don't support`},
+		{format: "%Y%", wantErr: `partition format "%Y%" ends with %`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := formatPartition(tt.format, ts)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("formatPartition() = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("formatPartition() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartitionPrefixes(t *testing.T) {
+	start := time.Date(2026, 3, 7, 9, 58, 30, 0, time.UTC)
+	tests := []struct {
+		name   string
+		format string
+		end    time.Time
+		want   []string
+	}{
+		{
+			name:   "minutes",
+			format: "%H/%M",
+			end:    start.Add(2 * time.Minute),
+			want:   []string{"traces/09/58/", "traces/09/59/", "traces/10/00/"},
+		},
+		{
+			name:   "hours",
+			format: "%Y/%H",
+			end:    start.Add(3 * time.Minute),
+			want:   []string{"traces/2026/09/", "traces/2026/10/"},
+		},
+		{
+			name:   "same minute",
+			format: "%H/%M",
+			end:    start.Add(time.Second),
+			want:   []string{"traces/09/58/"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := partitionPrefixes(settings{Prefix: "traces", PartitionFormat: tt.format}, start, tt.end)
+			if err != nil {
+				t.Fatalf("partitionPrefixes() = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("partitionPrefixes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManifestKey(t *testing.T) {
+	start := time.Date(2026, 3, 7, 9, 58, 30, 123e6, time.FixedZone("CET", 3600))
+	tests := []struct {
+		extensionID string
+		want        string
+	}{
+		{extensionID: "ext-1", want: "traces/_manifests/ext=ext-1/20260307T085830.123Z.json"},
+		{want: "traces/_manifests/ext=default/20260307T085830.123Z.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := (manifest{ExtensionID: tt.extensionID, Start: start}).key("traces"); got != tt.want {
+				t.Errorf("key() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadSettings(t *testing.T) {
+	type uploader struct {
+		Region   string `mapstructure:"region"`
+		S3Bucket string `mapstructure:"s3_bucket"`
+		S3Prefix string `mapstructure:"s3_prefix"`
+		Other    int    `mapstructure:"compression_level"`
+	}
+	type config struct {
+		S3Uploader uploader `mapstructure:"s3uploader"`
+		Timeout    int      `mapstructure:"timeout"`
+	}
+	tests := []struct {
+		name    string
+		cfg     config
+		want    settings
+		wantErr string
+	}{
+		{
+			name: "uploader",
+			cfg:  config{S3Uploader: uploader{Region: "eu-west-1", S3Bucket: "telemetry", S3Prefix: "traces", Other: 3}},
+			want: settings{Region: "eu-west-1", Bucket: "telemetry", Prefix: "traces"},
+		},
+		{
+			name:    "no bucket",
+			cfg:     config{S3Uploader: uploader{Region: "eu-west-1"}},
+			wantErr: "s3uploader.s3_bucket is not set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readSettings(&tt.cfg)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("readSettings() = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("readSettings() = %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+// fakeClient serves the objects of one bucket, a page of two at a time.
+type fakeClient struct {
+	objects map[string]time.Time
+	listErr error
+	put     *s3.PutObjectInput
+	body    []byte
+}
+
+func (c *fakeClient) ListObjectsV2(_ context.Context, params *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	if c.listErr != nil {
+		return nil, c.listErr
+	}
+	var keys []string
+	for key := range c.objects {
+		if len(key) >= len(*params.Prefix) && key[:len(*params.Prefix)] == *params.Prefix {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	from := 0
+	if params.ContinuationToken != nil {
+		from = slices.Index(keys, *params.ContinuationToken)
+	}
+	out := &s3.ListObjectsV2Output{}
+	for i := from; i < len(keys) && i < from+2; i++ {
+		out.Contents = append(out.Contents, types.Object{Key: aws.String(keys[i]), LastModified: aws.Time(c.objects[keys[i]])})
+	}
+	if from+2 < len(keys) {
+		out.NextContinuationToken = aws.String(keys[from+2])
+	}
+	return out, nil
+}
+
+func (c *fakeClient) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	c.put = params
+	body, err := io.ReadAll(params.Body)
+	c.body = body
+	return &s3.PutObjectOutput{}, err
+}
+
+func TestWrite(t *testing.T) {
+	start := time.Date(2026, 3, 7, 9, 58, 30, 500e6, time.UTC)
+	s := settings{Bucket: "telemetry", Prefix: "traces", PartitionFormat: "%H/%M"}
+	m := manifest{ExtensionID: "ext-1", Exporter: "awss3", Start: start, End: start.Add(time.Minute)}
+
+	tests := []struct {
+		name     string
+		client   *fakeClient
+		wantKeys []string
+		wantErr  string
+	}{
+		{
+			name: "objects written since the start",
+			client: &fakeClient{objects: map[string]time.Time{
+				"traces/09/58/a.json":   start.Truncate(time.Second),
+				"traces/09/58/b.json":   start.Add(time.Second),
+				"traces/09/58/c.json":   start.Add(2 * time.Second),
+				"traces/09/58/old.json": start.Add(-time.Minute),
+				"traces/09/59/d.json":   start.Add(time.Minute),
+				"traces/10/00/e.json":   start.Add(2 * time.Minute),
+				"logs/09/58/f.json":     start.Add(time.Second),
+			}},
+			wantKeys: []string{"traces/09/58/a.json", "traces/09/58/b.json", "traces/09/58/c.json", "traces/09/59/d.json"},
+		},
+		{
+			name:     "no objects",
+			client:   &fakeClient{},
+			wantKeys: []string{},
+		},
+		{
+			name:    "listing fails",
+			client:  &fakeClient{listErr: errors.New("access denied")},
+			wantErr: "failed to list objects under traces/09/58/: access denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := write(context.Background(), tt.client, s, m)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("write() = %v, want %q", err, tt.wantErr)
+				}
+				if tt.client.put != nil {
+					t.Error("write() put a manifest after failing to list the objects")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("write() = %v", err)
+			}
+			if tt.client.put == nil {
+				t.Fatal("write() put no manifest")
+			}
+			if got, want := aws.ToString(tt.client.put.Key), m.key(s.Prefix); got != want {
+				t.Errorf("manifest key = %q, want %q", got, want)
+			}
+			if got := aws.ToString(tt.client.put.Bucket); got != s.Bucket {
+				t.Errorf("manifest bucket = %q, want %q", got, s.Bucket)
+			}
+			var got manifest
+			if err := json.Unmarshal(tt.client.body, &got); err != nil {
+				t.Fatalf("manifest = %s: %v", tt.client.body, err)
+			}
+			if got.ExtensionID != m.ExtensionID || got.Exporter != m.Exporter || !got.Start.Equal(m.Start) || !got.End.Equal(m.End) {
+				t.Errorf("manifest = %+v, want %+v", got, m)
+			}
+			if !slices.Equal(got.Keys, tt.wantKeys) {
+				t.Errorf("manifest keys = %v, want %v", got.Keys, tt.wantKeys)
+			}
+		})
+	}
+}
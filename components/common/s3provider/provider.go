@@ -0,0 +1,123 @@
+// Package s3provider resolves collector configuration from Amazon S3. A URI of
+// the form s3://<bucket>/<key> is replaced by the object's content, parsed as
+// YAML, read in the region of the function, AWS_REGION. The virtual-hosted
+// form of the upstream provider, s3://<bucket>.s3.<region>.amazonaws.com/<key>,
+// is accepted too and reads the object in the region it names:
+//
+//	OPENTELEMETRY_COLLECTOR_CONFIG_URI=s3://my-configs/collector.yaml
+package s3provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const schemeName = "s3"
+
+// regionEnvVar names the variable Lambda sets to the region of the function.
+const regionEnvVar = "AWS_REGION"
+
+// virtualHost matches the host of a virtual-hosted style object URL.
+var virtualHost = regexp.MustCompile(`^(.+)\.s3\.([a-z0-9-]+)\.amazonaws\.com$`)
+
+// client is the part of the S3 API the provider uses.
+type client interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+// cache holds the objects fetched by every provider in the process, keyed by
+// URI, so a collector restarted within a warm execution environment doesn't
+// fetch them again.
+var cache sync.Map
+
+type provider struct {
+	mu     sync.Mutex
+	client client
+}
+
+// NewFactory returns a factory for a confmap.Provider that reads objects from
+// S3.
+func NewFactory() confmap.ProviderFactory {
+	return confmap.NewProviderFactory(func(confmap.ProviderSettings) confmap.Provider {
+		return &provider{}
+	})
+}
+
+func (p *provider) Retrieve(ctx context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	location, ok := strings.CutPrefix(uri, schemeName+"://")
+	if !ok {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+	bucket, key, _ := strings.Cut(location, "/")
+	if bucket == "" || key == "" {
+		return nil, fmt.Errorf("%q uri must be of the form s3://<bucket>/<key>", uri)
+	}
+	region := os.Getenv(regionEnvVar)
+	if m := virtualHost.FindStringSubmatch(bucket); m != nil {
+		bucket, region = m[1], m[2]
+	}
+
+	if content, ok := cache.Load(uri); ok {
+		return confmap.NewRetrievedFromYAML(content.([]byte))
+	}
+	c, err := p.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}, func(o *s3.Options) {
+		if region != "" {
+			o.Region = region
+		}
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, fmt.Errorf("S3 object %q not found in bucket %q: %w", key, bucket, err)
+		}
+		return nil, fmt.Errorf("failed to get S3 object %q from bucket %q: %w", key, bucket, err)
+	}
+	defer out.Body.Close()
+	content, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read S3 object %q from bucket %q: %w", key, bucket, err)
+	}
+	cache.Store(uri, content)
+	return confmap.NewRetrievedFromYAML(content)
+}
+
+func (p *provider) getClient(ctx context.Context) (client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client != nil {
+		return p.client, nil
+	}
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	p.client = s3.NewFromConfig(cfg)
+	return p.client, nil
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (*provider) Shutdown(context.Context) error {
+	return nil
+}
@@ -0,0 +1,132 @@
+package s3provider
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// fakeClient serves the objects in objects, keyed by <bucket>/<key>, and
+// records the regions they were requested in.
+type fakeClient struct {
+	objects map[string]string
+	regions []string
+}
+
+func (c *fakeClient) GetObject(_ context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	var o s3.Options
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	c.regions = append(c.regions, o.Region)
+	content, ok := c.objects[aws.ToString(params.Bucket)+"/"+aws.ToString(params.Key)]
+	if !ok {
+		return nil, &types.NoSuchKey{Message: aws.String("The specified key does not exist.")}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(content))}, nil
+}
+
+// resetCache empties the process-wide cache for the duration of the test.
+func resetCache(t *testing.T) {
+	empty := func() {
+		cache.Range(func(key, _ any) bool {
+			cache.Delete(key)
+			return true
+		})
+	}
+	empty()
+	t.Cleanup(empty)
+}
+
+func TestRetrieve(t *testing.T) {
+	objects := map[string]string{
+		"configs/collector.yaml": "exporters:\n  otlphttp:\n    endpoint: https://collector:4318\n",
+	}
+	want := map[string]any{"exporters": map[string]any{"otlphttp": map[string]any{"endpoint": "https://collector:4318"}}}
+	tests := []struct {
+		name        string
+		uris        []string
+		want        any
+		wantErr     string
+		wantRegions []string
+	}{
+		{
+			name:        "object",
+			uris:        []string{"s3://configs/collector.yaml"},
+			want:        want,
+			wantRegions: []string{"eu-west-1"},
+		},
+		{
+			name:        "virtual-hosted",
+			uris:        []string{"s3://configs.s3.us-east-2.amazonaws.com/collector.yaml"},
+			want:        want,
+			wantRegions: []string{"us-east-2"},
+		},
+		{
+			name:        "cached",
+			uris:        []string{"s3://configs/collector.yaml", "s3://configs/collector.yaml"},
+			want:        want,
+			wantRegions: []string{"eu-west-1"},
+		},
+		{
+			name:        "missing object",
+			uris:        []string{"s3://configs/missing.yaml"},
+			wantErr:     `S3 object "missing.yaml" not found in bucket "configs"`,
+			wantRegions: []string{"eu-west-1"},
+		},
+		{
+			name:        "errors aren't cached",
+			uris:        []string{"s3://configs/missing.yaml", "s3://configs/missing.yaml"},
+			wantErr:     `S3 object "missing.yaml" not found in bucket "configs"`,
+			wantRegions: []string{"eu-west-1", "eu-west-1"},
+		},
+		{
+			name:    "no key",
+			uris:    []string{"s3://configs"},
+			wantErr: `"s3://configs" uri must be of the form s3://<bucket>/<key>`,
+		},
+		{
+			name:    "other scheme",
+			uris:    []string{"ssm:/otel/config"},
+			wantErr: `"ssm:/otel/config" uri is not supported by "s3" provider`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetCache(t)
+			t.Setenv("AWS_REGION", "eu-west-1")
+			c := &fakeClient{objects: objects}
+			p := &provider{client: c}
+
+			var (
+				got any
+				err error
+			)
+			for _, uri := range tt.uris {
+				var retrieved *confmap.Retrieved
+				if retrieved, err = p.Retrieve(context.Background(), uri, nil); err == nil {
+					got, err = retrieved.AsRaw()
+				}
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Retrieve() = %v, want an error containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("Retrieve() = %v", err)
+			} else if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Retrieve() = %#v, want %#v", got, tt.want)
+			}
+			if !reflect.DeepEqual(c.regions, tt.wantRegions) {
+				t.Errorf("GetObject was called in regions %v, want %v", c.regions, tt.wantRegions)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Package secretsmanagerprovider resolves collector configuration from AWS
+// Secrets Manager. A URI of the form secretsmanager:<secret name or ARN> is
+// replaced by the secret string, parsed as YAML, and one of the form
+// secretsmanager:<secret name or ARN>#<key> by the value of a key of a JSON
+// secret:
+//
+//	extensions:
+//	  bearertokenauth:
+//	    token: ${secretsmanager:otel/backend#token}
+package secretsmanagerprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const schemeName = "secretsmanager"
+
+// client is the part of the Secrets Manager API the provider uses.
+type client interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// cache holds the secret strings fetched by every provider in the process,
+// keyed by secret ID, so a collector restarted within a warm execution
+// environment doesn't fetch them again, and the keys of a secret are read
+// with a single request.
+var cache sync.Map
+
+type provider struct {
+	mu     sync.Mutex
+	client client
+}
+
+// NewFactory returns a factory for a confmap.Provider that reads secrets from
+// Secrets Manager.
+func NewFactory() confmap.ProviderFactory {
+	return confmap.NewProviderFactory(func(confmap.ProviderSettings) confmap.Provider {
+		return &provider{}
+	})
+}
+
+func (p *provider) Retrieve(ctx context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+	secretID, key, hasKey := strings.Cut(strings.TrimPrefix(uri, schemeName+":"), "#")
+	if secretID == "" {
+		return nil, fmt.Errorf("%q uri does not name a secret", uri)
+	}
+
+	secret, err := p.secret(ctx, secretID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasKey {
+		return confmap.NewRetrievedFromYAML([]byte(secret))
+	}
+	var values map[string]any
+	if err := json.Unmarshal([]byte(secret), &values); err != nil {
+		return nil, fmt.Errorf("secret %q is not a JSON object, so key %q can't be read from it", secretID, key)
+	}
+	value, ok := values[key]
+	if !ok {
+		return nil, fmt.Errorf("secret %q has no key %q", secretID, key)
+	}
+	return confmap.NewRetrieved(value)
+}
+
+// secret returns the secret string of the secret with the given name or ARN.
+func (p *provider) secret(ctx context.Context, secretID string) (string, error) {
+	if secret, ok := cache.Load(secretID); ok {
+		return secret.(string), nil
+	}
+	c, err := p.getClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	out, err := c.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(secretID)})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return "", fmt.Errorf("secret %q not found: %w", secretID, err)
+		}
+		return "", fmt.Errorf("failed to get secret %q: %w", secretID, err)
+	}
+	if out.SecretString == nil {
+		return "", fmt.Errorf("secret %q has no secret string", secretID)
+	}
+	secret := aws.ToString(out.SecretString)
+	cache.Store(secretID, secret)
+	return secret, nil
+}
+
+func (p *provider) getClient(ctx context.Context) (client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client != nil {
+		return p.client, nil
+	}
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	p.client = secretsmanager.NewFromConfig(cfg)
+	return p.client, nil
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (*provider) Shutdown(context.Context) error {
+	return nil
+}
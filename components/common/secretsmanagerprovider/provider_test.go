@@ -0,0 +1,149 @@
+package secretsmanagerprovider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// fakeClient serves the secrets in secrets and counts the requests.
+type fakeClient struct {
+	secrets map[string]string
+	calls   int
+}
+
+func (c *fakeClient) GetSecretValue(_ context.Context, params *secretsmanager.GetSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	c.calls++
+	secret, ok := c.secrets[aws.ToString(params.SecretId)]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("Secrets Manager can't find the specified secret.")}
+	}
+	return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(secret)}, nil
+}
+
+// resetCache empties the process-wide cache for the duration of the test.
+func resetCache(t *testing.T) {
+	empty := func() {
+		cache.Range(func(key, _ any) bool {
+			cache.Delete(key)
+			return true
+		})
+	}
+	empty()
+	t.Cleanup(empty)
+}
+
+func TestRetrieve(t *testing.T) {
+	secrets := map[string]string{
+		"otel/token":   "s3cr3t",
+		"otel/backend": `{"endpoint": "https://collector:4318", "token": "s3cr3t"}`,
+	}
+	tests := []struct {
+		name      string
+		uris      []string
+		want      any
+		wantErr   string
+		wantCalls int
+	}{
+		{
+			name:      "secret string",
+			uris:      []string{"secretsmanager:otel/token"},
+			want:      "s3cr3t",
+			wantCalls: 1,
+		},
+		{
+			name:      "JSON secret",
+			uris:      []string{"secretsmanager:otel/backend"},
+			want:      map[string]any{"endpoint": "https://collector:4318", "token": "s3cr3t"},
+			wantCalls: 1,
+		},
+		{
+			name:      "key",
+			uris:      []string{"secretsmanager:otel/backend#token"},
+			want:      "s3cr3t",
+			wantCalls: 1,
+		},
+		{
+			name:      "cached",
+			uris:      []string{"secretsmanager:otel/token", "secretsmanager:otel/token"},
+			want:      "s3cr3t",
+			wantCalls: 1,
+		},
+		{
+			name:      "keys of a cached secret",
+			uris:      []string{"secretsmanager:otel/backend#endpoint", "secretsmanager:otel/backend#token"},
+			want:      "s3cr3t",
+			wantCalls: 1,
+		},
+		{
+			name:      "not found",
+			uris:      []string{"secretsmanager:otel/missing"},
+			wantErr:   `secret "otel/missing" not found`,
+			wantCalls: 1,
+		},
+		{
+			name:      "errors aren't cached",
+			uris:      []string{"secretsmanager:otel/missing", "secretsmanager:otel/missing"},
+			wantErr:   `secret "otel/missing" not found`,
+			wantCalls: 2,
+		},
+		{
+			name:      "missing key",
+			uris:      []string{"secretsmanager:otel/backend#password"},
+			wantErr:   `secret "otel/backend" has no key "password"`,
+			wantCalls: 1,
+		},
+		{
+			name:      "key of a secret that isn't JSON",
+			uris:      []string{"secretsmanager:otel/token#token"},
+			wantErr:   `secret "otel/token" is not a JSON object`,
+			wantCalls: 1,
+		},
+		{
+			name:    "no secret name",
+			uris:    []string{"secretsmanager:#token"},
+			wantErr: `"secretsmanager:#token" uri does not name a secret`,
+		},
+		{
+			name:    "other scheme",
+			uris:    []string{"ssm:/otel/token"},
+			wantErr: `"ssm:/otel/token" uri is not supported by "secretsmanager" provider`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetCache(t)
+			c := &fakeClient{secrets: secrets}
+			p := &provider{client: c}
+
+			var (
+				got any
+				err error
+			)
+			for _, uri := range tt.uris {
+				var retrieved *confmap.Retrieved
+				if retrieved, err = p.Retrieve(context.Background(), uri, nil); err == nil {
+					got, err = retrieved.AsRaw()
+				}
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Retrieve() = %v, want an error containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("Retrieve() = %v", err)
+			} else if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Retrieve() = %#v, want %#v", got, tt.want)
+			}
+			if c.calls != tt.wantCalls {
+				t.Errorf("GetSecretValue was called %d times, want %d", c.calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package selftelemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+type exporterFactory struct {
+	exporter.Factory
+}
+
+func (f exporterFactory) CreateTraces(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+	c := newCounters(set)
+	exp, err := f.Factory.CreateTraces(ctx, c.settings(set), cfg)
+	if err != nil {
+		return nil, err
+	}
+	return tracesExporter{Traces: exp, counters: c}, nil
+}
+
+func (f exporterFactory) CreateMetrics(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+	c := newCounters(set)
+	exp, err := f.Factory.CreateMetrics(ctx, c.settings(set), cfg)
+	if err != nil {
+		return nil, err
+	}
+	return metricsExporter{Metrics: exp, counters: c}, nil
+}
+
+func (f exporterFactory) CreateLogs(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+	c := newCounters(set)
+	exp, err := f.Factory.CreateLogs(ctx, c.settings(set), cfg)
+	if err != nil {
+		return nil, err
+	}
+	return logsExporter{Logs: exp, counters: c}, nil
+}
+
+type tracesExporter struct {
+	exporter.Traces
+	counters *counters
+}
+
+func (e tracesExporter) Start(ctx context.Context, host component.Host) error {
+	e.counters.start()
+	return e.Traces.Start(ctx, host)
+}
+
+func (e tracesExporter) Shutdown(ctx context.Context) error {
+	err := e.Traces.Shutdown(ctx)
+	e.counters.shutdown()
+	return err
+}
+
+type metricsExporter struct {
+	exporter.Metrics
+	counters *counters
+}
+
+func (e metricsExporter) Start(ctx context.Context, host component.Host) error {
+	e.counters.start()
+	return e.Metrics.Start(ctx, host)
+}
+
+func (e metricsExporter) Shutdown(ctx context.Context) error {
+	err := e.Metrics.Shutdown(ctx)
+	e.counters.shutdown()
+	return err
+}
+
+type logsExporter struct {
+	exporter.Logs
+	counters *counters
+}
+
+func (e logsExporter) Start(ctx context.Context, host component.Host) error {
+	e.counters.start()
+	return e.Logs.Start(ctx, host)
+}
+
+func (e logsExporter) Shutdown(ctx context.Context) error {
+	err := e.Logs.Shutdown(ctx)
+	e.counters.shutdown()
+	return err
+}
@@ -0,0 +1,187 @@
+package selftelemetry
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+// The names of the exporter helper instruments that are read, without the
+// otelcol_ prefix. The failure counters end with the items they count, e.g.
+// send_failed_spans.
+const (
+	queueSizeName     = "exporter_queue_size"
+	sendFailedName    = "exporter_send_failed_"
+	enqueueFailedName = "exporter_enqueue_failed_"
+)
+
+// meterProvider hands the instruments an exporter creates through to the
+// collector's meter provider, and records what the exporter helper measures
+// with those it reads in counters.
+type meterProvider struct {
+	metric.MeterProvider
+	counters *counters
+}
+
+func (p meterProvider) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	return meter{Meter: p.MeterProvider.Meter(name, opts...), counters: p.counters}
+}
+
+type meter struct {
+	metric.Meter
+	counters *counters
+}
+
+func (m meter) Int64Counter(name string, opts ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	counter, err := m.Meter.Int64Counter(name, opts...)
+	if err != nil {
+		return counter, err
+	}
+	switch name = strings.TrimPrefix(name, "otelcol_"); {
+	case strings.HasPrefix(name, sendFailedName):
+		return failureCounter{Int64Counter: counter, counters: m.counters, failed: &m.counters.sendFailed}, nil
+	case strings.HasPrefix(name, enqueueFailedName):
+		return failureCounter{Int64Counter: counter, counters: m.counters, failed: &m.counters.enqueueFailed}, nil
+	}
+	return counter, nil
+}
+
+// Int64ObservableGauge hands the exporter helper the queue size gauge wrapped
+// in a queueGauge, so the callbacks observing it can be told apart.
+func (m meter) Int64ObservableGauge(name string, opts ...metric.Int64ObservableGaugeOption) (metric.Int64ObservableGauge, error) {
+	gauge, err := m.Meter.Int64ObservableGauge(name, opts...)
+	if err != nil || strings.TrimPrefix(name, "otelcol_") != queueSizeName {
+		return gauge, err
+	}
+	m.counters.queueMu.Lock()
+	defer m.counters.queueMu.Unlock()
+	// Callbacks given as options only observe this gauge, and stay
+	// registered for as long as the meter provider.
+	for _, callback := range metric.NewInt64ObservableGaugeConfig(opts...).Callbacks() {
+		m.counters.queueReports[&queueReport{int64Callback: callback}] = struct{}{}
+	}
+	return &queueGauge{Int64ObservableGauge: gauge}, nil
+}
+
+// RegisterCallback registers f with the collector's meter for the
+// instruments it wraps. When f observes the queue size, it is also run by
+// counters until it is unregistered.
+func (m meter) RegisterCallback(f metric.Callback, instruments ...metric.Observable) (metric.Registration, error) {
+	unwrapped := make([]metric.Observable, len(instruments))
+	observesQueue := false
+	for i, instrument := range instruments {
+		if g, ok := instrument.(*queueGauge); ok {
+			instrument, observesQueue = g.Int64ObservableGauge, true
+		}
+		unwrapped[i] = instrument
+	}
+	reg, err := m.Meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		return f(ctx, unwrappingObserver{Observer: o})
+	}, unwrapped...)
+	if err != nil || !observesQueue {
+		return reg, err
+	}
+	r := &queueReport{callback: f}
+	m.counters.queueMu.Lock()
+	defer m.counters.queueMu.Unlock()
+	m.counters.queueReports[r] = struct{}{}
+	return registration{Registration: reg, counters: m.counters, report: r}, nil
+}
+
+// failureCounter counts the failures the exporter helper adds to a counter
+// of the collector.
+type failureCounter struct {
+	metric.Int64Counter
+	counters *counters
+	failed   *atomic.Int64
+}
+
+func (c failureCounter) Add(ctx context.Context, incr int64, opts ...metric.AddOption) {
+	c.Int64Counter.Add(ctx, incr, opts...)
+	if c.counters.owns(metric.NewAddConfig(opts).Attributes()) {
+		c.failed.Add(incr)
+	}
+}
+
+// queueGauge is the collector's queue size gauge, as handed to the exporter
+// helper.
+type queueGauge struct {
+	metric.Int64ObservableGauge
+}
+
+// queueReport is a callback observing the queue size, given either as an
+// option of the gauge or to RegisterCallback.
+type queueReport struct {
+	int64Callback metric.Int64Callback
+	callback      metric.Callback
+}
+
+func (r *queueReport) observe(ctx context.Context, o *queueObserver) error {
+	if r.int64Callback != nil {
+		return r.int64Callback(ctx, int64Observer{o: o})
+	}
+	return r.callback(ctx, o)
+}
+
+type registration struct {
+	metric.Registration
+	counters *counters
+	report   *queueReport
+}
+
+func (r registration) Unregister() error {
+	r.counters.queueMu.Lock()
+	delete(r.counters.queueReports, r.report)
+	r.counters.queueMu.Unlock()
+	return r.Registration.Unregister()
+}
+
+// unwrappingObserver hands the observations of a callback to the
+// collector's observer, for the instruments queue gauges wrap.
+type unwrappingObserver struct {
+	metric.Observer
+}
+
+func (o unwrappingObserver) ObserveInt64(obsrv metric.Int64Observable, value int64, opts ...metric.ObserveOption) {
+	if g, ok := obsrv.(*queueGauge); ok {
+		obsrv = g.Int64ObservableGauge
+	}
+	o.Observer.ObserveInt64(obsrv, value, opts...)
+}
+
+// queueObserver records the queue size a callback observes for the
+// exporter of counters, ignoring its other observations.
+type queueObserver struct {
+	embedded.Observer
+	counters *counters
+	size     int64
+	observed bool
+}
+
+func (o *queueObserver) ObserveFloat64(metric.Float64Observable, float64, ...metric.ObserveOption) {}
+
+func (o *queueObserver) ObserveInt64(obsrv metric.Int64Observable, value int64, opts ...metric.ObserveOption) {
+	if _, ok := obsrv.(*queueGauge); ok {
+		o.record(value, opts)
+	}
+}
+
+func (o *queueObserver) record(value int64, opts []metric.ObserveOption) {
+	if o.counters.owns(metric.NewObserveConfig(opts).Attributes()) {
+		o.size, o.observed = value, true
+	}
+}
+
+// int64Observer records the observations of a callback given as an option
+// of the queue size gauge.
+type int64Observer struct {
+	embedded.Int64Observer
+	o *queueObserver
+}
+
+func (o int64Observer) Observe(value int64, opts ...metric.ObserveOption) {
+	o.o.record(value, opts)
+}
@@ -0,0 +1,154 @@
+// Package selftelemetry logs the queue size and failures the collector reports
+// for each exporter, so failing exports can be diagnosed from the function's
+// logs. It is off unless EnvVar is set, and costs an atomic operation per
+// recorded failure when on.
+package selftelemetry
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// EnvVar names the variable that enables self-telemetry when set to a true
+// value.
+const EnvVar = "OCELOT_SELF_TELEMETRY"
+
+// logInterval is how often the counters of a running exporter are logged. The
+// timer doesn't fire while the environment is frozen; the counters are also
+// logged when the exporter shuts down.
+const logInterval = 10 * time.Second
+
+// Wrap returns factories with every exporter factory wrapped to log the
+// telemetry the collector's exporter helper records for its exporters: the
+// size of the sending queue (otelcol_exporter_queue_size) and the items it
+// failed to send, once retries were exhausted (otelcol_exporter_send_failed_*),
+// or to add to a full queue (otelcol_exporter_enqueue_failed_*). The
+// collector still reports these metrics through its own telemetry. Exporters
+// that don't use the exporter helper report nothing. factories is returned
+// unchanged unless EnvVar is set.
+func Wrap(factories otelcol.Factories) otelcol.Factories {
+	if !lambdaenv.Enabled(EnvVar) {
+		return factories
+	}
+	exporters := make(map[component.Type]exporter.Factory, len(factories.Exporters))
+	for typ, f := range factories.Exporters {
+		exporters[typ] = exporterFactory{Factory: f}
+	}
+	factories.Exporters = exporters
+	return factories
+}
+
+// counters holds what the exporter helper recorded for one exporter.
+type counters struct {
+	id            component.ID
+	logger        *zap.Logger
+	sendFailed    atomic.Int64
+	enqueueFailed atomic.Int64
+
+	// queueMu guards the callbacks reporting the queue size, and the last
+	// size they reported.
+	queueMu      sync.Mutex
+	queueReports map[*queueReport]struct{}
+	queueSize    int64
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func newCounters(set exporter.Settings) *counters {
+	return &counters{
+		id:           set.ID,
+		logger:       set.Logger,
+		queueReports: make(map[*queueReport]struct{}),
+		stop:         make(chan struct{}),
+	}
+}
+
+// settings returns set with its meter provider wrapped to record in c, for
+// the exporter c counts for.
+func (c *counters) settings(set exporter.Settings) exporter.Settings {
+	set.MeterProvider = meterProvider{MeterProvider: set.MeterProvider, counters: c}
+	return set
+}
+
+// owns reports whether a measurement with the given attributes was recorded
+// for c's exporter. The exporter helper names the exporter in the "exporter"
+// attribute; a secondary exporter created with the same settings, such as the
+// S3 fallback, records under its own ID.
+func (c *counters) owns(attrs attribute.Set) bool {
+	v, ok := attrs.Value("exporter")
+	return !ok || v.AsString() == c.id.String()
+}
+
+// observeQueue runs the callbacks reporting the queue size and returns the
+// size, or the last size reported once the queue has unregistered them.
+func (c *counters) observeQueue(ctx context.Context) int64 {
+	c.queueMu.Lock()
+	reports := make([]*queueReport, 0, len(c.queueReports))
+	for r := range c.queueReports {
+		reports = append(reports, r)
+	}
+	c.queueMu.Unlock()
+	for _, r := range reports {
+		o := &queueObserver{counters: c}
+		if err := r.observe(ctx, o); err != nil {
+			c.logger.Debug("Failed to observe the exporter queue size", zap.Error(err))
+			continue
+		}
+		if o.observed {
+			c.queueMu.Lock()
+			c.queueSize = o.size
+			c.queueMu.Unlock()
+		}
+	}
+	c.queueMu.Lock()
+	defer c.queueMu.Unlock()
+	return c.queueSize
+}
+
+func (c *counters) start() {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(logInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.log()
+			case <-c.stop:
+				return
+			}
+		}
+	}()
+}
+
+// shutdown stops the periodic logging and logs the counters a last time. It
+// is called once the exporter has shut down, so the failures of its final
+// drain are counted.
+func (c *counters) shutdown() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		c.wg.Wait()
+		c.log()
+	})
+}
+
+func (c *counters) log() {
+	c.logger.Info("Exporter self-telemetry",
+		zap.String("exporter", c.id.String()),
+		zap.Int64("queue_size", c.observeQueue(context.Background())),
+		zap.Int64("send_failed", c.sendFailed.Load()),
+		zap.Int64("enqueue_failed", c.enqueueFailed.Load()))
+}
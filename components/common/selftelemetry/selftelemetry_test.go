@@ -0,0 +1,174 @@
+package selftelemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var errExport = errors.New("export failed")
+
+type queuedConfig struct {
+	QueueConfig exporterhelper.QueueConfig `mapstructure:"sending_queue"`
+}
+
+// newFactory returns a traces exporter factory whose exporters queue the
+// batches handed to them in a queue of two, sent by a single consumer. Each
+// send signals started, then fails once release is closed.
+func newFactory(started chan<- struct{}, release <-chan struct{}) exporter.Factory {
+	return exporter.NewFactory(component.MustNewType("fake"),
+		func() component.Config {
+			cfg := exporterhelper.NewDefaultQueueConfig()
+			cfg.NumConsumers = 1
+			cfg.QueueSize = 2
+			return &queuedConfig{QueueConfig: cfg}
+		},
+		exporter.WithTraces(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			return exporterhelper.NewTraces(ctx, set, cfg, func(context.Context, ptrace.Traces) error {
+				started <- struct{}{}
+				<-release
+				return errExport
+			}, exporterhelper.WithQueue(cfg.(*queuedConfig).QueueConfig))
+		}, component.StabilityLevelDevelopment))
+}
+
+func newTraces(spans int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for range spans {
+		ss.AppendEmpty()
+	}
+	return td
+}
+
+// collected returns the value of each metric the collector reported.
+func collected(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() = %v", err)
+	}
+	values := make(map[string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					values[m.Name] += dp.Value
+				}
+			case metricdata.Gauge[int64]:
+				for _, dp := range data.DataPoints {
+					values[m.Name] += dp.Value
+				}
+			}
+		}
+	}
+	return values
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		wantWrapped bool
+	}{
+		{name: "unset"},
+		{name: "disabled", value: "false"},
+		{name: "enabled", value: "true", wantWrapped: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvVar, tt.value)
+			typ := component.MustNewType("fake")
+			factories := Wrap(otelcol.Factories{Exporters: map[component.Type]exporter.Factory{typ: newFactory(nil, nil)}})
+			if _, wrapped := factories.Exporters[typ].(exporterFactory); wrapped != tt.wantWrapped {
+				t.Errorf("exporter factory = %T, want it wrapped: %t", factories.Exporters[typ], tt.wantWrapped)
+			}
+		})
+	}
+}
+
+func TestSelfTelemetry(t *testing.T) {
+	t.Setenv(EnvVar, "true")
+	started := make(chan struct{}, 4)
+	release := make(chan struct{})
+	reader := sdkmetric.NewManualReader()
+	core, logs := observer.New(zap.InfoLevel)
+	set := exporter.Settings{
+		ID: component.MustNewID("fake"),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.New(core),
+			MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+	typ := component.MustNewType("fake")
+	f := Wrap(otelcol.Factories{Exporters: map[component.Type]exporter.Factory{typ: newFactory(started, release)}}).Exporters[typ]
+	exp, err := f.CreateTraces(context.Background(), set, f.CreateDefaultConfig())
+	if err != nil {
+		t.Fatalf("CreateTraces() = %v", err)
+	}
+	if err := exp.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+		t.Fatalf("Start() = %v", err)
+	}
+
+	// The first batch is being sent, the next two fill the queue and the
+	// last one, of 3 spans, doesn't fit.
+	if err := exp.ConsumeTraces(context.Background(), newTraces(2)); err != nil {
+		t.Fatalf("ConsumeTraces() = %v", err)
+	}
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("the first batch was never sent")
+	}
+	for range 2 {
+		if err := exp.ConsumeTraces(context.Background(), newTraces(2)); err != nil {
+			t.Fatalf("ConsumeTraces() = %v", err)
+		}
+	}
+	if err := exp.ConsumeTraces(context.Background(), newTraces(3)); err == nil {
+		t.Fatal("ConsumeTraces() = nil with a full queue")
+	}
+
+	exp.(tracesExporter).counters.log()
+	close(release)
+	if err := exp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() = %v", err)
+	}
+
+	logged := logs.FilterMessage("Exporter self-telemetry").All()
+	if len(logged) != 2 {
+		t.Fatalf("self-telemetry logged %d times, want 2", len(logged))
+	}
+	for i, want := range []map[string]int64{
+		{"queue_size": 2, "send_failed": 0, "enqueue_failed": 3},
+		// Every batch the queue held fails once it is released.
+		{"send_failed": 6, "enqueue_failed": 3},
+	} {
+		fields := logged[i].ContextMap()
+		for name, value := range want {
+			if fields[name] != value {
+				t.Errorf("log %d: %s = %v, want %d", i, name, fields[name], value)
+			}
+		}
+	}
+	// The collector still reports its own metrics.
+	if got := collected(t, reader)["otelcol_exporter_send_failed_spans"]; got != 6 {
+		t.Errorf("otelcol_exporter_send_failed_spans = %d, want 6", got)
+	}
+}
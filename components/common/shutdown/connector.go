@@ -0,0 +1,131 @@
+package shutdown
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+type connectorFactory struct {
+	connector.Factory
+	coordinator *Coordinator
+}
+
+func (f connectorFactory) CreateTracesToTraces(ctx context.Context, set connector.Settings, cfg component.Config, next consumer.Traces) (connector.Traces, error) {
+	c, err := f.Factory.CreateTracesToTraces(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := tracesConnector{Traces: c, once: &once{}}
+	f.coordinator.Add(Connector, "traces", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f connectorFactory) CreateTracesToMetrics(ctx context.Context, set connector.Settings, cfg component.Config, next consumer.Metrics) (connector.Traces, error) {
+	c, err := f.Factory.CreateTracesToMetrics(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := tracesConnector{Traces: c, once: &once{}}
+	f.coordinator.Add(Connector, "traces", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f connectorFactory) CreateTracesToLogs(ctx context.Context, set connector.Settings, cfg component.Config, next consumer.Logs) (connector.Traces, error) {
+	c, err := f.Factory.CreateTracesToLogs(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := tracesConnector{Traces: c, once: &once{}}
+	f.coordinator.Add(Connector, "traces", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f connectorFactory) CreateMetricsToTraces(ctx context.Context, set connector.Settings, cfg component.Config, next consumer.Traces) (connector.Metrics, error) {
+	c, err := f.Factory.CreateMetricsToTraces(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := metricsConnector{Metrics: c, once: &once{}}
+	f.coordinator.Add(Connector, "metrics", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f connectorFactory) CreateMetricsToMetrics(ctx context.Context, set connector.Settings, cfg component.Config, next consumer.Metrics) (connector.Metrics, error) {
+	c, err := f.Factory.CreateMetricsToMetrics(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := metricsConnector{Metrics: c, once: &once{}}
+	f.coordinator.Add(Connector, "metrics", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f connectorFactory) CreateMetricsToLogs(ctx context.Context, set connector.Settings, cfg component.Config, next consumer.Logs) (connector.Metrics, error) {
+	c, err := f.Factory.CreateMetricsToLogs(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := metricsConnector{Metrics: c, once: &once{}}
+	f.coordinator.Add(Connector, "metrics", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f connectorFactory) CreateLogsToTraces(ctx context.Context, set connector.Settings, cfg component.Config, next consumer.Traces) (connector.Logs, error) {
+	c, err := f.Factory.CreateLogsToTraces(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := logsConnector{Logs: c, once: &once{}}
+	f.coordinator.Add(Connector, "logs", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f connectorFactory) CreateLogsToMetrics(ctx context.Context, set connector.Settings, cfg component.Config, next consumer.Metrics) (connector.Logs, error) {
+	c, err := f.Factory.CreateLogsToMetrics(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := logsConnector{Logs: c, once: &once{}}
+	f.coordinator.Add(Connector, "logs", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f connectorFactory) CreateLogsToLogs(ctx context.Context, set connector.Settings, cfg component.Config, next consumer.Logs) (connector.Logs, error) {
+	c, err := f.Factory.CreateLogsToLogs(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := logsConnector{Logs: c, once: &once{}}
+	f.coordinator.Add(Connector, "logs", set.ID, tracked)
+	return tracked, nil
+}
+
+type tracesConnector struct {
+	connector.Traces
+	once *once
+}
+
+func (c tracesConnector) Shutdown(ctx context.Context) error {
+	return c.once.shutdown(ctx, c.Traces.Shutdown)
+}
+
+type metricsConnector struct {
+	connector.Metrics
+	once *once
+}
+
+func (c metricsConnector) Shutdown(ctx context.Context) error {
+	return c.once.shutdown(ctx, c.Metrics.Shutdown)
+}
+
+type logsConnector struct {
+	connector.Logs
+	once *once
+}
+
+func (c logsConnector) Shutdown(ctx context.Context) error {
+	return c.once.shutdown(ctx, c.Logs.Shutdown)
+}
@@ -0,0 +1,230 @@
+// Package shutdown drains the collector's components within the time Lambda
+// allows an extension after it receives the SHUTDOWN event.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// DefaultWindow is the time Lambda gives extensions to shut down after the
+// function's runtime has stopped, used when the event carries no deadline.
+const DefaultWindow = 2 * time.Second
+
+// margin is kept from the Lambda deadline for the extension to deregister and
+// exit once its components have drained.
+const margin = 100 * time.Millisecond
+
+// Deadline converts the deadlineMs field of a SHUTDOWN event, in milliseconds
+// since the Unix epoch, to the time components must have finished draining by.
+func Deadline(deadlineMs int64) time.Time {
+	if deadlineMs <= 0 {
+		return time.Now().Add(DefaultWindow - margin)
+	}
+	return time.UnixMilli(deadlineMs).Add(-margin)
+}
+
+// Kind is the kind of a tracked component. Without pipelines, components are
+// shut down in the order of their kinds.
+type Kind int
+
+const (
+	Receiver Kind = iota
+	Processor
+	Connector
+	Exporter
+)
+
+// Coordinator shuts down the components it tracks within a shared deadline.
+type Coordinator struct {
+	mu           sync.Mutex
+	components   []tracked
+	ranks        map[node]int
+	policy       Policy
+	inflight     map[uint64]inflight
+	nextInflight uint64
+	// newClient creates the S3 client the persist policy spills with, or
+	// newSpillClient when nil.
+	newClient func(ctx context.Context) (spillClient, error)
+}
+
+type tracked struct {
+	node      node
+	id        component.ID
+	component component.Component
+}
+
+// node identifies a component in the pipelines. Receivers, processors and
+// exporters are created once per signal; a connector is a single node joining
+// the pipelines it exports from to those it receives into.
+type node struct {
+	kind   Kind
+	signal string
+	id     string
+}
+
+// New returns a coordinator that tracks no component yet.
+func New() *Coordinator {
+	return &Coordinator{}
+}
+
+// SetPolicy selects what Shutdown does with data the components haven't
+// drained.
+func (c *Coordinator) SetPolicy(policy Policy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.policy = policy
+}
+
+// Add tracks a component of the given kind that the collector created under
+// the given ID for a pipeline of signal ("traces", "metrics" or "logs"). For
+// connectors, signal is the signal they receive.
+func (c *Coordinator) Add(kind Kind, signal string, id component.ID, comp component.Component) {
+	n := node{kind: kind, signal: signal, id: id.String()}
+	if kind == Connector {
+		n.signal = ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.components = append(c.components, tracked{node: n, id: id, component: comp})
+}
+
+// Shutdown shuts down the tracked components in the order data flows through
+// them and returns when all of them have returned or the deadline has passed.
+// A component is only shut down once every component that sends it data has
+// returned, so what they flush on Shutdown, such as the metrics a connector
+// derived, reaches it; components that don't depend on each other shut down
+// concurrently. The selected Policy decides how long components may drain.
+// The error names the components that failed and those that were still
+// draining, or hadn't been shut down yet, at the deadline.
+func (c *Coordinator) Shutdown(ctx context.Context, deadline time.Time) error {
+	c.mu.Lock()
+	policy := c.policy
+	c.mu.Unlock()
+
+	drainBy := deadline
+	switch policy {
+	case Block:
+		deadline = deadline.Add(margin)
+		drainBy = deadline
+	case Persist:
+		drainBy = deadline.Add(-persistReserve)
+	}
+	waitCtx, cancel := context.WithDeadline(ctx, drainBy)
+	defer cancel()
+	// Components are given the context they drain with. Under the drop
+	// policy it is already done, so they return without draining, but the
+	// coordinator still waits for them to return until the deadline.
+	shutdownCtx := waitCtx
+	if policy == Drop {
+		var cancelShutdown context.CancelFunc
+		shutdownCtx, cancelShutdown = context.WithCancel(ctx)
+		cancelShutdown()
+	}
+
+	stages := c.stages()
+	// pending holds the components that haven't returned. The traces,
+	// metrics and logs instances of a component share its ID, so they are
+	// held separately.
+	var (
+		mu      sync.Mutex
+		errs    []error
+		pending = make(map[*tracked]struct{})
+	)
+	for _, stage := range stages {
+		for i := range stage {
+			pending[&stage[i]] = struct{}{}
+		}
+	}
+	for _, stage := range stages {
+		if waitCtx.Err() != nil {
+			break
+		}
+		var wg sync.WaitGroup
+		for i := range stage {
+			t := &stage[i]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := t.component.Shutdown(shutdownCtx)
+				mu.Lock()
+				defer mu.Unlock()
+				delete(pending, t)
+				if err != nil && policy != Drop {
+					errs = append(errs, fmt.Errorf("failed to shut down %s: %w", t.id, err))
+				}
+			}()
+		}
+
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-waitCtx.Done():
+		}
+	}
+
+	if policy == Persist {
+		spillCtx, cancelSpill := context.WithDeadline(ctx, deadline)
+		defer cancelSpill()
+		if err := c.spill(spillCtx); err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(pending) > 0 {
+		ids := make([]string, 0, len(pending))
+		for t := range pending {
+			if id := t.id.String(); !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		errs = append(errs, fmt.Errorf("shutdown deadline reached before %v drained", ids))
+	}
+	return errors.Join(errs...)
+}
+
+// stages groups the tracked components by rank, in shutdown order. Components
+// missing from the pipelines, or all of them when no pipelines were set, are
+// ranked by kind after those in the pipelines.
+func (c *Coordinator) stages() [][]tracked {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	last := -1
+	for _, rank := range c.ranks {
+		last = max(last, rank)
+	}
+	byRank := make(map[int][]tracked)
+	for _, t := range c.components {
+		rank, ok := c.ranks[t.node]
+		if !ok {
+			rank = last + 1 + int(t.node.kind)
+		}
+		byRank[rank] = append(byRank[rank], t)
+	}
+	stages := make([][]tracked, 0, len(byRank))
+	ranks := make([]int, 0, len(byRank))
+	for rank := range byRank {
+		ranks = append(ranks, rank)
+	}
+	slices.Sort(ranks)
+	for _, rank := range ranks {
+		stages = append(stages, byRank[rank])
+	}
+	return stages
+}
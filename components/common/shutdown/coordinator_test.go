@@ -0,0 +1,125 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// fakeComponent shuts down with the given function.
+type fakeComponent struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+// blocking shuts down once its context is done, as an exporter that can't
+// reach its backend does, and records the deadline it was given.
+func blocking(deadline *time.Time) component.ShutdownFunc {
+	return func(ctx context.Context) error {
+		*deadline, _ = ctx.Deadline()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	eventDeadline := time.Now().Add(time.Second).Truncate(time.Millisecond)
+	tests := []struct {
+		name       string
+		deadlineMs int64
+		want       time.Time
+	}{
+		{name: "from the event", deadlineMs: eventDeadline.UnixMilli(), want: eventDeadline.Add(-margin)},
+		{name: "missing", want: time.Now().Add(DefaultWindow - margin)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Deadline(tt.deadlineMs); got.Sub(tt.want).Abs() > 50*time.Millisecond {
+				t.Errorf("Deadline(%d) = %v, want %v", tt.deadlineMs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoordinatorShutdown(t *testing.T) {
+	var blockedUntil time.Time
+	tests := []struct {
+		name     string
+		shutdown component.ShutdownFunc
+		wantErr  string
+	}{
+		{
+			name:     "drained",
+			shutdown: func(context.Context) error { return nil },
+		},
+		{
+			name:     "failed",
+			shutdown: func(context.Context) error { return errors.New("connection refused") },
+			wantErr:  "failed to shut down otlp/test: connection refused",
+		},
+		{
+			name:     "blocking",
+			shutdown: blocking(&blockedUntil),
+			wantErr:  "shutdown deadline reached before [otlp/test] drained",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			c.Add(Exporter, "traces", component.MustNewIDWithName("otlp", "test"), fakeComponent{ShutdownFunc: tt.shutdown})
+
+			deadline := time.Now().Add(100 * time.Millisecond)
+			err := c.Shutdown(context.Background(), deadline)
+			if returned := time.Now(); returned.After(deadline.Add(50 * time.Millisecond)) {
+				t.Errorf("Shutdown() returned %v after the deadline", returned.Sub(deadline))
+			}
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Shutdown() = %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Shutdown() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+	if blockedUntil.IsZero() {
+		t.Error("the blocking exporter was shut down without a deadline")
+	}
+}
+
+// An exporter in pipelines of two signals has an instance for each, sharing
+// its ID.
+func TestCoordinatorShutdownSiblingInstances(t *testing.T) {
+	var blockedUntil time.Time
+	id := component.MustNewIDWithName("otlp", "test")
+	c := New()
+	c.Add(Exporter, "traces", id, fakeComponent{ShutdownFunc: func(context.Context) error { return nil }})
+	c.Add(Exporter, "metrics", id, fakeComponent{ShutdownFunc: blocking(&blockedUntil)})
+
+	err := c.Shutdown(context.Background(), time.Now().Add(100*time.Millisecond))
+	if want := "shutdown deadline reached before [otlp/test] drained"; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Shutdown() = %v, want an error containing %q", err, want)
+	}
+}
+
+// The collector service shuts the components down again after the
+// coordinator has.
+func TestShutdownOnce(t *testing.T) {
+	calls := 0
+	o := &once{}
+	shutdown := func(context.Context) error {
+		calls++
+		return errors.New("connection refused")
+	}
+	for range 2 {
+		if err := o.shutdown(context.Background(), shutdown); err == nil {
+			t.Error("shutdown() = nil, want the error of the first call")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("the component was shut down %d times, want once", calls)
+	}
+}
@@ -0,0 +1,86 @@
+package shutdown
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+type pipeline struct {
+	signal     string
+	receivers  []string
+	processors []string
+	exporters  []string
+}
+
+// SetPipelines orders the shutdown of the tracked components by the pipelines
+// of the resolved collector configuration conf: a component is ranked after
+// every component that sends it data, following the pipelines through the
+// connectors that join them.
+func (c *Coordinator) SetPipelines(conf *confmap.Conf) {
+	connectors, _ := conf.Get("connectors").(map[string]any)
+	declared, _ := conf.Get("service::pipelines").(map[string]any)
+	var pipelines []pipeline
+	for name, v := range declared {
+		signal, _, _ := strings.Cut(name, "/")
+		p, _ := v.(map[string]any)
+		pipelines = append(pipelines, pipeline{
+			signal:     signal,
+			receivers:  ids(p["receivers"]),
+			processors: ids(p["processors"]),
+			exporters:  ids(p["exporters"]),
+		})
+	}
+
+	nodeOf := func(kind Kind, signal, id string) node {
+		if _, ok := connectors[id]; ok {
+			return node{kind: Connector, id: id}
+		}
+		return node{kind: kind, signal: signal, id: id}
+	}
+	ranks := make(map[node]int)
+	// raise sets the rank of n to at least rank, and reports whether it changed.
+	raise := func(n node, rank int) bool {
+		if current, ok := ranks[n]; ok && current >= rank {
+			return false
+		}
+		ranks[n] = rank
+		return true
+	}
+	// Connectors can't form cycles, so the ranks settle after at most one pass
+	// per pipeline.
+	for changed, pass := true, 0; changed && pass <= len(pipelines); pass++ {
+		changed = false
+		for _, p := range pipelines {
+			next := 0
+			for _, id := range p.receivers {
+				n := nodeOf(Receiver, p.signal, id)
+				changed = raise(n, 0) || changed
+				next = max(next, ranks[n]+1)
+			}
+			for _, id := range p.processors {
+				n := node{kind: Processor, signal: p.signal, id: id}
+				changed = raise(n, next) || changed
+				next = ranks[n] + 1
+			}
+			for _, id := range p.exporters {
+				changed = raise(nodeOf(Exporter, p.signal, id), next) || changed
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ranks = ranks
+}
+
+func ids(v any) []string {
+	list, _ := v.([]any)
+	ids := make([]string, 0, len(list))
+	for _, item := range list {
+		if id, ok := item.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
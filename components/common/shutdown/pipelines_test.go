@@ -0,0 +1,102 @@
+package shutdown
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// testComponent is a component the test adds to the coordinator.
+type testComponent struct {
+	kind   Kind
+	signal string
+	id     string
+}
+
+func TestSetPipelines(t *testing.T) {
+	// otlp -> forward -> batch -> spanmetrics -> otlphttp, through two
+	// connectors. Without the pipelines, the batch processor would shut down
+	// before the forward connector that sends it data.
+	pipelines := map[string]any{
+		"connectors": map[string]any{"forward": nil, "spanmetrics": nil},
+		"service": map[string]any{"pipelines": map[string]any{
+			"traces":   map[string]any{"receivers": []any{"otlp"}, "exporters": []any{"forward"}},
+			"traces/2": map[string]any{"receivers": []any{"forward"}, "processors": []any{"batch"}, "exporters": []any{"spanmetrics"}},
+			"metrics":  map[string]any{"receivers": []any{"spanmetrics"}, "exporters": []any{"otlphttp"}},
+		}},
+	}
+	// The components are added in the order the collector creates them:
+	// exporters first.
+	tests := []struct {
+		name       string
+		pipelines  map[string]any
+		components []testComponent
+		want       []string
+	}{
+		{
+			name:      "pipelines",
+			pipelines: pipelines,
+			components: []testComponent{
+				{kind: Exporter, signal: "metrics", id: "otlphttp"},
+				{kind: Connector, signal: "traces", id: "spanmetrics"},
+				{kind: Processor, signal: "traces", id: "batch"},
+				{kind: Connector, signal: "traces", id: "forward"},
+				{kind: Receiver, signal: "traces", id: "otlp"},
+			},
+			want: []string{"otlp", "forward", "batch", "spanmetrics", "otlphttp received", "otlphttp"},
+		},
+		{
+			name: "kinds",
+			components: []testComponent{
+				{kind: Exporter, signal: "metrics", id: "otlphttp"},
+				{kind: Connector, signal: "traces", id: "spanmetrics"},
+				{kind: Processor, signal: "traces", id: "batch"},
+				{kind: Receiver, signal: "traces", id: "otlp"},
+			},
+			want: []string{"otlp", "batch", "spanmetrics", "otlphttp received", "otlphttp"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu  sync.Mutex
+				got []string
+			)
+			record := func(event string) {
+				mu.Lock()
+				defer mu.Unlock()
+				got = append(got, event)
+			}
+			c := New()
+			for _, comp := range tt.components {
+				c.Add(comp.kind, comp.signal, component.MustNewID(comp.id), fakeComponent{ShutdownFunc: func(context.Context) error {
+					// Every component is slow to drain, so one shutting
+					// down too early would be seen before those it
+					// depends on have returned.
+					time.Sleep(10 * time.Millisecond)
+					if comp.id == "spanmetrics" {
+						// The connector flushes the metrics it derived.
+						record("otlphttp received")
+					}
+					record(comp.id)
+					return nil
+				}})
+			}
+			if tt.pipelines != nil {
+				c.SetPipelines(confmap.NewFromStringMap(tt.pipelines))
+			}
+
+			if err := c.Shutdown(context.Background(), time.Now().Add(time.Second)); err != nil {
+				t.Fatalf("Shutdown() = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("shutdown order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package shutdown
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// PolicyEnvVar names the variable selecting what happens to data the
+// exporters haven't sent when the environment shuts down.
+const PolicyEnvVar = "OCELOT_ON_FREEZE"
+
+// SpillBucketEnvVar names the variable holding the bucket the persist policy
+// spills to. It is the bucket of the S3 fallback.
+const SpillBucketEnvVar = "OCELOT_S3_FALLBACK_BUCKET"
+
+// Policy is what the coordinator does with data the components haven't
+// drained.
+type Policy int
+
+const (
+	// Drain waits for the components to drain until the deadline, keeping a
+	// margin for the extension to exit. It is the default.
+	Drain Policy = iota
+	// Drop shuts the components down without waiting for them to drain: what
+	// they hold is discarded.
+	Drop
+	// Persist drains until persistReserve before the deadline, then writes
+	// the data exporters are still sending to the S3 bucket in
+	// SpillBucketEnvVar, as OTLP JSON. Data an exporter had partly sent may be
+	// written too. The sending queue of the exporters is turned off, so the
+	// data they haven't sent is still being handed to them.
+	Persist
+	// Block waits for the components to drain until the Lambda deadline
+	// itself, giving up the margin kept for the extension to exit.
+	Block
+)
+
+// persistReserve is kept from the deadline by the persist policy to write the
+// data exporters are still sending.
+const persistReserve = 300 * time.Millisecond
+
+// PolicyFromEnv returns the policy selected in PolicyEnvVar: "drop",
+// "persist" or "block", or Drain when it is unset. Persist requires
+// SpillBucketEnvVar.
+func PolicyFromEnv() (Policy, error) {
+	switch v := strings.TrimSpace(os.Getenv(PolicyEnvVar)); v {
+	case "":
+		return Drain, nil
+	case "drop":
+		return Drop, nil
+	case "persist":
+		if os.Getenv(SpillBucketEnvVar) == "" {
+			return Drain, fmt.Errorf("%s=persist requires %s to name the bucket to spill to", PolicyEnvVar, SpillBucketEnvVar)
+		}
+		return Persist, nil
+	case "block":
+		return Block, nil
+	default:
+		return Drain, fmt.Errorf("%s must be drop, persist or block, got %q", PolicyEnvVar, v)
+	}
+}
@@ -0,0 +1,103 @@
+package shutdown
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestPolicyFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		bucket  string
+		want    Policy
+		wantErr string
+	}{
+		{name: "unset", want: Drain},
+		{name: "drop", policy: "drop", want: Drop},
+		{name: "block", policy: "block", want: Block},
+		{name: "persist", policy: "persist", bucket: "spill", want: Persist},
+		{name: "persist without a bucket", policy: "persist", wantErr: "OCELOT_ON_FREEZE=persist requires OCELOT_S3_FALLBACK_BUCKET"},
+		{name: "unknown", policy: "wait", wantErr: `OCELOT_ON_FREEZE must be drop, persist or block, got "wait"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(PolicyEnvVar, tt.policy)
+			t.Setenv(SpillBucketEnvVar, tt.bucket)
+			got, err := PolicyFromEnv()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("PolicyFromEnv() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("PolicyFromEnv() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+// shutdownCall is what a component was given to shut down with.
+type shutdownCall struct {
+	deadline time.Time
+	canceled bool
+}
+
+func TestShutdownPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		// wantDeadline is how long after the Lambda deadline the exporter
+		// may drain, unless it is dropped.
+		wantDeadline time.Duration
+		wantDropped  bool
+	}{
+		{name: "drain", policy: Drain},
+		{name: "drop", policy: Drop, wantDropped: true},
+		{name: "block", policy: Block, wantDeadline: margin},
+		// Nothing is in flight, so nothing is spilled.
+		{name: "persist", policy: Persist, wantDeadline: -persistReserve},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := make(chan shutdownCall, 1)
+			c := New()
+			c.SetPolicy(tt.policy)
+			// A slow exporter, which drains for as long as it is allowed
+			// to.
+			c.Add(Exporter, "traces", component.MustNewID("otlp"), fakeComponent{ShutdownFunc: func(ctx context.Context) error {
+				deadline, _ := ctx.Deadline()
+				calls <- shutdownCall{deadline: deadline, canceled: ctx.Err() != nil}
+				<-ctx.Done()
+				return ctx.Err()
+			}})
+
+			deadline := time.Now().Add(400 * time.Millisecond)
+			err := c.Shutdown(context.Background(), deadline)
+			returned := time.Now()
+			call := <-calls
+			if tt.wantDropped {
+				// What the exporter held is discarded, with no error.
+				if err != nil || !call.canceled {
+					t.Errorf("Shutdown() = %v, canceled = %v, want the exporter stopped without draining", err, call.canceled)
+				}
+				if returned.After(deadline.Add(-300 * time.Millisecond)) {
+					t.Errorf("Shutdown() returned %v before the deadline, want it to return at once", deadline.Sub(returned))
+				}
+				return
+			}
+			want := deadline.Add(tt.wantDeadline)
+			if call.canceled || !call.deadline.Equal(want) {
+				t.Errorf("the exporter was given until %v (canceled: %v), want %v", call.deadline, call.canceled, want)
+			}
+			if returned.After(want.Add(50 * time.Millisecond)) {
+				t.Errorf("Shutdown() returned %v after the exporter's deadline", returned.Sub(want))
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package shutdown
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+)
+
+type processorFactory struct {
+	processor.Factory
+	coordinator *Coordinator
+}
+
+func (f processorFactory) CreateTraces(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+	c, err := f.Factory.CreateTraces(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := tracesProcessor{Traces: c, once: &once{}}
+	f.coordinator.Add(Processor, "traces", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f processorFactory) CreateMetrics(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Metrics) (processor.Metrics, error) {
+	c, err := f.Factory.CreateMetrics(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := metricsProcessor{Metrics: c, once: &once{}}
+	f.coordinator.Add(Processor, "metrics", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f processorFactory) CreateLogs(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+	c, err := f.Factory.CreateLogs(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := logsProcessor{Logs: c, once: &once{}}
+	f.coordinator.Add(Processor, "logs", set.ID, tracked)
+	return tracked, nil
+}
+
+type tracesProcessor struct {
+	processor.Traces
+	once *once
+}
+
+func (c tracesProcessor) Shutdown(ctx context.Context) error {
+	return c.once.shutdown(ctx, c.Traces.Shutdown)
+}
+
+type metricsProcessor struct {
+	processor.Metrics
+	once *once
+}
+
+func (c metricsProcessor) Shutdown(ctx context.Context) error {
+	return c.once.shutdown(ctx, c.Metrics.Shutdown)
+}
+
+type logsProcessor struct {
+	processor.Logs
+	once *once
+}
+
+func (c logsProcessor) Shutdown(ctx context.Context) error {
+	return c.once.shutdown(ctx, c.Logs.Shutdown)
+}
@@ -0,0 +1,71 @@
+package shutdown
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+type receiverFactory struct {
+	receiver.Factory
+	coordinator *Coordinator
+}
+
+func (f receiverFactory) CreateTraces(ctx context.Context, set receiver.Settings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+	c, err := f.Factory.CreateTraces(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := tracesReceiver{Traces: c, once: &once{}}
+	f.coordinator.Add(Receiver, "traces", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f receiverFactory) CreateMetrics(ctx context.Context, set receiver.Settings, cfg component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+	c, err := f.Factory.CreateMetrics(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := metricsReceiver{Metrics: c, once: &once{}}
+	f.coordinator.Add(Receiver, "metrics", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f receiverFactory) CreateLogs(ctx context.Context, set receiver.Settings, cfg component.Config, next consumer.Logs) (receiver.Logs, error) {
+	c, err := f.Factory.CreateLogs(ctx, set, cfg, next)
+	if err != nil {
+		return nil, err
+	}
+	tracked := logsReceiver{Logs: c, once: &once{}}
+	f.coordinator.Add(Receiver, "logs", set.ID, tracked)
+	return tracked, nil
+}
+
+type tracesReceiver struct {
+	receiver.Traces
+	once *once
+}
+
+func (c tracesReceiver) Shutdown(ctx context.Context) error {
+	return c.once.shutdown(ctx, c.Traces.Shutdown)
+}
+
+type metricsReceiver struct {
+	receiver.Metrics
+	once *once
+}
+
+func (c metricsReceiver) Shutdown(ctx context.Context) error {
+	return c.once.shutdown(ctx, c.Metrics.Shutdown)
+}
+
+type logsReceiver struct {
+	receiver.Logs
+	once *once
+}
+
+func (c logsReceiver) Shutdown(ctx context.Context) error {
+	return c.once.shutdown(ctx, c.Logs.Shutdown)
+}
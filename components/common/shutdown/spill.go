@@ -0,0 +1,119 @@
+package shutdown
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/defaults"
+	"go.opentelemetry.io/collector/component"
+)
+
+// spillClient is the part of the S3 API the persist policy uses.
+type spillClient interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+func newSpillClient(ctx context.Context) (spillClient, error) {
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s3.NewFromConfig(awsCfg), nil
+}
+
+// inflight is data handed to an exporter that hasn't returned yet.
+type inflight struct {
+	id      component.ID
+	signal  string
+	marshal func() ([]byte, error)
+}
+
+// prepare turns off the sending queue of an exporter about to be created
+// under the persist policy. Data waiting in a queue is out of reach when the
+// environment shuts down, while data handed to an exporter that hasn't
+// returned yet is spilled.
+func (c *Coordinator) prepare(id component.ID, cfg component.Config) error {
+	c.mu.Lock()
+	policy := c.policy
+	c.mu.Unlock()
+	if policy != Persist {
+		return nil
+	}
+	if err := defaults.DisableSendingQueue(cfg); err != nil {
+		return fmt.Errorf("failed to disable the sending queue of %s for %s=persist: %w", id, PolicyEnvVar, err)
+	}
+	return nil
+}
+
+// begin records data handed to an exporter while the persist policy is
+// selected, and returns the function to call when the exporter returns.
+func (c *Coordinator) begin(id component.ID, signal string, marshal func() ([]byte, error)) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.policy != Persist {
+		return func() {}
+	}
+	c.nextInflight++
+	n := c.nextInflight
+	if c.inflight == nil {
+		c.inflight = make(map[uint64]inflight)
+	}
+	c.inflight[n] = inflight{id: id, signal: signal, marshal: marshal}
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.inflight, n)
+	}
+}
+
+// spill writes the data exporters are still sending to the spill bucket, one
+// object per export under ocelot-spill/<start time>/.
+func (c *Coordinator) spill(ctx context.Context) error {
+	c.mu.Lock()
+	pending := make([]inflight, 0, len(c.inflight))
+	for _, data := range c.inflight {
+		pending = append(pending, data)
+	}
+	c.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	bucket := os.Getenv(SpillBucketEnvVar)
+	newClient := c.newClient
+	if newClient == nil {
+		newClient = newSpillClient
+	}
+	client, err := newClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to spill unsent data: %w", err)
+	}
+	prefix := path.Join("ocelot-spill", time.Now().UTC().Format("20060102T150405.000Z"))
+	var errs []error
+	for i, data := range pending {
+		body, err := data.marshal()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to spill unsent %s of %s: %w", data.signal, data.id, err))
+			continue
+		}
+		key := path.Join(prefix, data.id.String(), data.signal+"-"+strconv.Itoa(i)+".json")
+		if _, err := client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:      aws.String(bucket),
+			Key:         aws.String(key),
+			Body:        bytes.NewReader(body),
+			ContentType: aws.String("application/json"),
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to spill unsent %s of %s: %w", data.signal, data.id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,134 @@
+package shutdown
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// fakeSpillClient records the objects put to it, by key.
+type fakeSpillClient struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (c *fakeSpillClient) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	body, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.objects == nil {
+		c.objects = make(map[string][]byte)
+	}
+	c.objects[aws.ToString(params.Key)] = body
+	return &s3.PutObjectOutput{}, nil
+}
+
+// queuedConfig is the configuration of an exporter with a sending queue, like
+// otlp and otlphttp.
+type queuedConfig struct {
+	QueueConfig exporterhelper.QueueConfig `mapstructure:"sending_queue"`
+}
+
+// slowQueuedFactory returns a traces exporter factory whose exporters queue
+// the data they are handed, with the upstream default queue, and only send it
+// once release is closed.
+func slowQueuedFactory(release <-chan struct{}) exporter.Factory {
+	return exporter.NewFactory(component.MustNewType("otlp"),
+		func() component.Config { return &queuedConfig{QueueConfig: exporterhelper.NewDefaultQueueConfig()} },
+		exporter.WithTraces(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			return exporterhelper.NewTraces(ctx, set, cfg, func(context.Context, ptrace.Traces) error {
+				<-release
+				return nil
+			}, exporterhelper.WithQueue(cfg.(*queuedConfig).QueueConfig))
+		}, component.StabilityLevelDevelopment))
+}
+
+func TestPersistSpillsQueuedExporter(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    Policy
+		wantQueue bool
+		wantSpill int
+	}{
+		{name: "persist", policy: Persist, wantSpill: 1},
+		{name: "drain", policy: Drain, wantQueue: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(SpillBucketEnvVar, "spill")
+			release := make(chan struct{})
+			defer close(release)
+			client := &fakeSpillClient{}
+			c := New()
+			c.SetPolicy(tt.policy)
+			c.newClient = func(context.Context) (spillClient, error) { return client, nil }
+
+			otlp := component.MustNewType("otlp")
+			factories := Track(otelcol.Factories{Exporters: map[component.Type]exporter.Factory{otlp: slowQueuedFactory(release)}}, c)
+			f := factories.Exporters[otlp]
+			cfg := f.CreateDefaultConfig().(*queuedConfig)
+			set := exporter.Settings{
+				ID: component.NewID(otlp),
+				TelemetrySettings: component.TelemetrySettings{
+					Logger:         zap.NewNop(),
+					MeterProvider:  metricnoop.NewMeterProvider(),
+					TracerProvider: tracenoop.NewTracerProvider(),
+				},
+				BuildInfo: component.NewDefaultBuildInfo(),
+			}
+			exp, err := f.CreateTraces(context.Background(), set, cfg)
+			if err != nil {
+				t.Fatalf("CreateTraces() = %v", err)
+			}
+			if cfg.QueueConfig.Enabled != tt.wantQueue {
+				t.Errorf("sending_queue enabled = %t, want %t", cfg.QueueConfig.Enabled, tt.wantQueue)
+			}
+			if err := exp.Start(context.Background(), componenttest.NewNopHost()); err != nil {
+				t.Fatalf("Start() = %v", err)
+			}
+
+			td := ptrace.NewTraces()
+			td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("checkout")
+			go func() { _ = exp.ConsumeTraces(context.Background(), td) }()
+			// Let the export reach the backend, which doesn't answer.
+			time.Sleep(50 * time.Millisecond)
+
+			_ = c.Shutdown(context.Background(), time.Now().Add(500*time.Millisecond))
+			client.mu.Lock()
+			defer client.mu.Unlock()
+			if len(client.objects) != tt.wantSpill {
+				t.Fatalf("%d objects spilled, want %d", len(client.objects), tt.wantSpill)
+			}
+			for key, body := range client.objects {
+				if !strings.HasSuffix(key, "/otlp/traces-0.json") {
+					t.Errorf("spilled to %q, want a key ending in /otlp/traces-0.json", key)
+				}
+				spilled, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(body)
+				if err != nil {
+					t.Fatalf("UnmarshalTraces() = %v", err)
+				}
+				if spilled.SpanCount() != 1 {
+					t.Errorf("%d spans spilled, want 1", spilled.SpanCount())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package shutdown
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// Track returns factories with every receiver, processor, connector and
+// exporter factory wrapped so the components the collector creates from them
+// are added to c. The tracked components only shut down once: when the service
+// shuts them down after the coordinator has, it gets the result of the first
+// call. Extensions aren't tracked: wrapping them would hide the interfaces
+// other components look them up by, and the service shuts them down last.
+func Track(factories otelcol.Factories, c *Coordinator) otelcol.Factories {
+	receivers := make(map[component.Type]receiver.Factory, len(factories.Receivers))
+	for typ, f := range factories.Receivers {
+		receivers[typ] = receiverFactory{Factory: f, coordinator: c}
+	}
+	processors := make(map[component.Type]processor.Factory, len(factories.Processors))
+	for typ, f := range factories.Processors {
+		processors[typ] = processorFactory{Factory: f, coordinator: c}
+	}
+	connectors := make(map[component.Type]connector.Factory, len(factories.Connectors))
+	for typ, f := range factories.Connectors {
+		connectors[typ] = connectorFactory{Factory: f, coordinator: c}
+	}
+	exporters := make(map[component.Type]exporter.Factory, len(factories.Exporters))
+	for typ, f := range factories.Exporters {
+		exporters[typ] = exporterFactory{Factory: f, coordinator: c}
+	}
+	factories.Receivers = receivers
+	factories.Processors = processors
+	factories.Connectors = connectors
+	factories.Exporters = exporters
+	return factories
+}
+
+type exporterFactory struct {
+	exporter.Factory
+	coordinator *Coordinator
+}
+
+func (f exporterFactory) CreateTraces(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+	if err := f.coordinator.prepare(set.ID, cfg); err != nil {
+		return nil, err
+	}
+	exp, err := f.Factory.CreateTraces(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	tracked := tracesExporter{Traces: exp, once: &once{}, id: set.ID, coordinator: f.coordinator}
+	f.coordinator.Add(Exporter, "traces", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f exporterFactory) CreateMetrics(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+	if err := f.coordinator.prepare(set.ID, cfg); err != nil {
+		return nil, err
+	}
+	exp, err := f.Factory.CreateMetrics(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	tracked := metricsExporter{Metrics: exp, once: &once{}, id: set.ID, coordinator: f.coordinator}
+	f.coordinator.Add(Exporter, "metrics", set.ID, tracked)
+	return tracked, nil
+}
+
+func (f exporterFactory) CreateLogs(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+	if err := f.coordinator.prepare(set.ID, cfg); err != nil {
+		return nil, err
+	}
+	exp, err := f.Factory.CreateLogs(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	tracked := logsExporter{Logs: exp, once: &once{}, id: set.ID, coordinator: f.coordinator}
+	f.coordinator.Add(Exporter, "logs", set.ID, tracked)
+	return tracked, nil
+}
+
+type tracesExporter struct {
+	exporter.Traces
+	once        *once
+	id          component.ID
+	coordinator *Coordinator
+}
+
+func (e tracesExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	end := e.coordinator.begin(e.id, "traces", func() ([]byte, error) {
+		return (&ptrace.JSONMarshaler{}).MarshalTraces(td)
+	})
+	defer end()
+	return e.Traces.ConsumeTraces(ctx, td)
+}
+
+func (e tracesExporter) Shutdown(ctx context.Context) error {
+	return e.once.shutdown(ctx, e.Traces.Shutdown)
+}
+
+type metricsExporter struct {
+	exporter.Metrics
+	once        *once
+	id          component.ID
+	coordinator *Coordinator
+}
+
+func (e metricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	end := e.coordinator.begin(e.id, "metrics", func() ([]byte, error) {
+		return (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	})
+	defer end()
+	return e.Metrics.ConsumeMetrics(ctx, md)
+}
+
+func (e metricsExporter) Shutdown(ctx context.Context) error {
+	return e.once.shutdown(ctx, e.Metrics.Shutdown)
+}
+
+type logsExporter struct {
+	exporter.Logs
+	once        *once
+	id          component.ID
+	coordinator *Coordinator
+}
+
+func (e logsExporter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	end := e.coordinator.begin(e.id, "logs", func() ([]byte, error) {
+		return (&plog.JSONMarshaler{}).MarshalLogs(ld)
+	})
+	defer end()
+	return e.Logs.ConsumeLogs(ctx, ld)
+}
+
+func (e logsExporter) Shutdown(ctx context.Context) error {
+	return e.once.shutdown(ctx, e.Logs.Shutdown)
+}
+
+// once runs a component's Shutdown a single time and replays its result.
+type once struct {
+	once sync.Once
+	err  error
+}
+
+func (o *once) shutdown(ctx context.Context, shutdown component.ShutdownFunc) error {
+	o.once.Do(func() { o.err = shutdown(ctx) })
+	return o.err
+}
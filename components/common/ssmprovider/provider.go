@@ -0,0 +1,99 @@
+// Package ssmprovider resolves collector configuration from AWS Systems
+// Manager Parameter Store. A URI of the form ssm:<parameter name> is replaced
+// by the parameter's value, parsed as YAML, so it can hold a single value or a
+// whole configuration fragment:
+//
+//	exporters:
+//	  otlphttp:
+//	    endpoint: ${ssm:/otel/endpoint}
+package ssmprovider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const schemeName = "ssm"
+
+// client is the part of the SSM API the provider uses.
+type client interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// cache holds the parameters fetched by every provider in the process, so a
+// collector restarted within a warm execution environment doesn't fetch them
+// again.
+var cache sync.Map
+
+type provider struct {
+	mu     sync.Mutex
+	client client
+}
+
+// NewFactory returns a factory for a confmap.Provider that reads parameters
+// from SSM Parameter Store. SecureString parameters are decrypted.
+func NewFactory() confmap.ProviderFactory {
+	return confmap.NewProviderFactory(func(confmap.ProviderSettings) confmap.Provider {
+		return &provider{}
+	})
+}
+
+func (p *provider) Retrieve(ctx context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+	name := strings.TrimPrefix(uri, schemeName+":")
+	if name == "" {
+		return nil, fmt.Errorf("%q uri does not name a parameter", uri)
+	}
+
+	if value, ok := cache.Load(name); ok {
+		return confmap.NewRetrievedFromYAML([]byte(value.(string)))
+	}
+	c, err := p.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SSM parameter %q: %w", name, err)
+	}
+	if out.Parameter == nil {
+		return nil, fmt.Errorf("SSM parameter %q has no value", name)
+	}
+	value := aws.ToString(out.Parameter.Value)
+	cache.Store(name, value)
+	return confmap.NewRetrievedFromYAML([]byte(value))
+}
+
+func (p *provider) getClient(ctx context.Context) (client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client != nil {
+		return p.client, nil
+	}
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	p.client = ssm.NewFromConfig(cfg)
+	return p.client, nil
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (*provider) Shutdown(context.Context) error {
+	return nil
+}
@@ -0,0 +1,144 @@
+package ssmprovider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// fakeClient serves the parameters in values and counts the requests.
+type fakeClient struct {
+	values map[string]string
+	calls  int
+}
+
+func (c *fakeClient) GetParameter(_ context.Context, params *ssm.GetParameterInput, _ ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	c.calls++
+	value, ok := c.values[aws.ToString(params.Name)]
+	if !ok {
+		return nil, &types.ParameterNotFound{Message: aws.String("parameter not found")}
+	}
+	if !aws.ToBool(params.WithDecryption) {
+		value = "encrypted"
+	}
+	return &ssm.GetParameterOutput{Parameter: &types.Parameter{Value: aws.String(value)}}, nil
+}
+
+// resetCache empties the process-wide cache for the duration of the test.
+func resetCache(t *testing.T) {
+	empty := func() {
+		cache.Range(func(key, _ any) bool {
+			cache.Delete(key)
+			return true
+		})
+	}
+	empty()
+	t.Cleanup(empty)
+}
+
+func TestRetrieve(t *testing.T) {
+	values := map[string]string{
+		"/otel/endpoint":  "https://collector:4318",
+		"/otel/exporters": "otlphttp:\n  endpoint: https://collector:4318\n",
+	}
+	tests := []struct {
+		name      string
+		uris      []string
+		want      any
+		wantErr   string
+		wantCalls int
+	}{
+		{
+			name:      "value",
+			uris:      []string{"ssm:/otel/endpoint"},
+			want:      "https://collector:4318",
+			wantCalls: 1,
+		},
+		{
+			name:      "fragment",
+			uris:      []string{"ssm:/otel/exporters"},
+			want:      map[string]any{"otlphttp": map[string]any{"endpoint": "https://collector:4318"}},
+			wantCalls: 1,
+		},
+		{
+			name:      "cached",
+			uris:      []string{"ssm:/otel/endpoint", "ssm:/otel/endpoint"},
+			want:      "https://collector:4318",
+			wantCalls: 1,
+		},
+		{
+			name:      "not found",
+			uris:      []string{"ssm:/otel/missing"},
+			wantErr:   `failed to get SSM parameter "/otel/missing"`,
+			wantCalls: 1,
+		},
+		{
+			name:      "errors aren't cached",
+			uris:      []string{"ssm:/otel/missing", "ssm:/otel/missing"},
+			wantErr:   `failed to get SSM parameter "/otel/missing"`,
+			wantCalls: 2,
+		},
+		{
+			name:    "no parameter name",
+			uris:    []string{"ssm:"},
+			wantErr: `"ssm:" uri does not name a parameter`,
+		},
+		{
+			name:    "other scheme",
+			uris:    []string{"s3://bucket/key"},
+			wantErr: `"s3://bucket/key" uri is not supported by "ssm" provider`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetCache(t)
+			c := &fakeClient{values: values}
+			p := &provider{client: c}
+
+			var (
+				got any
+				err error
+			)
+			for _, uri := range tt.uris {
+				var retrieved *confmap.Retrieved
+				if retrieved, err = p.Retrieve(context.Background(), uri, nil); err == nil {
+					got, err = retrieved.AsRaw()
+				}
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Retrieve() = %v, want an error containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("Retrieve() = %v", err)
+			} else if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Retrieve() = %#v, want %#v", got, tt.want)
+			}
+			if c.calls != tt.wantCalls {
+				t.Errorf("GetParameter was called %d times, want %d", c.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// The cache is shared by the providers of the process, so a collector
+// restarted in a warm execution environment doesn't fetch the parameters
+// again.
+func TestRetrieveSharesCache(t *testing.T) {
+	resetCache(t)
+	c := &fakeClient{values: map[string]string{"/otel/endpoint": "https://collector:4318"}}
+	for range 2 {
+		if _, err := (&provider{client: c}).Retrieve(context.Background(), "ssm:/otel/endpoint", nil); err != nil {
+			t.Fatalf("Retrieve() = %v", err)
+		}
+	}
+	if c.calls != 1 {
+		t.Errorf("GetParameter was called %d times, want once", c.calls)
+	}
+}
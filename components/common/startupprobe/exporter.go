@@ -0,0 +1,101 @@
+package startupprobe
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.uber.org/zap"
+)
+
+// Exporter returns f with every exporter it creates probing its backend when
+// it starts. Exporters without an endpoint in their configuration start
+// without a probe.
+func Exporter(f exporter.Factory, settings Settings) exporter.Factory {
+	return exporterFactory{Factory: f, settings: settings}
+}
+
+type exporterFactory struct {
+	exporter.Factory
+	settings Settings
+}
+
+func (f exporterFactory) newCheck(set exporter.Settings, cfg component.Config) check {
+	address, ok := address(cfg)
+	return check{settings: f.settings, id: set.ID, logger: set.Logger, address: address, enabled: ok}
+}
+
+func (f exporterFactory) CreateTraces(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+	exp, err := f.Factory.CreateTraces(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return tracesExporter{Traces: exp, check: f.newCheck(set, cfg)}, nil
+}
+
+func (f exporterFactory) CreateMetrics(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+	exp, err := f.Factory.CreateMetrics(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return metricsExporter{Metrics: exp, check: f.newCheck(set, cfg)}, nil
+}
+
+func (f exporterFactory) CreateLogs(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+	exp, err := f.Factory.CreateLogs(ctx, set, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return logsExporter{Logs: exp, check: f.newCheck(set, cfg)}, nil
+}
+
+type check struct {
+	settings Settings
+	id       component.ID
+	logger   *zap.Logger
+	address  string
+	enabled  bool
+}
+
+// run probes the backend, then starts the exporter unless the backend is
+// unreachable and Settings.Fail is set.
+func (c check) run(ctx context.Context, start func() error) error {
+	if c.enabled {
+		if err := probe(ctx, c.address, c.settings.Timeout); err != nil {
+			if c.settings.Fail {
+				return fmt.Errorf("backend of exporter %s at %s is unreachable: %w", c.id, c.address, err)
+			}
+			c.logger.Warn("Exporter backend is unreachable, data may be lost until it is",
+				zap.String("address", c.address), zap.Error(err))
+		}
+	}
+	return start()
+}
+
+type tracesExporter struct {
+	exporter.Traces
+	check check
+}
+
+func (e tracesExporter) Start(ctx context.Context, host component.Host) error {
+	return e.check.run(ctx, func() error { return e.Traces.Start(ctx, host) })
+}
+
+type metricsExporter struct {
+	exporter.Metrics
+	check check
+}
+
+func (e metricsExporter) Start(ctx context.Context, host component.Host) error {
+	return e.check.run(ctx, func() error { return e.Metrics.Start(ctx, host) })
+}
+
+type logsExporter struct {
+	exporter.Logs
+	check check
+}
+
+func (e logsExporter) Start(ctx context.Context, host component.Host) error {
+	return e.check.run(ctx, func() error { return e.Logs.Start(ctx, host) })
+}
@@ -0,0 +1,114 @@
+// Package startupprobe checks, when the collector starts, that the backends of
+// its exporters are reachable. Data received while a backend is unreachable is
+// lost once the exporter gives up retrying, so an unreachable backend is
+// either logged as a warning or made to fail the collector's startup.
+package startupprobe
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol"
+)
+
+const (
+	// EnvVar selects what an unreachable backend does: "warn" logs a warning
+	// and "fail" fails the collector's startup. Exporters aren't probed when
+	// it is unset.
+	EnvVar = "OCELOT_STARTUP_PROBE"
+	// TimeoutEnvVar overrides DefaultTimeout, as a Go duration (e.g. "500ms").
+	TimeoutEnvVar = "OCELOT_STARTUP_PROBE_TIMEOUT"
+)
+
+// DefaultTimeout is how long a probe waits for a connection.
+const DefaultTimeout = time.Second
+
+// Settings configures the probes of the wrapped exporters.
+type Settings struct {
+	// Fail makes an unreachable backend fail the exporter's Start.
+	Fail    bool
+	Timeout time.Duration
+}
+
+// Wrap returns factories with every exporter factory wrapped by Exporter,
+// using the settings from EnvVar and TimeoutEnvVar. factories is returned
+// unchanged when EnvVar is unset.
+func Wrap(factories otelcol.Factories) (otelcol.Factories, error) {
+	mode := strings.TrimSpace(os.Getenv(EnvVar))
+	if mode == "" {
+		return factories, nil
+	}
+	settings := Settings{Timeout: DefaultTimeout}
+	switch mode {
+	case "warn":
+	case "fail":
+		settings.Fail = true
+	default:
+		return factories, fmt.Errorf("%s must be warn or fail, got %q", EnvVar, mode)
+	}
+	if v := os.Getenv(TimeoutEnvVar); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil || timeout <= 0 {
+			return factories, fmt.Errorf("%s must be a positive duration, got %q", TimeoutEnvVar, v)
+		}
+		settings.Timeout = timeout
+	}
+	exporters := make(map[component.Type]exporter.Factory, len(factories.Exporters))
+	for typ, f := range factories.Exporters {
+		exporters[typ] = Exporter(f, settings)
+	}
+	factories.Exporters = exporters
+	return factories, nil
+}
+
+// address returns the host and port to probe for the exporter configuration
+// cfg. Only exporters configured with an endpoint, as a URL or as host:port,
+// can be probed.
+func address(cfg component.Config) (string, bool) {
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err != nil {
+		return "", false
+	}
+	endpoint, ok := conf.Get("endpoint").(string)
+	if !ok || endpoint == "" {
+		return "", false
+	}
+	if u, err := url.Parse(endpoint); err == nil && u.Scheme != "" && u.Host != "" {
+		port := u.Port()
+		if port == "" {
+			switch u.Scheme {
+			case "http":
+				port = "80"
+			case "https":
+				port = "443"
+			default:
+				return "", false
+			}
+		}
+		return net.JoinHostPort(u.Hostname(), port), true
+	}
+	if _, _, err := net.SplitHostPort(endpoint); err == nil {
+		return endpoint, true
+	}
+	return "", false
+}
+
+// probe opens a TCP connection to address and closes it.
+func probe(ctx context.Context, address string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,180 @@
+package startupprobe
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+type endpointConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+type otherConfig struct {
+	Brokers []string `mapstructure:"brokers"`
+}
+
+func TestAddress(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    component.Config
+		want   string
+		wantOK bool
+	}{
+		{name: "url", cfg: &endpointConfig{Endpoint: "https://otlp.example.com:4318/v1/traces"}, want: "otlp.example.com:4318", wantOK: true},
+		{name: "https", cfg: &endpointConfig{Endpoint: "https://otlp.example.com"}, want: "otlp.example.com:443", wantOK: true},
+		{name: "http", cfg: &endpointConfig{Endpoint: "http://otlp.example.com/"}, want: "otlp.example.com:80", wantOK: true},
+		{name: "ipv6", cfg: &endpointConfig{Endpoint: "http://[::1]:4318"}, want: "[::1]:4318", wantOK: true},
+		{name: "host and port", cfg: &endpointConfig{Endpoint: "localhost:4317"}, want: "localhost:4317", wantOK: true},
+		{name: "unknown scheme without port", cfg: &endpointConfig{Endpoint: "grpc://otlp.example.com"}},
+		{name: "host without port", cfg: &endpointConfig{Endpoint: "otlp.example.com"}},
+		{name: "empty endpoint", cfg: &endpointConfig{}},
+		{name: "no endpoint", cfg: &otherConfig{Brokers: []string{"localhost:9092"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := address(tt.cfg)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("address() = %q, %t, want %q, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// addresses returns the address of a listening backend and of one that
+// isn't, for the duration of the test.
+func addresses(t *testing.T) (reachable, unreachable string) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() = %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() = %v", err)
+	}
+	closed.Close()
+	return l.Addr().String(), closed.Addr().String()
+}
+
+func TestExporterStart(t *testing.T) {
+	reachable, unreachable := addresses(t)
+	tests := []struct {
+		name      string
+		fail      bool
+		endpoint  string
+		wantStart bool
+		wantErr   string
+	}{
+		{name: "reachable", fail: true, endpoint: "http://" + reachable, wantStart: true},
+		{name: "unreachable with warn", endpoint: "http://" + unreachable, wantStart: true},
+		{
+			name:     "unreachable with fail",
+			fail:     true,
+			endpoint: "http://" + unreachable,
+			wantErr:  "backend of exporter otlphttp at " + unreachable + " is unreachable: ",
+		},
+		{name: "no endpoint", fail: true, wantStart: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := false
+			upstream := exporter.NewFactory(component.MustNewType("otlphttp"),
+				func() component.Config { return &endpointConfig{} },
+				exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+					return fakeTraces{TracesSink: new(consumertest.TracesSink), start: &started}, nil
+				}, component.StabilityLevelDevelopment))
+			f := Exporter(upstream, Settings{Fail: tt.fail, Timeout: time.Second})
+			set := exporter.Settings{
+				ID: component.MustNewID("otlphttp"),
+				TelemetrySettings: component.TelemetrySettings{
+					Logger:         zap.NewNop(),
+					MeterProvider:  metricnoop.NewMeterProvider(),
+					TracerProvider: tracenoop.NewTracerProvider(),
+				},
+				BuildInfo: component.NewDefaultBuildInfo(),
+			}
+			exp, err := f.CreateTraces(context.Background(), set, &endpointConfig{Endpoint: tt.endpoint})
+			if err != nil {
+				t.Fatalf("CreateTraces() = %v", err)
+			}
+			err = exp.Start(context.Background(), nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Start() = %v", err)
+				}
+			} else if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("Start() = %v, want an error starting with %q", err, tt.wantErr)
+			}
+			if started != tt.wantStart {
+				t.Errorf("upstream exporter started: %t, want %t", started, tt.wantStart)
+			}
+		})
+	}
+}
+
+type fakeTraces struct {
+	component.ShutdownFunc
+	*consumertest.TracesSink
+	start *bool
+}
+
+func (e fakeTraces) Start(context.Context, component.Host) error {
+	*e.start = true
+	return nil
+}
+
+func TestWrap(t *testing.T) {
+	typ := component.MustNewType("otlphttp")
+	factories := otelcol.Factories{Exporters: map[component.Type]exporter.Factory{
+		typ: exporter.NewFactory(typ, func() component.Config { return &endpointConfig{} }),
+	}}
+	tests := []struct {
+		name         string
+		mode         string
+		timeout      string
+		wantWrapped  bool
+		wantSettings Settings
+		wantErr      string
+	}{
+		{name: "unset"},
+		{name: "warn", mode: "warn", wantWrapped: true, wantSettings: Settings{Timeout: DefaultTimeout}},
+		{name: "fail", mode: " fail ", timeout: "250ms", wantWrapped: true, wantSettings: Settings{Fail: true, Timeout: 250 * time.Millisecond}},
+		{name: "unknown mode", mode: "block", wantErr: `OCELOT_STARTUP_PROBE must be warn or fail, got "block"`},
+		{name: "invalid timeout", mode: "warn", timeout: "-1s", wantErr: `OCELOT_STARTUP_PROBE_TIMEOUT must be a positive duration, got "-1s"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvVar, tt.mode)
+			t.Setenv(TimeoutEnvVar, tt.timeout)
+			got, err := Wrap(factories)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Wrap() = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Wrap() = %v", err)
+			}
+			ef, wrapped := got.Exporters[typ].(exporterFactory)
+			if wrapped != tt.wantWrapped {
+				t.Fatalf("exporter factory = %T, want it wrapped: %t", got.Exporters[typ], tt.wantWrapped)
+			}
+			if wrapped && ef.settings != tt.wantSettings {
+				t.Errorf("settings = %+v, want %+v", ef.settings, tt.wantSettings)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package syntheticreceiver
+
+import "errors"
+
+// Config defines the configuration for the synthetic receiver.
+type Config struct {
+	// Count is the number of spans, data points or log records emitted, once,
+	// for every pipeline the receiver is part of.
+	Count int `mapstructure:"count"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Count <= 0 {
+		return errors.New("count must be greater than zero")
+	}
+	return nil
+}
+
+func createDefaultConfig() *Config {
+	return &Config{Count: 10}
+}
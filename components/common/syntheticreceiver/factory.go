@@ -0,0 +1,46 @@
+// Package syntheticreceiver generates a fixed amount of telemetry when it
+// starts. It is used to check a freshly built collector layer end to end
+// without an external client.
+package syntheticreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+var componentType = component.MustNewType("synthetic")
+
+// NewFactory creates a factory for the synthetic receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		componentType,
+		func() component.Config { return createDefaultConfig() },
+		receiver.WithTraces(createTraces, component.StabilityLevelDevelopment),
+		receiver.WithMetrics(createMetrics, component.StabilityLevelDevelopment),
+		receiver.WithLogs(createLogs, component.StabilityLevelDevelopment),
+	)
+}
+
+func createTraces(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+	count := cfg.(*Config).Count
+	return newReceiver(set, func(ctx context.Context) error {
+		return next.ConsumeTraces(ctx, generateTraces(count))
+	}), nil
+}
+
+func createMetrics(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+	count := cfg.(*Config).Count
+	return newReceiver(set, func(ctx context.Context) error {
+		return next.ConsumeMetrics(ctx, generateMetrics(count))
+	}), nil
+}
+
+func createLogs(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Logs) (receiver.Logs, error) {
+	count := cfg.(*Config).Count
+	return newReceiver(set, func(ctx context.Context) error {
+		return next.ConsumeLogs(ctx, generateLogs(count))
+	}), nil
+}
@@ -0,0 +1,91 @@
+package syntheticreceiver
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	serviceName = "ocelot-synthetic"
+	scopeName   = "github.com/open-telemetry/opentelemetry-lambda/collector/common/syntheticreceiver"
+)
+
+func generateTraces(count int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", serviceName)
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName(scopeName)
+
+	now := time.Now()
+	traceID := newTraceID()
+	for i := range count {
+		span := ss.Spans().AppendEmpty()
+		span.SetTraceID(traceID)
+		span.SetSpanID(newSpanID())
+		span.SetName("synthetic-span-" + strconv.Itoa(i))
+		span.SetKind(ptrace.SpanKindInternal)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(now.Add(-time.Millisecond)))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(now))
+	}
+	return td
+}
+
+func generateMetrics(count int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", serviceName)
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+
+	metric := sm.Metrics().AppendEmpty()
+	metric.SetName("synthetic.value")
+	gauge := metric.SetEmptyGauge()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for i := range count {
+		dp := gauge.DataPoints().AppendEmpty()
+		dp.SetTimestamp(now)
+		dp.SetIntValue(int64(i))
+		dp.Attributes().PutInt("synthetic.index", int64(i))
+	}
+	return md
+}
+
+func generateLogs(count int) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", serviceName)
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for i := range count {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetTimestamp(now)
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.Body().SetStr("synthetic log " + strconv.Itoa(i))
+	}
+	return ld
+}
+
+func newTraceID() pcommon.TraceID {
+	var id pcommon.TraceID
+	for i := range id {
+		id[i] = byte(rand.IntN(256))
+	}
+	return id
+}
+
+func newSpanID() pcommon.SpanID {
+	var id pcommon.SpanID
+	for i := range id {
+		id[i] = byte(rand.IntN(256))
+	}
+	return id
+}
@@ -0,0 +1,48 @@
+package syntheticreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+// syntheticReceiver emits its telemetry once from a goroutine started with the
+// receiver, so Start doesn't block on the downstream pipeline.
+type syntheticReceiver struct {
+	settings receiver.Settings
+	emit     func(ctx context.Context) error
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func newReceiver(set receiver.Settings, emit func(ctx context.Context) error) *syntheticReceiver {
+	return &syntheticReceiver{settings: set, emit: emit}
+}
+
+func (r *syntheticReceiver) Start(_ context.Context, _ component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		if err := r.emit(ctx); err != nil {
+			r.settings.Logger.Error("Failed to emit synthetic telemetry", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (r *syntheticReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,140 @@
+package syntheticreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+func settings() receiver.Settings {
+	return receiver.Settings{
+		ID: component.NewID(componentType),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+}
+
+func TestSyntheticReceiver(t *testing.T) {
+	ctx := context.Background()
+	f := NewFactory()
+	tests := []struct {
+		name  string
+		count int
+		// create creates the receiver for one signal and returns it along
+		// with the number of items it has emitted so far.
+		create func(cfg component.Config) (component.Component, func() int, error)
+	}{
+		{
+			name:  "traces",
+			count: 3,
+			create: func(cfg component.Config) (component.Component, func() int, error) {
+				sink := new(consumertest.TracesSink)
+				r, err := f.CreateTraces(ctx, settings(), cfg, sink)
+				return r, sink.SpanCount, err
+			},
+		},
+		{
+			name:  "metrics",
+			count: 5,
+			create: func(cfg component.Config) (component.Component, func() int, error) {
+				sink := new(consumertest.MetricsSink)
+				r, err := f.CreateMetrics(ctx, settings(), cfg, sink)
+				return r, sink.DataPointCount, err
+			},
+		},
+		{
+			name:  "logs",
+			count: 7,
+			create: func(cfg component.Config) (component.Component, func() int, error) {
+				sink := new(consumertest.LogsSink)
+				r, err := f.CreateLogs(ctx, settings(), cfg, sink)
+				return r, sink.LogRecordCount, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, emitted, err := tt.create(&Config{Count: tt.count})
+			if err != nil {
+				t.Fatalf("create = %v", err)
+			}
+			if err := r.Start(ctx, nil); err != nil {
+				t.Fatalf("Start() = %v", err)
+			}
+			// Shutdown waits for the telemetry to be emitted.
+			if err := r.Shutdown(ctx); err != nil {
+				t.Fatalf("Shutdown() = %v", err)
+			}
+			if got := emitted(); got != tt.count {
+				t.Errorf("%d items emitted, want %d", got, tt.count)
+			}
+		})
+	}
+}
+
+func TestShutdownBeforeStart(t *testing.T) {
+	r := newReceiver(settings(), func(context.Context) error { return errors.New("not started") })
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() = %v", err)
+	}
+}
+
+func TestGenerateTraces(t *testing.T) {
+	td := generateTraces(4)
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	if spans.Len() != 4 {
+		t.Fatalf("%d spans, want 4", spans.Len())
+	}
+	seen := make(map[string]bool)
+	for i := 0; i < spans.Len(); i++ {
+		span := spans.At(i)
+		if span.TraceID() != spans.At(0).TraceID() {
+			t.Errorf("span %d has trace ID %v, want the trace of the first span, %v", i, span.TraceID(), spans.At(0).TraceID())
+		}
+		if seen[span.SpanID().String()] {
+			t.Errorf("span %d reuses span ID %v", i, span.SpanID())
+		}
+		seen[span.SpanID().String()] = true
+		if span.EndTimestamp() <= span.StartTimestamp() {
+			t.Errorf("span %d ends at %v, before it starts at %v", i, span.EndTimestamp(), span.StartTimestamp())
+		}
+	}
+	if got, _ := td.ResourceSpans().At(0).Resource().Attributes().Get("service.name"); got.Str() != serviceName {
+		t.Errorf("service.name = %q, want %q", got.Str(), serviceName)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{name: "default", cfg: *createDefaultConfig()},
+		{name: "no count", cfg: Config{}, wantErr: "count must be greater than zero"},
+		{name: "negative count", cfg: Config{Count: -1}, wantErr: "count must be greater than zero"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
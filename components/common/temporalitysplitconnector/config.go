@@ -0,0 +1,32 @@
+package temporalitysplitconnector
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// Config defines the configuration for the temporality split connector.
+type Config struct {
+	// DeltaPipelines receive the sums and histograms with delta temporality.
+	DeltaPipelines []pipeline.ID `mapstructure:"delta_pipelines"`
+	// CumulativePipelines receive the sums and histograms with cumulative
+	// temporality.
+	CumulativePipelines []pipeline.ID `mapstructure:"cumulative_pipelines"`
+	// DefaultPipelines receive the metrics without a temporality: gauges,
+	// summaries and metrics whose temporality is unspecified. They are
+	// dropped when it is empty.
+	DefaultPipelines []pipeline.ID `mapstructure:"default_pipelines"`
+}
+
+// Validate checks the connector configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.DeltaPipelines) == 0 && len(cfg.CumulativePipelines) == 0 {
+		return errors.New("at least one of delta_pipelines and cumulative_pipelines must list a pipeline")
+	}
+	return nil
+}
+
+func createDefaultConfig() *Config {
+	return &Config{}
+}
@@ -0,0 +1,149 @@
+package temporalitysplitconnector
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// route is where a metric is sent.
+type route int
+
+const (
+	routeDefault route = iota
+	routeDelta
+	routeCumulative
+	routeCount
+)
+
+type temporalitySplit struct {
+	component.StartFunc
+	component.ShutdownFunc
+	// consumers holds the consumer of each route, nil for a route without
+	// pipelines, whose metrics are dropped.
+	consumers [routeCount]consumer.Metrics
+}
+
+func newConnector(cfg *Config, router connector.MetricsRouterAndConsumer) (*temporalitySplit, error) {
+	c := &temporalitySplit{}
+	for r, pipelines := range map[route][]pipeline.ID{
+		routeDefault:    cfg.DefaultPipelines,
+		routeDelta:      cfg.DeltaPipelines,
+		routeCumulative: cfg.CumulativePipelines,
+	} {
+		if len(pipelines) == 0 {
+			continue
+		}
+		next, err := router.Consumer(pipelines...)
+		if err != nil {
+			return nil, err
+		}
+		c.consumers[r] = next
+	}
+	return c, nil
+}
+
+func (c *temporalitySplit) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *temporalitySplit) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	// Most batches hold metrics of a single temporality; those are forwarded
+	// as they are.
+	if r, ok := singleRoute(md); ok {
+		if c.consumers[r] == nil {
+			return nil
+		}
+		return c.consumers[r].ConsumeMetrics(ctx, md)
+	}
+
+	var split [routeCount]pmetric.Metrics
+	for r := range split {
+		split[r] = pmetric.NewMetrics()
+	}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		var resources [routeCount]pmetric.ResourceMetrics
+		var resourceCreated [routeCount]bool
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			var scopes [routeCount]pmetric.ScopeMetrics
+			var scopeCreated [routeCount]bool
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				r := routeOf(m)
+				if c.consumers[r] == nil {
+					continue
+				}
+				if !resourceCreated[r] {
+					resources[r] = split[r].ResourceMetrics().AppendEmpty()
+					rm.Resource().CopyTo(resources[r].Resource())
+					resources[r].SetSchemaUrl(rm.SchemaUrl())
+					resourceCreated[r] = true
+				}
+				if !scopeCreated[r] {
+					scopes[r] = resources[r].ScopeMetrics().AppendEmpty()
+					sm.Scope().CopyTo(scopes[r].Scope())
+					scopes[r].SetSchemaUrl(sm.SchemaUrl())
+					scopeCreated[r] = true
+				}
+				m.CopyTo(scopes[r].Metrics().AppendEmpty())
+			}
+		}
+	}
+
+	var errs []error
+	for r, next := range c.consumers {
+		if next == nil || split[r].ResourceMetrics().Len() == 0 {
+			continue
+		}
+		errs = append(errs, next.ConsumeMetrics(ctx, split[r]))
+	}
+	return errors.Join(errs...)
+}
+
+// routeOf returns the route of a metric by its aggregation temporality.
+func routeOf(m pmetric.Metric) route {
+	var temporality pmetric.AggregationTemporality
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		temporality = m.Sum().AggregationTemporality()
+	case pmetric.MetricTypeHistogram:
+		temporality = m.Histogram().AggregationTemporality()
+	case pmetric.MetricTypeExponentialHistogram:
+		temporality = m.ExponentialHistogram().AggregationTemporality()
+	}
+	switch temporality {
+	case pmetric.AggregationTemporalityDelta:
+		return routeDelta
+	case pmetric.AggregationTemporalityCumulative:
+		return routeCumulative
+	default:
+		return routeDefault
+	}
+}
+
+// singleRoute returns the route of every metric in md, and false if they
+// don't share one. An empty md has the default route.
+func singleRoute(md pmetric.Metrics) (route, bool) {
+	r, found := routeDefault, false
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				mr := routeOf(ms.At(k))
+				if found && mr != r {
+					return 0, false
+				}
+				r, found = mr, true
+			}
+		}
+	}
+	return r, true
+}
@@ -0,0 +1,228 @@
+package temporalitysplitconnector
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pipeline"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+func settings() connector.Settings {
+	return connector.Settings{
+		ID: component.NewID(componentType),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+}
+
+var (
+	deltaPipeline      = pipeline.NewIDWithName(pipeline.SignalMetrics, "delta")
+	cumulativePipeline = pipeline.NewIDWithName(pipeline.SignalMetrics, "cumulative")
+	defaultPipeline    = pipeline.NewIDWithName(pipeline.SignalMetrics, "default")
+)
+
+// newMetrics returns one resource and scope holding a metric of each kind, such
+// as "delta_sum", named after it.
+func newMetrics(kinds ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	for _, kind := range kinds {
+		m := metrics.AppendEmpty()
+		m.SetName(kind)
+		switch kind {
+		case "delta_sum":
+			m.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			m.Sum().DataPoints().AppendEmpty()
+		case "cumulative_sum":
+			m.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			m.Sum().DataPoints().AppendEmpty()
+		case "delta_histogram":
+			m.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			m.Histogram().DataPoints().AppendEmpty()
+		case "cumulative_exponential_histogram":
+			m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			m.ExponentialHistogram().DataPoints().AppendEmpty()
+		case "unspecified_sum":
+			m.SetEmptySum().DataPoints().AppendEmpty()
+		case "gauge":
+			m.SetEmptyGauge().DataPoints().AppendEmpty()
+		case "summary":
+			m.SetEmptySummary().DataPoints().AppendEmpty()
+		}
+	}
+	return md
+}
+
+func names(sink *consumertest.MetricsSink) []string {
+	var got []string
+	for _, md := range sink.AllMetrics() {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			sms := rms.At(i).ScopeMetrics()
+			for j := 0; j < sms.Len(); j++ {
+				ms := sms.At(j).Metrics()
+				for k := 0; k < ms.Len(); k++ {
+					got = append(got, ms.At(k).Name())
+				}
+			}
+		}
+	}
+	return got
+}
+
+func TestTemporalitySplit(t *testing.T) {
+	all := []string{"delta_sum", "cumulative_sum", "gauge", "delta_histogram", "unspecified_sum", "cumulative_exponential_histogram", "summary"}
+	tests := []struct {
+		name           string
+		cfg            Config
+		metrics        []string
+		wantDelta      []string
+		wantCumulative []string
+		wantDefault    []string
+	}{
+		{
+			name:           "split",
+			cfg:            Config{DeltaPipelines: []pipeline.ID{deltaPipeline}, CumulativePipelines: []pipeline.ID{cumulativePipeline}, DefaultPipelines: []pipeline.ID{defaultPipeline}},
+			metrics:        all,
+			wantDelta:      []string{"delta_sum", "delta_histogram"},
+			wantCumulative: []string{"cumulative_sum", "cumulative_exponential_histogram"},
+			wantDefault:    []string{"gauge", "unspecified_sum", "summary"},
+		},
+		{
+			name:           "no default pipelines",
+			cfg:            Config{DeltaPipelines: []pipeline.ID{deltaPipeline}, CumulativePipelines: []pipeline.ID{cumulativePipeline}},
+			metrics:        all,
+			wantDelta:      []string{"delta_sum", "delta_histogram"},
+			wantCumulative: []string{"cumulative_sum", "cumulative_exponential_histogram"},
+		},
+		{
+			name:      "single temporality",
+			cfg:       Config{DeltaPipelines: []pipeline.ID{deltaPipeline}, CumulativePipelines: []pipeline.ID{cumulativePipeline}},
+			metrics:   []string{"delta_sum", "delta_histogram"},
+			wantDelta: []string{"delta_sum", "delta_histogram"},
+		},
+		{
+			name:    "single temporality without pipelines",
+			cfg:     Config{DeltaPipelines: []pipeline.ID{deltaPipeline}},
+			metrics: []string{"cumulative_sum"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta, cumulative, def := new(consumertest.MetricsSink), new(consumertest.MetricsSink), new(consumertest.MetricsSink)
+			router := connector.NewMetricsRouter(map[pipeline.ID]consumer.Metrics{
+				deltaPipeline:      delta,
+				cumulativePipeline: cumulative,
+				defaultPipeline:    def,
+			})
+			f := NewFactory()
+			c, err := f.CreateMetricsToMetrics(context.Background(), settings(), &tt.cfg, router)
+			if err != nil {
+				t.Fatalf("CreateMetricsToMetrics() = %v", err)
+			}
+			if err := c.ConsumeMetrics(context.Background(), newMetrics(tt.metrics...)); err != nil {
+				t.Fatalf("ConsumeMetrics() = %v", err)
+			}
+			for _, sink := range []struct {
+				name string
+				sink *consumertest.MetricsSink
+				want []string
+			}{
+				{"delta", delta, tt.wantDelta},
+				{"cumulative", cumulative, tt.wantCumulative},
+				{"default", def, tt.wantDefault},
+			} {
+				if got := names(sink.sink); !slices.Equal(got, sink.want) {
+					t.Errorf("%s pipelines received %v, want %v", sink.name, got, sink.want)
+				}
+			}
+		})
+	}
+}
+
+func TestTemporalitySplitKeepsResourcesAndScopes(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("instrumentation")
+	newMetrics("delta_sum", "cumulative_sum").ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().MoveAndAppendTo(sm.Metrics())
+
+	delta := new(consumertest.MetricsSink)
+	cumulative := new(consumertest.MetricsSink)
+	router := connector.NewMetricsRouter(map[pipeline.ID]consumer.Metrics{deltaPipeline: delta, cumulativePipeline: cumulative})
+	c, err := newConnector(&Config{DeltaPipelines: []pipeline.ID{deltaPipeline}, CumulativePipelines: []pipeline.ID{cumulativePipeline}}, router)
+	if err != nil {
+		t.Fatalf("newConnector() = %v", err)
+	}
+	if err := c.ConsumeMetrics(context.Background(), md); err != nil {
+		t.Fatalf("ConsumeMetrics() = %v", err)
+	}
+	for name, sink := range map[string]*consumertest.MetricsSink{"delta": delta, "cumulative": cumulative} {
+		if len(sink.AllMetrics()) != 1 {
+			t.Fatalf("%s pipelines received %d batches, want 1", name, len(sink.AllMetrics()))
+		}
+		got := sink.AllMetrics()[0].ResourceMetrics().At(0)
+		if service, _ := got.Resource().Attributes().Get("service.name"); service.Str() != "checkout" {
+			t.Errorf("%s pipelines: service.name = %q, want checkout", name, service.Str())
+		}
+		if scope := got.ScopeMetrics().At(0).Scope().Name(); scope != "instrumentation" {
+			t.Errorf("%s pipelines: scope = %q, want instrumentation", name, scope)
+		}
+	}
+}
+
+func TestCreateWithoutRouter(t *testing.T) {
+	f := NewFactory()
+	cfg := &Config{DeltaPipelines: []pipeline.ID{deltaPipeline}}
+	_, err := f.CreateMetricsToMetrics(context.Background(), settings(), cfg, new(consumertest.MetricsSink))
+	if err == nil || err.Error() != "expected the next consumer to be a metrics router" {
+		t.Errorf("CreateMetricsToMetrics() = %v, want an error", err)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{name: "delta", cfg: Config{DeltaPipelines: []pipeline.ID{deltaPipeline}}},
+		{name: "cumulative", cfg: Config{CumulativePipelines: []pipeline.ID{cumulativePipeline}}},
+		{
+			name:    "default only",
+			cfg:     Config{DefaultPipelines: []pipeline.ID{defaultPipeline}},
+			wantErr: "at least one of delta_pipelines and cumulative_pipelines must list a pipeline",
+		},
+		{
+			name:    "none",
+			cfg:     *createDefaultConfig(),
+			wantErr: "at least one of delta_pipelines and cumulative_pipelines must list a pipeline",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
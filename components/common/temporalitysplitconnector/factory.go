@@ -0,0 +1,32 @@
+// Package temporalitysplitconnector routes metrics to different pipelines by
+// their aggregation temporality, so a backend that wants delta metrics and one
+// that wants cumulative metrics can be fed from the same pipeline.
+package temporalitysplitconnector
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+var componentType = component.MustNewType("temporalitysplit")
+
+// NewFactory creates a factory for the temporality split connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		componentType,
+		func() component.Config { return createDefaultConfig() },
+		connector.WithMetricsToMetrics(createMetricsToMetrics, component.StabilityLevelDevelopment),
+	)
+}
+
+func createMetricsToMetrics(_ context.Context, _ connector.Settings, cfg component.Config, next consumer.Metrics) (connector.Metrics, error) {
+	router, ok := next.(connector.MetricsRouterAndConsumer)
+	if !ok {
+		return nil, errors.New("expected the next consumer to be a metrics router")
+	}
+	return newConnector(cfg.(*Config), router)
+}
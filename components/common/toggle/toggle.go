@@ -0,0 +1,35 @@
+// Package toggle gates debugging components behind environment variables so a
+// layer can ship them without them running unless an operator opts in.
+package toggle
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/common/lambdaenv"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+// Extension returns f with Create replaced by a no-op extension unless the
+// envVar variable is set to a true value when the extension is created.
+func Extension(f extension.Factory, envVar string) extension.Factory {
+	return extensionFactory{Factory: f, envVar: envVar}
+}
+
+type extensionFactory struct {
+	extension.Factory
+	envVar string
+}
+
+func (f extensionFactory) Create(ctx context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	if !lambdaenv.Enabled(f.envVar) {
+		set.Logger.Debug("Extension disabled, set " + f.envVar + " to enable it")
+		return nopExtension{}, nil
+	}
+	return f.Factory.Create(ctx, set, cfg)
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
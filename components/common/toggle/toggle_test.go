@@ -0,0 +1,69 @@
+package toggle
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+const envVar = "OCELOT_TEST_EXTENSION_ENABLED"
+
+type fakeExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func TestExtension(t *testing.T) {
+	typ := component.MustNewType("fake")
+	upstream := extension.NewFactory(typ, func() component.Config { return &struct{}{} },
+		func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return &fakeExtension{}, nil
+		}, component.StabilityLevelDevelopment)
+	f := Extension(upstream, envVar)
+	set := extension.Settings{
+		ID: component.NewID(typ),
+		TelemetrySettings: component.TelemetrySettings{
+			Logger:         zap.NewNop(),
+			MeterProvider:  metricnoop.NewMeterProvider(),
+			TracerProvider: tracenoop.NewTracerProvider(),
+		},
+		BuildInfo: component.NewDefaultBuildInfo(),
+	}
+
+	tests := []struct {
+		name        string
+		value       string
+		wantEnabled bool
+	}{
+		{name: "unset"},
+		{name: "false", value: "false"},
+		{name: "not a bool", value: "on"},
+		{name: "true", value: "true", wantEnabled: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envVar, tt.value)
+			if f.Type() != typ {
+				t.Errorf("Type() = %v, want %v", f.Type(), typ)
+			}
+			ext, err := f.Create(context.Background(), set, f.CreateDefaultConfig())
+			if err != nil {
+				t.Fatalf("Create() = %v", err)
+			}
+			if _, enabled := ext.(*fakeExtension); enabled != tt.wantEnabled {
+				t.Errorf("Create() = %T, want the upstream extension: %t", ext, tt.wantEnabled)
+			}
+			if err := ext.Start(context.Background(), nil); err != nil {
+				t.Errorf("Start() = %v", err)
+			}
+			if err := ext.Shutdown(context.Background()); err != nil {
+				t.Errorf("Shutdown() = %v", err)
+			}
+		})
+	}
+}
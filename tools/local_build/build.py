@@ -66,6 +66,8 @@ def build_layer(context: BuildContext, tracker) -> BuildContext:
     # If a custom config file is specified, pass it as an argument
     if context.config_file:
         build_cmd.extend(["--config-file", context.config_file])
+    if context.pin_upstream:
+        build_cmd.append("--pin-upstream")
 
     try:
         # Run build script (don't capture output by default, let it stream)
@@ -20,6 +20,7 @@ class BuildContext:
         verbose: bool,
         public: bool,
         keep_temp: bool,
+        pin_upstream: bool = False,
     ):
         # CLI parameters
         self.distribution = distribution
@@ -32,6 +33,7 @@ class BuildContext:
         self.verbose = verbose
         self.public = public
         self.keep_temp = keep_temp
+        self.pin_upstream = pin_upstream
 
         # Paths
         self.repo_root = Path().cwd()
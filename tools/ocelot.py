@@ -130,6 +130,11 @@ ARCHITECTURE_CHOICES = ["amd64", "arm64"]
     is_flag=True,
     help="Keep temporary directories (e.g., upstream clone).",
 )
+@click.option(
+    "--pin-upstream",
+    is_flag=True,
+    help="Check the upstream patches against the cloned commit and pin it.",
+)
 def main(
     distribution,
     architecture,
@@ -141,6 +146,7 @@ def main(
     verbose,
     public,
     keep_temp,
+    pin_upstream,
 ):
     """Build and test custom OTel Collector distributions locally."""
 
@@ -162,6 +168,7 @@ def main(
         verbose=verbose,
         public=public,
         keep_temp=keep_temp,
+        pin_upstream=pin_upstream,
     )
 
     # Ensure build directory exists
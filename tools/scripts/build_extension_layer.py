@@ -19,6 +19,14 @@ import click
 
 # Import utility modules
 from otel_layer_utils.distribution_utils import resolve_build_tags, DistributionError
+from otel_layer_utils.build_constraints import validate_component_constraints
+from otel_layer_utils.upstream_patches import (
+    PIN_PATH,
+    UpstreamPatchError,
+    apply_upstream_patches,
+    read_pinned_commit,
+    write_pinned_commit,
+)
 from otel_layer_utils.ui_utils import (
     header,
     subheader,
@@ -39,6 +47,54 @@ DEFAULT_UPSTREAM_REF = "main"
 DEFAULT_DISTRIBUTION = "default"
 DEFAULT_ARCHITECTURE = "amd64"
 
+# Files in a component type directory that belong to the package rather than to
+# a single component. They are copied whenever the overlay is applied.
+PACKAGE_SUPPORT_FILES = ["registry.go"]
+
+# The size-optimized core profile. It mirrors the build constraints of the
+# component files: the core tag selects CORE_COMPONENTS on its own and excludes
+# CORE_EXCLUDED_COMPONENTS even when an 'all' tag would include them.
+CORE_PROFILE_TAG = "lambdacomponents.core"
+CORE_COMPONENTS = [
+    "lambdacomponents.receiver.otlp",
+    "lambdacomponents.processor.batch",
+    "lambdacomponents.processor.memorylimiter",
+    "lambdacomponents.exporter.otlp",
+    "lambdacomponents.exporter.otlphttp",
+]
+CORE_EXCLUDED_COMPONENTS = [
+    "lambdacomponents.connector.datadog",
+    "lambdacomponents.exporter.clickhouse",
+    "lambdacomponents.exporter.datadog",
+]
+
+# The metrics-only profile. It excludes every component that consumes traces
+# or logs and nothing else, as the '!lambdacomponents.metricsonly' constraint
+# of their files does, whatever else is selected.
+METRICSONLY_PROFILE_TAG = "lambdacomponents.metricsonly"
+METRICSONLY_EXCLUDED_COMPONENTS = [
+    "lambdacomponents.connector.coldstart",
+    "lambdacomponents.connector.datadog",
+    "lambdacomponents.connector.durationrouting",
+    "lambdacomponents.connector.errorsampling",
+    "lambdacomponents.connector.exceptions",
+    "lambdacomponents.connector.grafanacloud",
+    "lambdacomponents.connector.logtometrics",
+    "lambdacomponents.connector.ottlspanmetrics",
+    "lambdacomponents.connector.servicegraph",
+    "lambdacomponents.connector.spaneventtolog",
+    "lambdacomponents.connector.spanmetrics",
+    "lambdacomponents.exporter.awscloudwatchlogs",
+    "lambdacomponents.exporter.awsxray",
+    "lambdacomponents.exporter.loki",
+    "lambdacomponents.processor.lambdacontext",
+    "lambdacomponents.processor.logdedup",
+    "lambdacomponents.processor.probabilisticsampler",
+    "lambdacomponents.processor.span",
+    "lambdacomponents.processor.tailsampling",
+    "lambdacomponents.receiver.filelog",
+]
+
 
 def load_component_dependencies(yaml_path: Path) -> dict:
     """Load component dependency mappings from YAML file."""
@@ -107,6 +163,8 @@ def resolve_components_by_tags(
 
     # Check for hierarchical tag resolution
     has_global_all = "lambdacomponents.all" in active_build_tags
+    has_core = CORE_PROFILE_TAG in active_build_tags
+    has_metricsonly = METRICSONLY_PROFILE_TAG in active_build_tags
 
     # Identify categories with 'all' tags
     category_all_tags = {}
@@ -119,6 +177,16 @@ def resolve_components_by_tags(
     for component_tag in dependency_mappings.keys():
         should_include = False
 
+        # The core profile excludes heavy components whatever else is selected
+        if has_core and component_tag in CORE_EXCLUDED_COMPONENTS:
+            detail("Excluding component", f"Via core profile: {component_tag}")
+            continue
+
+        # So does the metrics-only profile for the components without metrics
+        if has_metricsonly and component_tag in METRICSONLY_EXCLUDED_COMPONENTS:
+            detail("Excluding component", f"Via metrics-only profile: {component_tag}")
+            continue
+
         # Direct match with active tag
         if component_tag in active_build_tags:
             should_include = True
@@ -129,6 +197,11 @@ def resolve_components_by_tags(
             should_include = True
             detail("Including component", f"Via global 'all' tag: {component_tag}")
 
+        # The core profile includes its curated components
+        elif has_core and component_tag in CORE_COMPONENTS:
+            should_include = True
+            detail("Including component", f"Via core profile: {component_tag}")
+
         # Category "all" tag includes components in that category
         else:
             for category in category_all_tags:
@@ -205,6 +278,26 @@ def selective_copy_components(
         shutil.copytree(common_dir, upstream_common_dir, dirs_exist_ok=True)
         success("Copied common files", f"From {common_dir} to {upstream_common_dir}")
 
+    # Copy the package support files of every component type
+    for component_type_dir in component_type_dirs.values():
+        source_type_dir = component_dir / "collector" / "lambdacomponents" / component_type_dir
+        dest_type_dir = upstream_dir / "collector" / "lambdacomponents" / component_type_dir
+        for support_file in PACKAGE_SUPPORT_FILES:
+            source_file = source_type_dir / support_file
+            if source_file.is_file():
+                dest_type_dir.mkdir(parents=True, exist_ok=True)
+                shutil.copy2(source_file, dest_type_dir / support_file)
+                detail("Copied support file", f"{component_type_dir}/{support_file}")
+
+    # Copy the files of the lambdacomponents package itself, which replace the
+    # upstream entry point
+    lambdacomponents_dir = component_dir / "collector" / "lambdacomponents"
+    for source_file in sorted(lambdacomponents_dir.glob("*.go")):
+        dest_file = upstream_dir / "collector" / "lambdacomponents" / source_file.name
+        dest_file.parent.mkdir(parents=True, exist_ok=True)
+        shutil.copy2(source_file, dest_file)
+        detail("Copied entry point file", source_file.name)
+
     # Copy each component type directory if needed
     for component_tag in included_components:
         # Extract the component type (e.g., 'connector', 'exporter')
@@ -485,6 +578,11 @@ def add_dependencies(
     "--config-file",
     help="Optional custom collector config file name (relative to config/examples/)",
 )
+@click.option(
+    "--pin-upstream",
+    is_flag=True,
+    help=f"Check the upstream patches against the cloned commit and pin it in {PIN_PATH}",
+)
 def main(
     upstream_repo,
     upstream_ref,
@@ -495,6 +593,7 @@ def main(
     upstream_version,
     build_tags,
     config_file,
+    pin_upstream,
 ):
     """Build Custom OpenTelemetry Collector Lambda Layer."""
 
@@ -542,6 +641,17 @@ def main(
     # Load component dependencies config
     dependency_mappings = load_component_dependencies(dependency_yaml_path)
 
+    # Components whose build constraints don't follow the convention would be
+    # copied but silently left out of the build, so stop before cloning.
+    constraint_problems = validate_component_constraints(
+        component_dir / "collector" / "lambdacomponents"
+    )
+    if constraint_problems:
+        for path, problems in constraint_problems.items():
+            for problem in problems:
+                error(f"Invalid build constraint in {path}", problem)
+        sys.exit(1)
+
     # Display build configuration using property list
     header("Build configuration")
 
@@ -617,6 +727,26 @@ def main(
         else:
             success("Copied components", f"From {component_dir} to {upstream_dir}")
 
+        # Connect the overlaid entry points to the upstream collector
+        if included_components:
+            commit_result = run_command(
+                ["git", "rev-parse", "HEAD"], cwd=str(upstream_dir), capture_output=True
+            )
+            commit = commit_result.stdout.strip()
+            pin_file = custom_repo_path / PIN_PATH
+            pinned_commit = commit if pin_upstream else read_pinned_commit(pin_file)
+            try:
+                for patched_file in apply_upstream_patches(
+                    upstream_dir, active_build_tags, commit, pinned_commit
+                ):
+                    detail("Patched upstream file", patched_file)
+            except UpstreamPatchError as e:
+                error("Failed to patch the upstream collector", str(e))
+                sys.exit(1)
+            if pin_upstream:
+                write_pinned_commit(pin_file, commit)
+                success("Pinned upstream commit", f"{commit} in {pin_file}")
+
         # Step 2.5: Copy custom config file if specified
         if config_file:
             custom_config_path = (
@@ -0,0 +1,286 @@
+#!/usr/bin/env python3
+"""
+build_constraints.py
+
+Checks the //go:build constraints of the component wrapper files.
+
+Every component file is expected to follow the convention
+
+    lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.<kind>.all || lambdacomponents.<kind>.<name>)
+
+optionally with '|| lambdacomponents.core' inside the parentheses and
+'&& !lambdacomponents.metricsonly' or '&& !lambdacomponents.core' after them.
+A file that drops one of the 'all' tags still builds, but is silently left
+out of the builds that select it through that tag.
+"""
+
+import re
+from pathlib import Path
+from typing import Dict, List, Optional, Set, Union
+
+CUSTOM_TAG = "lambdacomponents.custom"
+GLOBAL_ALL_TAG = "lambdacomponents.all"
+CORE_TAG = "lambdacomponents.core"
+
+# Tags a component may be excluded by, as '&& !<tag>' after the selection.
+EXCLUSION_TAGS = ["lambdacomponents.metricsonly", "lambdacomponents.core"]
+
+# Components deliberately left out of the 'all' tags, which must be requested
+# by their own tag: 'lambdacomponents.custom && lambdacomponents.<kind>.<name>'.
+EXPLICIT_ONLY_COMPONENTS = [
+    "lambdacomponents.exporter.debug",
+    "lambdacomponents.receiver.synthetic",
+]
+
+# Files in a component type directory that belong to the package and are
+# constrained by 'lambdacomponents.custom' alone.
+PACKAGE_FILES = ["registry.go"]
+
+COMPONENT_KINDS = ["connector", "exporter", "extension", "processor", "receiver"]
+
+_TOKEN = re.compile(r"\s*(&&|\|\||!|\(|\)|[A-Za-z0-9_.]+)")
+
+
+class BuildConstraintError(Exception):
+    """Raised when a build constraint can't be parsed."""
+
+    pass
+
+
+# An expression is a tag name, or a tuple of an operator ('!', '&&' or '||')
+# and its operands.
+Expr = Union[str, tuple]
+
+
+def parse_constraint(expr: str) -> Expr:
+    """
+    Parses a //go:build expression, with Go's precedence: '!' binds tighter
+    than '&&', which binds tighter than '||'. Chains of the same operator are
+    flattened into a single node.
+    """
+    tokens = []
+    pos = 0
+    expr = expr.rstrip()
+    while pos < len(expr):
+        match = _TOKEN.match(expr, pos)
+        if not match:
+            raise BuildConstraintError(f"unexpected character at {pos}: {expr!r}")
+        tokens.append(match.group(1))
+        pos = match.end()
+
+    def parse_or(i):
+        node, i = parse_and(i)
+        operands = [node]
+        while i < len(tokens) and tokens[i] == "||":
+            node, i = parse_and(i + 1)
+            operands.append(node)
+        return (operands[0] if len(operands) == 1 else ("||", *operands)), i
+
+    def parse_and(i):
+        node, i = parse_not(i)
+        operands = [node]
+        while i < len(tokens) and tokens[i] == "&&":
+            node, i = parse_not(i + 1)
+            operands.append(node)
+        return (operands[0] if len(operands) == 1 else ("&&", *operands)), i
+
+    def parse_not(i):
+        if i >= len(tokens):
+            raise BuildConstraintError(f"unexpected end of expression: {expr!r}")
+        token = tokens[i]
+        if token == "!":
+            node, i = parse_not(i + 1)
+            return ("!", node), i
+        if token == "(":
+            node, i = parse_or(i + 1)
+            if i >= len(tokens) or tokens[i] != ")":
+                raise BuildConstraintError(f"missing ')': {expr!r}")
+            return node, i + 1
+        if token in ("&&", "||", ")"):
+            raise BuildConstraintError(f"unexpected {token!r}: {expr!r}")
+        return token, i + 1
+
+    if not tokens:
+        raise BuildConstraintError("empty expression")
+    node, i = parse_or(0)
+    if i != len(tokens):
+        raise BuildConstraintError(f"unexpected {tokens[i]!r}: {expr!r}")
+    return node
+
+
+def read_constraint(path: Path) -> Optional[str]:
+    """
+    Returns the //go:build expression of a Go file, or None if it has none.
+    Only the header before the package clause is considered, as in Go.
+    """
+    for line in path.read_text().splitlines():
+        stripped = line.strip()
+        if stripped.startswith("//go:build"):
+            return stripped[len("//go:build") :].strip()
+        if stripped.startswith("package "):
+            break
+    return None
+
+
+def check_constraint(kind: str, expr: str) -> List[str]:
+    """
+    Checks a component file's constraint against the convention and returns
+    the problems found, an empty list when it conforms.
+    """
+    try:
+        node = parse_constraint(expr)
+    except BuildConstraintError as e:
+        return [f"can't parse build constraint: {e}"]
+
+    terms = list(node[1:]) if isinstance(node, tuple) and node[0] == "&&" else [node]
+    if terms[0] != CUSTOM_TAG:
+        return [f"build constraint must start with '{CUSTOM_TAG} &&'"]
+    if len(terms) < 2:
+        return [f"build constraint selects no component beyond '{CUSTOM_TAG}'"]
+
+    problems = []
+    selection, exclusions = terms[1], terms[2:]
+
+    kind_prefix = f"lambdacomponents.{kind}."
+    kind_all = f"{kind_prefix}all"
+
+    if isinstance(selection, str):
+        # A single tag: only allowed for components that are opt-in by design.
+        if selection not in EXPLICIT_ONLY_COMPONENTS:
+            problems.append(
+                f"'{selection}' must be combined with '{GLOBAL_ALL_TAG}' and "
+                f"'{kind_all}', or listed in EXPLICIT_ONLY_COMPONENTS"
+            )
+    elif selection[0] == "||" and all(isinstance(t, str) for t in selection[1:]):
+        tags = list(selection[1:])
+        names = [
+            t for t in tags if t.startswith(kind_prefix) and t != kind_all
+        ]
+        if GLOBAL_ALL_TAG not in tags:
+            problems.append(f"selection is missing '{GLOBAL_ALL_TAG}'")
+        if kind_all not in tags:
+            problems.append(f"selection is missing '{kind_all}'")
+        if len(names) != 1:
+            problems.append(
+                f"selection must name exactly one '{kind_prefix}<name>' tag"
+            )
+        others = [
+            t
+            for t in tags
+            if t not in (GLOBAL_ALL_TAG, kind_all, CORE_TAG) and t not in names
+        ]
+        if others:
+            problems.append(f"unexpected tags in selection: {', '.join(others)}")
+    else:
+        problems.append(
+            "selection must be a parenthesized '||' of tags after "
+            f"'{CUSTOM_TAG} &&'"
+        )
+
+    for term in exclusions:
+        if not (
+            isinstance(term, tuple)
+            and term[0] == "!"
+            and isinstance(term[1], str)
+            and term[1] in EXCLUSION_TAGS
+        ):
+            allowed = " or ".join(f"'!{t}'" for t in EXCLUSION_TAGS)
+            problems.append(f"unexpected term {_format(term)}, expected {allowed}")
+
+    return problems
+
+
+def validate_component_constraints(lambdacomponents_dir: Path) -> Dict[str, List[str]]:
+    """
+    Checks every component file under the lambdacomponents directory and
+    returns the problems by file path, relative to the directory. Files
+    without problems are left out.
+    """
+    results = {}
+    for kind in COMPONENT_KINDS:
+        kind_dir = lambdacomponents_dir / kind
+        if not kind_dir.is_dir():
+            continue
+        for path in sorted(kind_dir.glob("*.go")):
+            expr = read_constraint(path)
+            if path.name in PACKAGE_FILES:
+                problems = (
+                    []
+                    if expr == CUSTOM_TAG
+                    else [f"package file must be constrained by '{CUSTOM_TAG}' alone"]
+                )
+            elif expr is None:
+                problems = ["missing //go:build constraint"]
+            else:
+                problems = check_constraint(kind, expr)
+            if problems:
+                results[str(path.relative_to(lambdacomponents_dir))] = problems
+    return results
+
+
+def component_tags(lambdacomponents_dir: Path) -> Set[str]:
+    """
+    Returns the tags that select a single component, lambdacomponents.<kind>.<name>,
+    used in the build constraints of the component files.
+    """
+    tags = set()
+    for kind in COMPONENT_KINDS:
+        kind_dir = lambdacomponents_dir / kind
+        if not kind_dir.is_dir():
+            continue
+        kind_all = f"lambdacomponents.{kind}.all"
+        for path in sorted(kind_dir.glob("*.go")):
+            expr = read_constraint(path)
+            if path.name in PACKAGE_FILES or expr is None:
+                continue
+            try:
+                names = _tag_names(parse_constraint(expr))
+            except BuildConstraintError:
+                continue
+            tags.update(
+                t for t in names if t.startswith(f"lambdacomponents.{kind}.") and t != kind_all
+            )
+    return tags
+
+
+def components_excluded_by(lambdacomponents_dir: Path, tag: str) -> Set[str]:
+    """
+    Returns the tags of the components whose build constraint excludes them
+    with '&& !<tag>'.
+    """
+    excluded = set()
+    for kind in COMPONENT_KINDS:
+        kind_dir = lambdacomponents_dir / kind
+        if not kind_dir.is_dir():
+            continue
+        kind_prefix = f"lambdacomponents.{kind}."
+        for path in sorted(kind_dir.glob("*.go")):
+            expr = read_constraint(path)
+            if path.name in PACKAGE_FILES or expr is None:
+                continue
+            try:
+                node = parse_constraint(expr)
+            except BuildConstraintError:
+                continue
+            terms = list(node[1:]) if isinstance(node, tuple) and node[0] == "&&" else [node]
+            if ("!", tag) in terms:
+                excluded.update(
+                    t for t in _tag_names(node) if t.startswith(kind_prefix) and t != f"{kind_prefix}all"
+                )
+    return excluded
+
+
+def _tag_names(node: Expr) -> Set[str]:
+    if isinstance(node, str):
+        return {node}
+    if node[0] == "!":
+        return set()
+    return set().union(*(_tag_names(n) for n in node[1:]))
+
+
+def _format(node: Expr) -> str:
+    if isinstance(node, str):
+        return node
+    if node[0] == "!":
+        return f"!{_format(node[1])}"
+    return "(" + f" {node[0]} ".join(_format(n) for n in node[1:]) + ")"
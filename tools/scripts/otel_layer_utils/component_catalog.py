@@ -0,0 +1,87 @@
+#!/usr/bin/env python3
+"""
+component_catalog.py
+
+Generates components/common/registry/catalog.go, which maps every component
+this repository provides to the build tag that selects it. The collector reads
+it to name the tag to build with when a configuration uses a component the
+layer wasn't built with; a tag can't be derived from the component type
+alone, e.g. the otlpjsonfile receiver is selected by
+lambdacomponents.receiver.otlpjson.
+
+Run it from the tools directory after adding or renaming a component:
+
+    python3 -m scripts.otel_layer_utils.component_catalog
+"""
+
+import re
+from pathlib import Path
+from typing import Dict
+
+from .build_constraints import COMPONENT_KINDS, PACKAGE_FILES
+
+REPO_ROOT = Path(__file__).resolve().parents[3]
+LAMBDACOMPONENTS_DIR = REPO_ROOT / "components" / "collector" / "lambdacomponents"
+CATALOG_PATH = REPO_ROOT / "components" / "common" / "registry" / "catalog.go"
+
+# Register(tag, module, typ, newFactory), as called by every component file.
+_REGISTER = re.compile(r'\bRegister\(\s*"([^"]+)",\s*"[^"]*",\s*"([^"]+)"')
+
+
+class CatalogError(Exception):
+    """Raised when the registrations can't be read from a component file."""
+
+    pass
+
+
+def collect_catalog(lambdacomponents_dir: Path) -> Dict[str, str]:
+    """
+    Returns the build tag of every component registered under the
+    lambdacomponents directory, keyed by '<kind>/<type>'.
+    """
+    catalog = {}
+    for kind in COMPONENT_KINDS:
+        kind_dir = lambdacomponents_dir / kind
+        if not kind_dir.is_dir():
+            continue
+        for path in sorted(kind_dir.glob("*.go")):
+            if path.name in PACKAGE_FILES or path.name.endswith("_test.go"):
+                continue
+            registrations = _REGISTER.findall(path.read_text())
+            if not registrations:
+                raise CatalogError(f"{kind}/{path.name} registers no component")
+            for tag, typ in registrations:
+                key = f"{kind}/{typ}"
+                if key in catalog and catalog[key] != tag:
+                    raise CatalogError(
+                        f"{key} is registered by both {catalog[key]} and {tag}"
+                    )
+                catalog[key] = tag
+    return catalog
+
+
+def render_catalog(catalog: Dict[str, str]) -> str:
+    """Returns the Go source of catalog.go for the given catalog."""
+    width = max((len(key) for key in catalog), default=0) + 3
+    lines = [
+        "// Code generated by tools/scripts/otel_layer_utils/component_catalog.py. DO NOT EDIT.",
+        "",
+        "package registry",
+        "",
+        "// catalog maps every component provided by this repository, keyed by kind",
+        "// and type, to the build tag that selects it.",
+        "var catalog = map[string]string{",
+    ]
+    for key in sorted(catalog):
+        lines.append(f'\t{(chr(34) + key + chr(34) + ":").ljust(width)} "{catalog[key]}",')
+    lines.append("}")
+    return "\n".join(lines) + "\n"
+
+
+def main() -> None:
+    CATALOG_PATH.write_text(render_catalog(collect_catalog(LAMBDACOMPONENTS_DIR)))
+    print(f"Wrote {CATALOG_PATH}")
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,281 @@
+#!/usr/bin/env python3
+"""
+upstream_patches.py
+
+Patches the upstream collector sources that can't be replaced by an overlay
+file, so that the layer's own entry points are called from them. The patches
+are only applied to the upstream commit pinned in config/upstream.yaml, which
+they were checked against, and each anchor they look for must appear exactly
+once: an upstream change would otherwise silently leave the feature that
+relies on it disconnected, or patch the wrong place.
+"""
+
+import re
+from pathlib import Path
+from typing import Callable, List, Optional, Tuple
+
+import yaml
+
+# Patches only apply to custom builds: the default build uses the upstream
+# lambdacomponents package as is.
+CUSTOM_TAG = "lambdacomponents.custom"
+
+MANAGER_PATH = Path("collector") / "internal" / "lifecycle" / "manager.go"
+COLLECTOR_PATH = Path("collector") / "internal" / "collector" / "collector.go"
+
+# The file pinning the upstream commit, relative to the repository root.
+PIN_PATH = Path("config") / "upstream.yaml"
+
+
+class UpstreamPatchError(Exception):
+    """
+    Raised when the upstream checkout isn't the pinned commit, or the anchor of
+    a patch doesn't appear exactly once in the upstream source.
+    """
+
+    pass
+
+
+def read_pinned_commit(pin_file: Path) -> Optional[str]:
+    """Returns the upstream commit pinned in pin_file, or None if there is none."""
+    if not pin_file.is_file():
+        return None
+    pin = yaml.safe_load(pin_file.read_text()) or {}
+    return pin.get("commit") or None
+
+
+def write_pinned_commit(pin_file: Path, commit: str) -> None:
+    """Pins commit in pin_file, as the one the patches were checked against."""
+    pin_file.write_text(
+        "# The upstream commit the patches in\n"
+        "# tools/scripts/otel_layer_utils/upstream_patches.py were checked against.\n"
+        "# Custom builds of any other commit fail: build with --pin-upstream to check\n"
+        "# the patches against a new one and pin it.\n"
+        + yaml.safe_dump({"commit": commit})
+    )
+
+
+def _match_once(pattern: "re.Pattern[str]", source: str, anchor: str) -> "re.Match[str]":
+    """Returns the only match of pattern in source, naming anchor otherwise."""
+    matches = list(pattern.finditer(source))
+    if len(matches) != 1:
+        raise UpstreamPatchError(f"{anchor} found {len(matches)} times, expected exactly once")
+    return matches[0]
+
+
+def _not_patched(source: str, call: str) -> None:
+    """Fails if upstream already makes the call a patch adds."""
+    if call in source:
+        raise UpstreamPatchError(f"upstream already calls {call[:-1]}, drop the patch adding it")
+
+
+# lambdacomponents.Components returns an error, which upstream discards.
+_COMPONENTS_CALL = re.compile(
+    r"^(?P<indent>[ \t]*)(?P<var>\w+), _ :?= lambdacomponents\.Components\((?P<args>[^)]*)\)[ \t]*$",
+    re.MULTILINE,
+)
+
+
+def patch_components_error(source: str) -> str:
+    """
+    Makes the lifecycle manager stop the extension when the components can't
+    be assembled, instead of starting the collector without them.
+    """
+    match = _match_once(_COMPONENTS_CALL, source, "the lambdacomponents.Components call discarding its error")
+    indent, var, args = match.group("indent"), match.group("var"), match.group("args")
+    replacement = (
+        f"{indent}{var}, componentsErr := lambdacomponents.Components({args})\n"
+        f"{indent}if componentsErr != nil {{\n"
+        f'{indent}\tlogger.Fatal("Cannot assemble the collector components", zap.Error(componentsErr))\n'
+        f"{indent}}}"
+    )
+    return source[: match.start()] + replacement + source[match.end() :]
+
+
+ASSEMBLY_IMPORT = "github.com/open-telemetry/opentelemetry-lambda/collector/common/assembly"
+
+
+def add_import(source: str, import_path: str) -> str:
+    """Adds import_path to the import block of a Go file, unless it is there."""
+    if f'"{import_path}"' in source:
+        return source
+    match = _match_once(re.compile(r"^import \($", re.MULTILINE), source, "the import block")
+    return source[: match.end()] + f'\n\t"{import_path}"' + source[match.end() :]
+
+
+# The variable holding the event returned by NextEvent, e.g. 'res.EventType'.
+_EVENT_VAR = re.compile(r"\b(\w+)\.EventType\b")
+
+
+def _insert_in_branch(source: str, event_type: str, statements: List[str]) -> str:
+    """
+    Inserts statements at the start of the branch handling events of the given
+    type: after the 'if <var>.EventType == extensionapi.<type> {' or
+    'case extensionapi.<type>:' line.
+    """
+    branch = _match_once(
+        re.compile(
+            rf"^(?P<indent>[ \t]*)(?:(?:}} else )?if \w+\.EventType == extensionapi\.{event_type} \{{|case extensionapi\.{event_type}:)[ \t]*$",
+            re.MULTILINE,
+        ),
+        source,
+        f"the branch handling the extensionapi.{event_type} event",
+    )
+    indent = branch.group("indent") + "\t"
+    inserted = "".join(f"\n{indent}{line}" if line else "\n" for line in statements)
+    return source[: branch.end()] + inserted + source[branch.end() :]
+
+
+def _event_var(source: str) -> str:
+    names = {m.group(1) for m in _EVENT_VAR.finditer(source)}
+    if len(names) != 1:
+        raise UpstreamPatchError(
+            f"the events returned by NextEvent are read from {len(names)} variables, expected exactly one"
+        )
+    return names.pop()
+
+
+def patch_invoke(source: str) -> str:
+    """
+    Makes the lifecycle manager report every INVOKE event to assembly.Invoke,
+    which tracks the invocation the telemetry belongs to.
+    """
+    _not_patched(source, "assembly.Invoke(")
+    event = _event_var(source)
+    source = _insert_in_branch(source, "Invoke", [f"assembly.Invoke({event}.RequestID)"])
+    return add_import(source, ASSEMBLY_IMPORT)
+
+
+ZAP_IMPORT = "go.uber.org/zap"
+
+# A method declaration, e.g. 'func (lm *manager) processEvents(ctx context.Context)'.
+_METHOD = re.compile(r"^func \((?P<receiver>\w+) \*?\w+\) \w+\((?P<params>[^)]*)\)", re.MULTILINE)
+
+
+def patch_shutdown(source: str) -> str:
+    """
+    Makes the lifecycle manager drain the components through
+    assembly.Shutdown when it receives the SHUTDOWN event, by the deadline the
+    event carries, before it stops the collector.
+    """
+    _not_patched(source, "assembly.Shutdown(")
+    event = _event_var(source)
+    methods = [m for m in _METHOD.finditer(source) if m.start() < source.find(f"{event}.EventType")]
+    if not methods or "ctx context.Context" not in methods[-1].group("params"):
+        raise UpstreamPatchError("the events are no longer read by a method taking a context")
+    receiver = methods[-1].group("receiver")
+    source = _insert_in_branch(
+        source,
+        "Shutdown",
+        [
+            f"if err := assembly.Shutdown(ctx, {event}.DeadlineMs); err != nil {{",
+            f'\t{receiver}.logger.Warn("Components did not drain before the shutdown deadline", zap.Error(err))',
+            "}",
+        ],
+    )
+    return add_import(add_import(source, ZAP_IMPORT), ASSEMBLY_IMPORT)
+
+
+def _field_value_end(source: str, start: int) -> int:
+    """
+    Returns the end of the Go expression starting at start: the first comma or
+    newline outside brackets.
+    """
+    depth = 0
+    for i in range(start, len(source)):
+        c = source[i]
+        if c in "([{":
+            depth += 1
+        elif c in ")]}":
+            if depth == 0:
+                return i
+            depth -= 1
+        elif c in ",\n" and depth == 0:
+            return i
+    raise UpstreamPatchError("unterminated expression")
+
+
+def _wrap_field(source: str, field: str, function: str) -> str:
+    """
+    Passes the value of the given field of a composite literal, a slice, to
+    function as its variadic arguments: 'Field: x,' becomes
+    'Field: function(x...),'.
+    """
+    _not_patched(source, f"{function}(")
+    match = _match_once(re.compile(rf"\b{field}:[ \t]*"), source, f"the {field} field")
+    end = _field_value_end(source, match.end())
+    value = source[match.end() : end].rstrip()
+    if not value:
+        raise UpstreamPatchError(f"{field} has no value")
+    end = match.end() + len(value)
+    return source[: match.end()] + f"{function}({value}...)" + source[end:]
+
+
+def patch_config_providers(source: str) -> str:
+    """
+    Makes the collector resolve its configuration with the providers returned
+    by assembly.ConfigProviderFactories, which adds those of this layer to the
+    upstream ones.
+    """
+    source = _wrap_field(source, "ProviderFactories", "assembly.ConfigProviderFactories")
+    return add_import(source, ASSEMBLY_IMPORT)
+
+
+def patch_config_converters(source: str) -> str:
+    """
+    Makes the collector run the converters returned by
+    assembly.ConfigConverterFactories, which adds those of this layer after
+    the upstream ones, on its resolved configuration.
+    """
+    source = _wrap_field(source, "ConverterFactories", "assembly.ConfigConverterFactories")
+    return add_import(source, ASSEMBLY_IMPORT)
+
+
+# The patches applied to each upstream file, in order.
+PATCHES: List[Tuple[Path, Callable[[str], str]]] = [
+    (MANAGER_PATH, patch_components_error),
+    (MANAGER_PATH, patch_invoke),
+    (MANAGER_PATH, patch_shutdown),
+    (COLLECTOR_PATH, patch_config_providers),
+    (COLLECTOR_PATH, patch_config_converters),
+]
+
+
+def apply_upstream_patches(
+    upstream_dir: Path,
+    active_build_tags: List[str],
+    commit: str,
+    pinned_commit: Optional[str],
+) -> List[str]:
+    """
+    Applies PATCHES to the upstream checkout of commit and returns the relative
+    paths of the files that were changed. Nothing is patched unless the build
+    is a custom one. commit must be pinned_commit, the one the patches were
+    checked against; pass pinned_commit=commit to check them against a new one.
+    """
+    if CUSTOM_TAG not in active_build_tags:
+        return []
+    if pinned_commit is None:
+        raise UpstreamPatchError(
+            f"no upstream commit is pinned in {PIN_PATH}: build with --pin-upstream to check the patches against {commit} and pin it"
+        )
+    if commit != pinned_commit:
+        raise UpstreamPatchError(
+            f"the patches were checked against upstream commit {pinned_commit}, not {commit}: "
+            "build with --pin-upstream to check them against it and pin it"
+        )
+    sources = {}
+    for relative_path, patch in PATCHES:
+        path = upstream_dir / relative_path
+        if not path.is_file():
+            raise UpstreamPatchError(f"{relative_path} not found in the upstream repository")
+        source = sources.get(relative_path, path.read_text())
+        try:
+            sources[relative_path] = patch(source)
+        except UpstreamPatchError as e:
+            raise UpstreamPatchError(f"{relative_path}: {e}") from e
+    # Files are only written once every patch applied, so a failed patch
+    # leaves the checkout untouched.
+    for relative_path, source in sources.items():
+        (upstream_dir / relative_path).write_text(source)
+    return [str(relative_path) for relative_path in sources]
@@ -0,0 +1,157 @@
+from pathlib import Path
+
+import pytest
+import yaml
+
+from scripts.otel_layer_utils.build_constraints import (
+    BuildConstraintError,
+    check_constraint,
+    component_tags,
+    components_excluded_by,
+    parse_constraint,
+    validate_component_constraints,
+)
+
+REPO_ROOT = Path(__file__).resolve().parents[2]
+REPO_COMPONENTS = REPO_ROOT / "components" / "collector" / "lambdacomponents"
+
+# Tags selecting components that live in the upstream tree rather than here.
+UPSTREAM_COMPONENT_TAGS = {"lambdacomponents.processor.decouple"}
+
+# Tags that select profiles or groups of components rather than one component.
+PROFILE_TAGS = {
+    "lambdacomponents.custom",
+    "lambdacomponents.all",
+    "lambdacomponents.core",
+    "lambdacomponents.metricsonly",
+}
+
+WELL_FORMED = """//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.myexporter) && !lambdacomponents.metricsonly
+
+package exporter
+
+import (
+	"github.com/actual-repo/myexporter"
+	"go.opentelemetry.io/collector/exporter"
+)
+
+func init() {
+	Register("lambdacomponents.exporter.myexporter", "github.com/actual-repo/myexporter", "myexporter", func(extensionId string) exporter.Factory {
+		return myexporter.NewFactory()
+	})
+}
+"""
+
+# Forgets lambdacomponents.all, so the 'all' builds would leave it out.
+MALFORMED = """//go:build lambdacomponents.custom && (lambdacomponents.exporter.all || lambdacomponents.exporter.myexporter)
+
+package exporter
+"""
+
+
+def write_component(root: Path, kind: str, name: str, content: str) -> None:
+    kind_dir = root / kind
+    kind_dir.mkdir(parents=True, exist_ok=True)
+    (kind_dir / f"{name}.go").write_text(content)
+
+
+def test_parse_constraint_precedence():
+    assert parse_constraint("a && b || !c && d") == (
+        "||",
+        ("&&", "a", "b"),
+        ("&&", ("!", "c"), "d"),
+    )
+    assert parse_constraint("a && (b || c || d)") == ("&&", "a", ("||", "b", "c", "d"))
+
+
+def test_parse_constraint_rejects_unbalanced():
+    with pytest.raises(BuildConstraintError):
+        parse_constraint("a && (b || c")
+    with pytest.raises(BuildConstraintError):
+        parse_constraint("a &&")
+
+
+def test_check_constraint_accepts_core_selection_and_exclusions():
+    assert (
+        check_constraint(
+            "receiver",
+            "lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.otlp || lambdacomponents.core)",
+        )
+        == []
+    )
+    assert (
+        check_constraint(
+            "connector",
+            "lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.connector.all || lambdacomponents.connector.datadog) && !lambdacomponents.metricsonly && !lambdacomponents.core",
+        )
+        == []
+    )
+
+
+def test_check_constraint_allows_explicit_only_components():
+    assert (
+        check_constraint(
+            "exporter", "lambdacomponents.custom && lambdacomponents.exporter.debug"
+        )
+        == []
+    )
+    assert check_constraint(
+        "exporter", "lambdacomponents.custom && lambdacomponents.exporter.kafka"
+    )
+
+
+def test_check_constraint_reports_deviations():
+    assert check_constraint(
+        "exporter",
+        "(lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.kafka)",
+    )
+    assert check_constraint(
+        "exporter",
+        "lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.kafka)",
+    ) == ["selection is missing 'lambdacomponents.exporter.all'"]
+    assert check_constraint(
+        "exporter",
+        "lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.exporter.kafka)",
+    )
+    assert check_constraint(
+        "exporter",
+        "lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.exporter.all || lambdacomponents.exporter.kafka) && !lambdacomponents.all",
+    )
+
+
+def test_validate_component_constraints_fixtures(tmp_path):
+    write_component(tmp_path, "exporter", "good", WELL_FORMED)
+    write_component(tmp_path, "exporter", "bad", MALFORMED)
+    write_component(tmp_path, "exporter", "registry", "//go:build lambdacomponents.custom\n\npackage exporter\n")
+    write_component(tmp_path, "exporter", "missing", "package exporter\n")
+
+    results = validate_component_constraints(tmp_path)
+    assert set(results) == {"exporter/bad.go", "exporter/missing.go"}
+    assert results["exporter/bad.go"] == ["selection is missing 'lambdacomponents.all'"]
+    assert results["exporter/missing.go"] == ["missing //go:build constraint"]
+
+
+def test_components_excluded_by(tmp_path):
+    write_component(tmp_path, "exporter", "myexporter", WELL_FORMED)
+    write_component(tmp_path, "exporter", "bad", MALFORMED)
+    write_component(tmp_path, "exporter", "registry", "//go:build lambdacomponents.custom && !lambdacomponents.metricsonly\n\npackage exporter\n")
+
+    assert components_excluded_by(tmp_path, "lambdacomponents.metricsonly") == {"lambdacomponents.exporter.myexporter"}
+    assert components_excluded_by(tmp_path, "lambdacomponents.core") == set()
+
+
+def test_repository_components_follow_convention():
+    assert validate_component_constraints(REPO_COMPONENTS) == {}
+
+
+def test_distribution_tags_select_components():
+    distributions = yaml.safe_load((REPO_ROOT / "config" / "distributions.yaml").read_text())
+    known = component_tags(REPO_COMPONENTS) | UPSTREAM_COMPONENT_TAGS
+    unknown = {}
+    for name, distribution in distributions.items():
+        for tag in distribution.get("buildtags", []):
+            if tag in PROFILE_TAGS or tag.endswith(".all"):
+                continue
+            if tag not in known:
+                unknown.setdefault(name, []).append(tag)
+    assert unknown == {}
@@ -1,4 +1,16 @@
-from scripts.build_extension_layer import resolve_components_by_tags
+from pathlib import Path
+
+from scripts.build_extension_layer import (
+    CORE_EXCLUDED_COMPONENTS,
+    CORE_PROFILE_TAG,
+    METRICSONLY_EXCLUDED_COMPONENTS,
+    METRICSONLY_PROFILE_TAG,
+    resolve_components_by_tags,
+    selective_copy_components,
+)
+from scripts.otel_layer_utils.build_constraints import components_excluded_by
+
+REPO_COMPONENTS = Path(__file__).resolve().parents[2] / "components" / "collector" / "lambdacomponents"
 
 
 def test_resolve_components_by_tags_with_global_all():
@@ -33,3 +45,103 @@ def test_resolve_components_by_tags_with_direct_match():
     }
     included = resolve_components_by_tags(active_tags, dependency_mappings)
     assert included == ["lambdacomponents.exporter.clickhouse"]
+
+
+def test_resolve_components_by_tags_with_core_profile():
+    active_tags = ["lambdacomponents.core"]
+    dependency_mappings = {
+        "lambdacomponents.receiver.otlp": ["dep1"],
+        "lambdacomponents.exporter.otlphttp": ["dep2"],
+        "lambdacomponents.exporter.kafka": ["dep3"],
+    }
+    included = resolve_components_by_tags(active_tags, dependency_mappings)
+    assert included == [
+        "lambdacomponents.receiver.otlp",
+        "lambdacomponents.exporter.otlphttp",
+    ]
+
+
+def test_resolve_components_by_tags_core_excludes_heavy_exporters():
+    active_tags = [
+        "lambdacomponents.core",
+        "lambdacomponents.all",
+        "lambdacomponents.exporter.datadog",
+    ]
+    dependency_mappings = {
+        "lambdacomponents.exporter.otlp": ["dep1"],
+        "lambdacomponents.exporter.kafka": ["dep2"],
+        "lambdacomponents.exporter.clickhouse": ["dep3"],
+        "lambdacomponents.exporter.datadog": ["dep4"],
+        "lambdacomponents.connector.datadog": ["dep5"],
+    }
+    included = resolve_components_by_tags(active_tags, dependency_mappings)
+    assert included == [
+        "lambdacomponents.exporter.otlp",
+        "lambdacomponents.exporter.kafka",
+    ]
+
+
+def test_resolve_components_by_tags_metricsonly_excludes_trace_and_log_components():
+    active_tags = [
+        "lambdacomponents.metricsonly",
+        "lambdacomponents.all",
+        "lambdacomponents.exporter.awsxray",
+    ]
+    dependency_mappings = {
+        "lambdacomponents.exporter.otlp": ["dep1"],
+        "lambdacomponents.exporter.awsxray": ["dep2"],
+        "lambdacomponents.connector.spanmetrics": ["dep3"],
+        "lambdacomponents.connector.servicegraph": ["dep4"],
+        "lambdacomponents.connector.exceptions": ["dep5"],
+        "lambdacomponents.receiver.filelog": ["dep6"],
+        "lambdacomponents.connector.routing": ["dep7"],
+    }
+    included = resolve_components_by_tags(active_tags, dependency_mappings)
+    assert included == [
+        "lambdacomponents.exporter.otlp",
+        "lambdacomponents.connector.routing",
+    ]
+
+
+def test_profile_exclusions_match_build_constraints():
+    # The lists mirror the '&& !<profile>' constraints of the component files.
+    for tag, excluded in [
+        (CORE_PROFILE_TAG, CORE_EXCLUDED_COMPONENTS),
+        (METRICSONLY_PROFILE_TAG, METRICSONLY_EXCLUDED_COMPONENTS),
+    ]:
+        assert sorted(excluded) == sorted(components_excluded_by(REPO_COMPONENTS, tag)), tag
+
+
+def _write_component(root, type_dir, name):
+    path = root / "collector" / "lambdacomponents" / type_dir / name
+    path.parent.mkdir(parents=True, exist_ok=True)
+    path.write_text("package " + type_dir + "\n")
+
+
+def test_selective_copy_components_copies_support_files(tmp_path):
+    component_dir = tmp_path / "components"
+    upstream_dir = tmp_path / "upstream"
+    _write_component(component_dir, "exporter", "clickhouse.go")
+    _write_component(component_dir, "exporter", "awss3.go")
+    _write_component(component_dir, "exporter", "registry.go")
+    _write_component(component_dir, "connector", "registry.go")
+    entry_point = component_dir / "collector" / "lambdacomponents" / "custom.go"
+    entry_point.write_text("package lambdacomponents\n")
+    dependency_mappings = {
+        "lambdacomponents.exporter.clickhouse": ["dep1"],
+        "lambdacomponents.exporter.awss3": ["dep2"],
+    }
+
+    selective_copy_components(
+        component_dir,
+        upstream_dir,
+        ["lambdacomponents.exporter.clickhouse"],
+        dependency_mappings,
+    )
+
+    lambdacomponents = upstream_dir / "collector" / "lambdacomponents"
+    assert (lambdacomponents / "exporter" / "clickhouse.go").is_file()
+    assert (lambdacomponents / "exporter" / "registry.go").is_file()
+    assert (lambdacomponents / "connector" / "registry.go").is_file()
+    assert (lambdacomponents / "custom.go").read_text() == "package lambdacomponents\n"
+    assert not (lambdacomponents / "exporter" / "awss3.go").exists()
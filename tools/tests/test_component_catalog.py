@@ -0,0 +1,62 @@
+from pathlib import Path
+
+import pytest
+
+from scripts.otel_layer_utils.component_catalog import (
+    CATALOG_PATH,
+    LAMBDACOMPONENTS_DIR,
+    CatalogError,
+    collect_catalog,
+    render_catalog,
+)
+
+COMPONENT = """//go:build lambdacomponents.custom && (lambdacomponents.all || lambdacomponents.receiver.all || lambdacomponents.receiver.{name})
+
+package receiver
+
+func init() {{
+	Register("lambdacomponents.receiver.{name}", "example.com/{name}", "{typ}", func(extensionId string) receiver.Factory {{
+		return {name}.NewFactory()
+	}})
+}}
+"""
+
+
+def write_component(root: Path, name: str, typ: str) -> None:
+    kind_dir = root / "receiver"
+    kind_dir.mkdir(parents=True, exist_ok=True)
+    (kind_dir / f"{name}.go").write_text(COMPONENT.format(name=name, typ=typ))
+
+
+def test_collect_catalog_records_the_registered_type(tmp_path):
+    write_component(tmp_path, "otlpjson", "otlpjsonfile")
+    write_component(tmp_path, "otlp", "otlp")
+    (tmp_path / "receiver" / "registry.go").write_text("package receiver\n")
+
+    assert collect_catalog(tmp_path) == {
+        "receiver/otlp": "lambdacomponents.receiver.otlp",
+        "receiver/otlpjsonfile": "lambdacomponents.receiver.otlpjson",
+    }
+
+
+def test_collect_catalog_rejects_conflicting_tags(tmp_path):
+    write_component(tmp_path, "otlpjson", "otlpjsonfile")
+    write_component(tmp_path, "jsonfile", "otlpjsonfile")
+    with pytest.raises(CatalogError):
+        collect_catalog(tmp_path)
+
+
+def test_collect_catalog_rejects_files_without_registrations(tmp_path):
+    (tmp_path / "receiver").mkdir()
+    (tmp_path / "receiver" / "otlp.go").write_text("package receiver\n")
+    with pytest.raises(CatalogError):
+        collect_catalog(tmp_path)
+
+
+def test_render_catalog_aligns_entries():
+    source = render_catalog({"receiver/otlp": "a", "exporter/awss3": "b"})
+    assert '\t"exporter/awss3": "b",\n\t"receiver/otlp":  "a",\n}\n' in source
+
+
+def test_checked_in_catalog_is_current():
+    assert CATALOG_PATH.read_text() == render_catalog(collect_catalog(LAMBDACOMPONENTS_DIR))
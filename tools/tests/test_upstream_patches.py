@@ -0,0 +1,314 @@
+import pytest
+
+from scripts.otel_layer_utils.upstream_patches import (
+    ASSEMBLY_IMPORT,
+    COLLECTOR_PATH,
+    MANAGER_PATH,
+    UpstreamPatchError,
+    add_import,
+    apply_upstream_patches,
+    patch_components_error,
+    patch_config_converters,
+    patch_config_providers,
+    patch_invoke,
+    patch_shutdown,
+    read_pinned_commit,
+    write_pinned_commit,
+)
+
+COMMIT = "0123456789abcdef0123456789abcdef01234567"
+
+# Abridged from collector/internal/lifecycle/manager.go upstream.
+MANAGER = """package lifecycle
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+func NewManager(ctx context.Context, logger *zap.Logger, version string) (context.Context, *manager) {
+	res, err := extensionClient.Register(ctx, extensionName)
+	if err != nil {
+		logger.Fatal("Cannot register extension", zap.Error(err))
+	}
+
+	factories, _ := lambdacomponents.Components(res.ExtensionID)
+	lm.collector = collector.NewCollector(logger, factories, version)
+	return ctx, lm
+}
+
+func (lm *manager) processEvents(ctx context.Context) error {
+	for {
+		res, err := lm.extensionClient.NextEvent(ctx)
+		if err != nil {
+			return err
+		}
+
+		if res.EventType == extensionapi.Shutdown {
+			lm.logger.Info("Received SHUTDOWN event")
+			err = lm.collector.Stop()
+			return err
+		} else if res.EventType == extensionapi.Invoke {
+			lm.notifyFunctionInvoked()
+		}
+	}
+}
+"""
+
+# The same event loop, with a switch on the event type.
+SWITCH_MANAGER = """package lifecycle
+
+import (
+	"context"
+)
+
+func (lm *manager) processEvents(ctx context.Context) error {
+	for {
+		event, err := lm.extensionClient.NextEvent(ctx)
+		if err != nil {
+			return err
+		}
+		switch event.EventType {
+		case extensionapi.Shutdown:
+			return lm.collector.Stop()
+		case extensionapi.Invoke:
+			lm.notifyFunctionInvoked()
+		}
+	}
+}
+"""
+
+# Abridged from collector/internal/collector/collector.go upstream.
+COLLECTOR = """package collector
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/otelcol"
+)
+
+func NewCollector(logger *zap.Logger, factories otelcol.Factories, version string) *Collector {
+	cfgSet := otelcol.ConfigProviderSettings{
+		ResolverSettings: confmap.ResolverSettings{
+			URIs: []string{getConfig(l)},
+			ProviderFactories: []confmap.ProviderFactory{fileprovider.NewFactory(), envprovider.NewFactory(),
+				s3provider.NewFactory(), secretsmanagerprovider.NewFactory()},
+			ConverterFactories: []confmap.ConverterFactory{
+				confmap.NewConverterFactory(disablequeuedretryconverter.New),
+			},
+		},
+	}
+	return &Collector{cfgProSet: cfgSet}
+}
+"""
+
+
+def test_patch_components_error_stops_on_error():
+    patched = patch_components_error(MANAGER)
+    assert "factories, _ :=" not in patched
+    assert (
+        "\tfactories, componentsErr := lambdacomponents.Components(res.ExtensionID)\n"
+        "\tif componentsErr != nil {\n"
+        '\t\tlogger.Fatal("Cannot assemble the collector components", zap.Error(componentsErr))\n'
+        "\t}\n"
+        "\tlm.collector = collector.NewCollector(logger, factories, version)\n"
+    ) in patched
+
+
+def test_patch_components_error_requires_a_discarded_error():
+    handled = MANAGER.replace("factories, _ :=", "factories, err :=")
+    with pytest.raises(UpstreamPatchError, match="found 0 times"):
+        patch_components_error(handled)
+
+
+def test_patch_components_error_requires_a_single_call():
+    twice = MANAGER.replace(
+        "\tlm.collector = collector.NewCollector",
+        "\tfactories, _ = lambdacomponents.Components(res.ExtensionID)\n\tlm.collector = collector.NewCollector",
+    )
+    with pytest.raises(UpstreamPatchError, match="found 2 times, expected exactly once"):
+        patch_components_error(twice)
+
+
+def test_patch_components_error_requires_the_call():
+    with pytest.raises(UpstreamPatchError):
+        patch_components_error(MANAGER.replace("lambdacomponents.Components", "components.All"))
+
+
+def test_add_import():
+    patched = add_import(MANAGER, "example.com/assembly")
+    assert 'import (\n\t"example.com/assembly"\n\t"context"' in patched
+    assert add_import(patched, "example.com/assembly") == patched
+    with pytest.raises(UpstreamPatchError):
+        add_import("package lifecycle\n", "example.com/assembly")
+
+
+def test_patch_invoke():
+    for source, event in [(MANAGER, "res"), (SWITCH_MANAGER, "event")]:
+        patched = patch_invoke(source)
+        assert (
+            f"extensionapi.Invoke{' {' if source is MANAGER else ':'}\n"
+            f"\t\t\tassembly.Invoke({event}.RequestID)\n"
+            "\t\t\tlm.notifyFunctionInvoked()\n"
+        ) in patched
+        assert '"github.com/open-telemetry/opentelemetry-lambda/collector/common/assembly"' in patched
+        with pytest.raises(UpstreamPatchError, match="upstream already calls assembly.Invoke"):
+            patch_invoke(patched)
+
+
+def test_patch_invoke_requires_the_branch():
+    with pytest.raises(UpstreamPatchError):
+        patch_invoke(MANAGER.replace("extensionapi.Invoke", "extensionapi.Invocation"))
+
+
+def test_patch_invoke_requires_a_single_branch():
+    twice = MANAGER.replace(
+        "\t\t} else if res.EventType == extensionapi.Invoke {",
+        "\t\t} else if res.EventType == extensionapi.Invoke {\n"
+        "\t\t\tlm.notifyFunctionInvoked()\n"
+        "\t\t} else if res.EventType == extensionapi.Invoke {",
+    )
+    with pytest.raises(UpstreamPatchError, match="found 2 times"):
+        patch_invoke(twice)
+
+
+def test_patch_invoke_requires_a_single_event_variable():
+    with pytest.raises(UpstreamPatchError, match="read from 2 variables"):
+        patch_invoke(MANAGER.replace("} else if res.EventType", "} else if other.EventType"))
+
+
+def test_patch_shutdown():
+    for source, event in [(MANAGER, "res"), (SWITCH_MANAGER, "event")]:
+        patched = patch_shutdown(source)
+        assert (
+            f"extensionapi.Shutdown{' {' if source is MANAGER else ':'}\n"
+            f"\t\t\tif err := assembly.Shutdown(ctx, {event}.DeadlineMs); err != nil {{\n"
+            '\t\t\t\tlm.logger.Warn("Components did not drain before the shutdown deadline", zap.Error(err))\n'
+            "\t\t\t}\n"
+        ) in patched
+        assert patched.index("assembly.Shutdown(") < patched.index("lm.collector.Stop()")
+        assert '\t"go.uber.org/zap"' in patched
+        assert '"github.com/open-telemetry/opentelemetry-lambda/collector/common/assembly"' in patched
+        with pytest.raises(UpstreamPatchError, match="upstream already calls assembly.Shutdown"):
+            patch_shutdown(patched)
+
+
+def test_patch_shutdown_requires_a_context():
+    with pytest.raises(UpstreamPatchError):
+        patch_shutdown(MANAGER.replace("processEvents(ctx context.Context)", "processEvents()"))
+    with pytest.raises(UpstreamPatchError):
+        patch_shutdown(MANAGER.replace("extensionapi.Shutdown", "extensionapi.Stop"))
+
+
+def test_patch_config_providers():
+    patched = patch_config_providers(COLLECTOR)
+    assert (
+        "\t\t\tProviderFactories: assembly.ConfigProviderFactories([]confmap.ProviderFactory{fileprovider.NewFactory(), envprovider.NewFactory(),\n"
+        "\t\t\t\ts3provider.NewFactory(), secretsmanagerprovider.NewFactory()}...),\n"
+        "\t\t\tConverterFactories: []confmap.ConverterFactory{\n"
+    ) in patched
+    assert '"github.com/open-telemetry/opentelemetry-lambda/collector/common/assembly"' in patched
+    with pytest.raises(UpstreamPatchError, match="upstream already calls assembly.ConfigProviderFactories"):
+        patch_config_providers(patched)
+
+
+def test_patch_config_providers_wraps_a_variable():
+    source = COLLECTOR.replace(
+        "ProviderFactories: []confmap.ProviderFactory{fileprovider.NewFactory(), envprovider.NewFactory(),\n"
+        "\t\t\t\ts3provider.NewFactory(), secretsmanagerprovider.NewFactory()},",
+        "ProviderFactories: providerFactories,",
+    )
+    assert "ProviderFactories: assembly.ConfigProviderFactories(providerFactories...),\n" in patch_config_providers(source)
+
+
+def test_patch_config_providers_requires_the_field():
+    with pytest.raises(UpstreamPatchError):
+        patch_config_providers(COLLECTOR.replace("ProviderFactories:", "Providers:"))
+
+
+def test_patch_config_providers_requires_a_single_field():
+    twice = COLLECTOR.replace(
+        "\t\t\tConverterFactories:", "\t\t\tProviderFactories: []confmap.ProviderFactory{},\n\t\t\tConverterFactories:"
+    )
+    with pytest.raises(UpstreamPatchError, match="the ProviderFactories field found 2 times"):
+        patch_config_providers(twice)
+
+
+def test_patch_config_converters():
+    patched = patch_config_converters(COLLECTOR)
+    assert (
+        "\t\t\tConverterFactories: assembly.ConfigConverterFactories([]confmap.ConverterFactory{\n"
+        "\t\t\t\tconfmap.NewConverterFactory(disablequeuedretryconverter.New),\n"
+        "\t\t\t}...),\n"
+        "\t\t},\n"
+    ) in patched
+    with pytest.raises(UpstreamPatchError, match="upstream already calls assembly.ConfigConverterFactories"):
+        patch_config_converters(patched)
+    # Both lists are wrapped when the upstream file is patched.
+    both = patch_config_converters(patch_config_providers(COLLECTOR))
+    assert "assembly.ConfigProviderFactories(" in both
+    assert "assembly.ConfigConverterFactories(" in both
+    assert both.count(ASSEMBLY_IMPORT) == 1
+
+
+def test_patch_config_converters_requires_the_field():
+    with pytest.raises(UpstreamPatchError):
+        patch_config_converters(COLLECTOR.replace("ConverterFactories:", "Converters:"))
+
+
+def write_upstream(tmp_path):
+    manager = tmp_path / MANAGER_PATH
+    manager.parent.mkdir(parents=True)
+    manager.write_text(MANAGER)
+    collector = tmp_path / COLLECTOR_PATH
+    collector.parent.mkdir(parents=True)
+    collector.write_text(COLLECTOR)
+    return manager, collector
+
+
+def test_apply_upstream_patches(tmp_path):
+    manager, collector = write_upstream(tmp_path)
+
+    assert apply_upstream_patches(tmp_path, ["lambdacomponents.all"], COMMIT, None) == []
+    assert manager.read_text() == MANAGER
+
+    assert apply_upstream_patches(tmp_path, ["lambdacomponents.custom"], COMMIT, COMMIT) == [
+        str(MANAGER_PATH),
+        str(COLLECTOR_PATH),
+    ]
+    patched = manager.read_text()
+    assert "componentsErr" in patched
+    assert "assembly.Invoke(" in patched
+    assert "assembly.Shutdown(" in patched
+    assert "assembly.ConfigProviderFactories(" in collector.read_text()
+    assert "assembly.ConfigConverterFactories(" in collector.read_text()
+
+
+def test_apply_upstream_patches_requires_the_pinned_commit(tmp_path):
+    manager, _ = write_upstream(tmp_path)
+    with pytest.raises(UpstreamPatchError, match="no upstream commit is pinned"):
+        apply_upstream_patches(tmp_path, ["lambdacomponents.custom"], COMMIT, None)
+    with pytest.raises(UpstreamPatchError, match=f"checked against upstream commit {COMMIT}, not fedcba"):
+        apply_upstream_patches(tmp_path, ["lambdacomponents.custom"], "fedcba", COMMIT)
+    assert manager.read_text() == MANAGER
+
+
+def test_apply_upstream_patches_writes_nothing_on_failure(tmp_path):
+    manager, collector = write_upstream(tmp_path)
+    collector.write_text(COLLECTOR.replace("ConverterFactories:", "Converters:"))
+    with pytest.raises(UpstreamPatchError, match=str(COLLECTOR_PATH)):
+        apply_upstream_patches(tmp_path, ["lambdacomponents.custom"], COMMIT, COMMIT)
+    assert manager.read_text() == MANAGER
+
+
+def test_apply_upstream_patches_requires_the_files(tmp_path):
+    with pytest.raises(UpstreamPatchError):
+        apply_upstream_patches(tmp_path, ["lambdacomponents.custom"], COMMIT, COMMIT)
+
+
+def test_pinned_commit(tmp_path):
+    pin_file = tmp_path / "upstream.yaml"
+    assert read_pinned_commit(pin_file) is None
+    write_pinned_commit(pin_file, COMMIT)
+    assert read_pinned_commit(pin_file) == COMMIT
+    assert pin_file.read_text().startswith("# The upstream commit")